@@ -0,0 +1,19 @@
+package azure
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// SchemaSubscriptionIdOverride returns the schema for the optional `subscription_id` field, which allows a Resource
+// to be managed within a different Subscription to the one configured in the Provider block - when unspecified this
+// is the Provider's Subscription
+func SchemaSubscriptionIdOverride() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.IsUUID,
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/recorder"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
 )
 
@@ -51,6 +52,9 @@ type TestData struct {
 
 	// resourceLabel is the local used for the resource - generally "test""
 	resourceLabel string
+
+	// recorder records/replays the requests made during this test - or nil when running against Azure
+	recorder *recorder.Recorder
 }
 
 // BuildTestData generates some test data for the given resource
@@ -62,9 +66,15 @@ func BuildTestData(t *testing.T, resourceType string, resourceLabel string) Test
 		t.Fatalf("Error retrieving Environment: %+v", err)
 	}
 
+	// when recording/replaying, the random values and locations are stored in the cassette
+	// so that the same requests are made when replaying
+	rec := recorder.Start(t)
+
 	testData := TestData{
-		RandomInteger:   RandTimeInt(),
-		RandomString:    acctest.RandString(5),
+		RandomInteger: rec.Integer("random_integer", RandTimeInt),
+		RandomString: rec.String("random_string", func() string {
+			return acctest.RandString(5)
+		}),
 		ResourceName:    fmt.Sprintf("%s.%s", resourceType, resourceLabel),
 		Environment:     *env,
 		EnvironmentName: EnvironmentName(),
@@ -72,17 +82,30 @@ func BuildTestData(t *testing.T, resourceType string, resourceLabel string) Test
 
 		ResourceType:  resourceType,
 		resourceLabel: resourceLabel,
+		recorder:      rec,
 	}
 
+	var locations Regions
 	if features.UseDynamicTestLocations() {
-		testData.Locations = availableLocations()
+		locations = availableLocations()
 	} else {
-		testData.Locations = Regions{
+		locations = Regions{
 			Primary:   os.Getenv("ARM_TEST_LOCATION"),
 			Secondary: os.Getenv("ARM_TEST_LOCATION_ALT"),
 			Ternary:   os.Getenv("ARM_TEST_LOCATION_ALT2"),
 		}
 	}
+	testData.Locations = Regions{
+		Primary: rec.String("location_primary", func() string {
+			return locations.Primary
+		}),
+		Secondary: rec.String("location_secondary", func() string {
+			return locations.Secondary
+		}),
+		Ternary: rec.String("location_ternary", func() string {
+			return locations.Ternary
+		}),
+	}
 
 	return testData
 }
@@ -122,5 +145,7 @@ func (td *TestData) RandomStringOfLength(len int) string {
 		panic("Invalid Test: RandomStringOfLength: length argument must be between 1 and 1024 characters")
 	}
 
-	return acctest.RandString(len)
+	return td.recorder.String(fmt.Sprintf("random_string_of_length_%d", len), func() string {
+		return acctest.RandString(len)
+	})
 }
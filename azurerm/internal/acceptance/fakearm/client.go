@@ -0,0 +1,40 @@
+package fakearm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-helpers/authentication"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
+)
+
+// Client returns a Client which sends all requests to this Server, which can be passed to the Exists
+// functions used in the Acceptance Tests - or used by the Acceptance Test helpers via `testclient.Use`
+func (s *Server) Client() (*clients.Client, error) {
+	builder := authentication.Builder{
+		SubscriptionID: SubscriptionId,
+		ClientID:       ClientId,
+		TenantID:       TenantId,
+		ClientSecret:   "fake-client-secret",
+		Environment:    "public",
+
+		SupportsClientSecretAuth: true,
+	}
+	config, err := builder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("building authentication config: %+v", err)
+	}
+
+	clientBuilder := clients.ClientBuilder{
+		AuthConfig:               config,
+		SkipProviderRegistration: true,
+		TerraformVersion:         "0.0.0",
+		Features:                 features.Default(),
+		SendDecorators: []autorest.SendDecorator{
+			s.SendDecorator(),
+		},
+	}
+	return clients.Build(context.TODO(), clientBuilder)
+}
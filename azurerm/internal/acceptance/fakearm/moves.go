@@ -0,0 +1,84 @@
+package fakearm
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// isMoveAction returns whether the action is one of the actions used to move Resources between Resource Groups
+func isMoveAction(resourceSegments []string, actionName string) bool {
+	if !isResourceGroup(resourceSegments) {
+		return false
+	}
+
+	return strings.EqualFold(actionName, "moveResources") || strings.EqualFold(actionName, "validateMoveResources")
+}
+
+// moveResources validates (and, unless `validateOnly` is set, completes) the move of the Resources within the
+// request body from the Resource Group `resourceGroupId` into the `targetResourceGroup` - along with any Resources
+// nested within them
+func (s *Server) moveResources(w http.ResponseWriter, r *http.Request, resourceGroupId string, validateOnly bool) error {
+	body, err := readJson(r)
+	if err != nil {
+		return err
+	}
+
+	targetResourceGroupId, _ := body["targetResourceGroup"].(string)
+	if _, exists := s.resources[strings.ToLower(targetResourceGroupId)]; !exists {
+		return &Error{
+			StatusCode: http.StatusNotFound,
+			Code:       "ResourceGroupNotFound",
+			Message:    fmt.Sprintf("Resource group %q could not be found.", targetResourceGroupId),
+		}
+	}
+
+	rawIds, _ := body["resources"].([]interface{})
+	if len(rawIds) == 0 {
+		return &Error{
+			StatusCode: http.StatusBadRequest,
+			Code:       "InvalidResourceMoveRequest",
+			Message:    "The list of resources in the move definition cannot be null or empty.",
+		}
+	}
+
+	ids := make([]string, 0)
+	for _, v := range rawIds {
+		id, _ := v.(string)
+		if !strings.HasPrefix(strings.ToLower(id), strings.ToLower(resourceGroupId)+"/") {
+			return &Error{
+				StatusCode: http.StatusBadRequest,
+				Code:       "InvalidResourceMoveRequest",
+				Message:    fmt.Sprintf("The resource %q is not within the source resource group %q.", id, resourceGroupId),
+			}
+		}
+		if _, exists := s.resources[strings.ToLower(id)]; !exists {
+			return NewNotFoundError(id)
+		}
+		ids = append(ids, id)
+	}
+
+	if !validateOnly {
+		for _, id := range ids {
+			s.moveResource(id, targetResourceGroupId+id[len(resourceGroupId):])
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Server) moveResource(from string, to string) {
+	fromKey := strings.ToLower(from)
+	for k, v := range s.resources {
+		if k != fromKey && !strings.HasPrefix(k, fromKey+"/") {
+			continue
+		}
+
+		delete(s.resources, k)
+		if id, ok := v["id"].(string); ok {
+			v["id"] = to + id[len(from):]
+		}
+		s.resources[strings.ToLower(to)+k[len(fromKey):]] = v
+	}
+}
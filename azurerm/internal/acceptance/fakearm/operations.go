@@ -0,0 +1,121 @@
+package fakearm
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// operationsPath is the path used for the Long Running Operations started by the Server
+const operationsPath = "/fakearm/operations/"
+
+type operation struct {
+	id     string
+	method string
+
+	// resourceKey is the (lower-cased) ID of the Resource being created, updated or deleted
+	resourceKey string
+
+	longRunningOperationType LongRunningOperationType
+	pollsUntilCompleted      int
+	retryAfter               int
+	polls                    int
+	completed                bool
+}
+
+func (s *Server) startOperation(r *http.Request, resourceKey string, resourceProvider ResourceProvider) *operation {
+	op := &operation{
+		id:                       fmt.Sprintf("%d", len(s.operations)+1),
+		method:                   r.Method,
+		resourceKey:              resourceKey,
+		longRunningOperationType: resourceProvider.LongRunningOperations,
+		pollsUntilCompleted:      resourceProvider.PollsUntilCompleted,
+		retryAfter:               resourceProvider.RetryAfter,
+	}
+	if op.pollsUntilCompleted < 1 {
+		op.pollsUntilCompleted = 1
+	}
+
+	s.operations[op.id] = op
+	return op
+}
+
+// writeOperationAccepted writes the response for a request which has started a Long Running Operation
+func (s *Server) writeOperationAccepted(w http.ResponseWriter, r *http.Request, op *operation, resource map[string]interface{}) {
+	uri := fmt.Sprintf("%s%s%s", baseUri(r), operationsPath, op.id)
+
+	// poll the operation immediately (unless configured otherwise), rather than waiting for the default polling delay
+	w.Header().Set("Retry-After", strconv.Itoa(op.retryAfter))
+
+	if op.longRunningOperationType == LongRunningOperationAzureAsyncOperation {
+		w.Header().Set("Azure-AsyncOperation", uri)
+
+		if resource != nil {
+			writeJson(w, http.StatusCreated, resource)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	w.Header().Set("Location", uri)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) pollOperation(w http.ResponseWriter, r *http.Request, id string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	op, ok := s.operations[id]
+	if !ok {
+		writeError(w, NewNotFoundError(r.URL.Path))
+		return
+	}
+
+	op.polls++
+	if !op.completed && op.polls >= op.pollsUntilCompleted {
+		s.completeOperation(op)
+	}
+
+	w.Header().Set("Retry-After", strconv.Itoa(op.retryAfter))
+
+	if op.longRunningOperationType == LongRunningOperationAzureAsyncOperation {
+		status := "InProgress"
+		if op.completed {
+			status = "Succeeded"
+		}
+
+		writeJson(w, http.StatusOK, map[string]interface{}{
+			"id":     r.URL.Path,
+			"name":   op.id,
+			"status": status,
+		})
+		return
+	}
+
+	if !op.completed {
+		w.Header().Set("Location", fmt.Sprintf("%s%s%s", baseUri(r), operationsPath, op.id))
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	if resource, ok := s.resources[op.resourceKey]; ok && !strings.EqualFold(op.method, http.MethodDelete) {
+		writeJson(w, http.StatusOK, resource)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) completeOperation(op *operation) {
+	op.completed = true
+
+	if strings.EqualFold(op.method, http.MethodDelete) {
+		s.deleteResource(op.resourceKey)
+		return
+	}
+
+	if resource, ok := s.resources[op.resourceKey]; ok {
+		setProvisioningState(resource, "Succeeded")
+	}
+}
@@ -0,0 +1,84 @@
+package fakearm
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type LongRunningOperationType string
+
+const (
+	// LongRunningOperationNone completes Create/Update and Delete requests synchronously
+	LongRunningOperationNone LongRunningOperationType = ""
+
+	// LongRunningOperationAzureAsyncOperation completes Create/Update and Delete requests asynchronously,
+	// returning the URI of the Operation in the `Azure-AsyncOperation` header
+	LongRunningOperationAzureAsyncOperation LongRunningOperationType = "Azure-AsyncOperation"
+
+	// LongRunningOperationLocation completes Create/Update and Delete requests asynchronously,
+	// returning the URI of the Operation Result in the `Location` header
+	LongRunningOperationLocation LongRunningOperationType = "Location"
+)
+
+// ResourceFunc is called with the ID of a Resource, the existing Resource (which is nil when the
+// Resource doesn't exist) and the Resource which is going to be stored (or is nil when deleting)
+type ResourceFunc func(id string, existing, resource map[string]interface{}) error
+
+// ActionFunc is called for a POST request to an action on an existing Resource (for example `listKeys`)
+// and returns the value which should be returned in the response
+type ActionFunc func(id string, resource map[string]interface{}, body map[string]interface{}) (interface{}, error)
+
+// ResourceProvider allows the behaviour of the Resources within a Resource Provider to be customised
+type ResourceProvider struct {
+	// LongRunningOperations specifies how Create/Update and Delete requests are completed
+	LongRunningOperations LongRunningOperationType
+
+	// PollsUntilCompleted is the number of times a Long Running Operation is polled before it
+	// completes - which defaults to 1
+	PollsUntilCompleted int
+
+	// RetryAfter is the number of seconds returned in the `Retry-After` header whilst a Long Running
+	// Operation is in progress - which defaults to 0 so that the Operation is polled immediately
+	RetryAfter int
+
+	// OnCreateOrUpdate is called before a Resource is created/updated (via a PUT or PATCH request),
+	// and can modify the Resource being stored (e.g. to set read-only properties) or return an
+	// error (such as an *Error) to reject the request
+	OnCreateOrUpdate ResourceFunc
+
+	// OnDelete is called before a Resource is deleted and can return an error (such as an *Error)
+	// to reject the request
+	OnDelete ResourceFunc
+
+	// Actions are called for POST requests to the specified (case-insensitive) action on a Resource
+	Actions map[string]ActionFunc
+}
+
+// Error is an error returned from the API in the same format as Azure Resource Manager
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// NewNotFoundError returns an Error for a Resource which doesn't exist
+func NewNotFoundError(id string) *Error {
+	return &Error{
+		StatusCode: http.StatusNotFound,
+		Code:       "ResourceNotFound",
+		Message:    fmt.Sprintf("The Resource %q was not found.", id),
+	}
+}
+
+func (e Error) body() map[string]interface{} {
+	return map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    e.Code,
+			"message": e.Message,
+		},
+	}
+}
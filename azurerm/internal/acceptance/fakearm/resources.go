@@ -0,0 +1,494 @@
+package fakearm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/resourceproviders"
+)
+
+const defaultNamespace = "Microsoft.Resources"
+
+const managementLocksSegment = "/providers/microsoft.authorization/locks/"
+
+// Resource returns a copy of the Resource with the specified ID, if it exists
+func (s *Server) Resource(id string) (map[string]interface{}, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	existing, ok := s.resources[strings.ToLower(id)]
+	if !ok {
+		return nil, false
+	}
+	return copyResource(existing), true
+}
+
+// SetResource creates (or replaces) the Resource with the specified ID, without calling
+// any behaviours registered for the Resource Provider
+func (s *Server) SetResource(id string, resource map[string]interface{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	resource = copyResource(resource)
+	normalizeResource(splitPath(id), resource, "Succeeded")
+	s.resources[strings.ToLower(id)] = resource
+}
+
+func (s *Server) resourceManager(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("api-version") == "" {
+		writeError(w, &Error{
+			StatusCode: http.StatusBadRequest,
+			Code:       "MissingApiVersionParameter",
+			Message:    "The api-version query parameter (?api-version=) is required for all requests.",
+		})
+		return
+	}
+
+	segments := splitPath(r.URL.Path)
+	if len(segments) == 0 {
+		writeError(w, NewNotFoundError(r.URL.Path))
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if isResourceProvidersPath(segments) {
+		s.resourceProviderRegistration(w, r, segments)
+		return
+	}
+
+	// Resource ID's are made up of key/value pairs - where a path with an odd number of segments is a list
+	isList := len(segments)%2 == 1
+
+	var err error
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		if isList {
+			err = s.list(w, segments)
+		} else {
+			err = s.get(w, r, segments)
+		}
+
+	case http.MethodPut, http.MethodPatch:
+		if isList {
+			err = methodNotAllowed(r)
+		} else {
+			err = s.createOrUpdate(w, r, segments)
+		}
+
+	case http.MethodDelete:
+		if isList {
+			err = methodNotAllowed(r)
+		} else {
+			err = s.delete(w, r, segments)
+		}
+
+	case http.MethodPost:
+		if isList {
+			err = s.action(w, r, segments)
+		} else {
+			err = methodNotAllowed(r)
+		}
+
+	default:
+		err = methodNotAllowed(r)
+	}
+
+	if err != nil {
+		writeError(w, err)
+	}
+}
+
+func (s *Server) get(w http.ResponseWriter, r *http.Request, segments []string) error {
+	id := resourceId(segments)
+	existing, ok := s.resources[strings.ToLower(id)]
+	if !ok {
+		return notFoundError(segments)
+	}
+
+	if r.Method == http.MethodHead {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+
+	writeJson(w, http.StatusOK, existing)
+	return nil
+}
+
+func (s *Server) list(w http.ResponseWriter, segments []string) error {
+	prefix := strings.ToLower(resourceId(segments)) + "/"
+
+	keys := make([]string, 0)
+	for key := range s.resources {
+		if strings.HasPrefix(key, prefix) && !strings.Contains(strings.TrimPrefix(key, prefix), "/") {
+			keys = append(keys, key)
+			continue
+		}
+
+		// Management Locks are listed for the Scope and everything beneath it
+		if strings.HasSuffix(prefix, managementLocksSegment) {
+			scope := strings.TrimSuffix(prefix, managementLocksSegment)
+			if strings.Contains(key, managementLocksSegment) && strings.HasPrefix(key, scope+"/") {
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	values := make([]interface{}, 0)
+	for _, key := range keys {
+		values = append(values, s.resources[key])
+	}
+
+	writeJson(w, http.StatusOK, map[string]interface{}{
+		"value": values,
+	})
+	return nil
+}
+
+func (s *Server) createOrUpdate(w http.ResponseWriter, r *http.Request, segments []string) error {
+	id := resourceId(segments)
+	key := strings.ToLower(id)
+
+	body, err := readJson(r)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkParentExists(segments); err != nil {
+		return err
+	}
+
+	existing, exists := s.resources[key]
+	resource := body
+	if r.Method == http.MethodPatch {
+		if !exists {
+			return notFoundError(segments)
+		}
+		resource = mergeResource(copyResource(existing), body)
+	}
+
+	resourceProvider := s.resourceProviders[strings.ToLower(namespaceForResource(segments))]
+	if resourceProvider.OnCreateOrUpdate != nil {
+		if err := resourceProvider.OnCreateOrUpdate(id, copyResource(existing), resource); err != nil {
+			return err
+		}
+	}
+
+	if resourceProvider.LongRunningOperations == LongRunningOperationNone {
+		normalizeResource(segments, resource, "Succeeded")
+		s.resources[key] = copyResource(resource)
+
+		statusCode := http.StatusCreated
+		if exists {
+			statusCode = http.StatusOK
+		}
+		writeJson(w, statusCode, resource)
+		return nil
+	}
+
+	provisioningState := "Creating"
+	if exists {
+		provisioningState = "Updating"
+	}
+	normalizeResource(segments, resource, provisioningState)
+	s.resources[key] = copyResource(resource)
+
+	op := s.startOperation(r, key, resourceProvider)
+	s.writeOperationAccepted(w, r, op, resource)
+	return nil
+}
+
+func (s *Server) delete(w http.ResponseWriter, r *http.Request, segments []string) error {
+	id := resourceId(segments)
+	key := strings.ToLower(id)
+
+	existing, exists := s.resources[key]
+	if !exists {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+
+	resourceProvider := s.resourceProviders[strings.ToLower(namespaceForResource(segments))]
+	if resourceProvider.OnDelete != nil {
+		if err := resourceProvider.OnDelete(id, copyResource(existing), nil); err != nil {
+			return err
+		}
+	}
+
+	if resourceProvider.LongRunningOperations == LongRunningOperationNone {
+		s.deleteResource(key)
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+
+	setProvisioningState(existing, "Deleting")
+	op := s.startOperation(r, key, resourceProvider)
+	s.writeOperationAccepted(w, r, op, nil)
+	return nil
+}
+
+// deleteResource deletes the specified Resource along with any nested Resources
+func (s *Server) deleteResource(key string) {
+	for k := range s.resources {
+		if k == key || strings.HasPrefix(k, key+"/") {
+			delete(s.resources, k)
+		}
+	}
+}
+
+func (s *Server) action(w http.ResponseWriter, r *http.Request, segments []string) error {
+	resourceSegments := segments[0 : len(segments)-1]
+	actionName := segments[len(segments)-1]
+
+	id := resourceId(resourceSegments)
+	existing, exists := s.resources[strings.ToLower(id)]
+	if !exists {
+		return notFoundError(resourceSegments)
+	}
+
+	if isMoveAction(resourceSegments, actionName) {
+		return s.moveResources(w, r, id, strings.EqualFold(actionName, "validateMoveResources"))
+	}
+
+	body, err := readJson(r)
+	if err != nil {
+		return err
+	}
+
+	resourceProvider := s.resourceProviders[strings.ToLower(namespaceForResource(resourceSegments))]
+	var actionFunc ActionFunc
+	for name, v := range resourceProvider.Actions {
+		if strings.EqualFold(name, actionName) {
+			actionFunc = v
+		}
+	}
+	if actionFunc == nil {
+		return &Error{
+			StatusCode: http.StatusNotFound,
+			Code:       "NotFound",
+			Message:    fmt.Sprintf("The action %q is not supported for %q.", actionName, id),
+		}
+	}
+
+	result, err := actionFunc(id, copyResource(existing), body)
+	if err != nil {
+		return err
+	}
+
+	if result == nil {
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+	writeJson(w, http.StatusOK, result)
+	return nil
+}
+
+// checkParentExists confirms that the Resource Group and/or Parent Resource containing the Resource exists
+func (s *Server) checkParentExists(segments []string) error {
+	parent := parentSegments(segments)
+
+	// Subscriptions (and the Tenant) are assumed to exist
+	if len(parent) <= 2 {
+		return nil
+	}
+	if _, exists := s.resources[strings.ToLower(resourceId(parent))]; exists {
+		return nil
+	}
+
+	if isResourceGroup(parent) {
+		return &Error{
+			StatusCode: http.StatusNotFound,
+			Code:       "ResourceGroupNotFound",
+			Message:    fmt.Sprintf("Resource group '%s' could not be found.", parent[3]),
+		}
+	}
+
+	return &Error{
+		StatusCode: http.StatusNotFound,
+		Code:       "ParentResourceNotFound",
+		Message:    fmt.Sprintf("Can not perform requested operation on nested resource. Parent resource '%s' not found.", resourceId(parent)),
+	}
+}
+
+// resourceProviderRegistration handles listing, retrieving and registering Resource Providers - all of
+// which are treated as being registered
+func (s *Server) resourceProviderRegistration(w http.ResponseWriter, r *http.Request, segments []string) {
+	subscriptionId := segments[1]
+
+	if len(segments) == 3 {
+		namespaces := make([]string, 0)
+		for namespace := range resourceproviders.Required() {
+			namespaces = append(namespaces, namespace)
+		}
+		for namespace := range s.resourceProviders {
+			namespaces = append(namespaces, namespace)
+		}
+		sort.Strings(namespaces)
+
+		values := make([]interface{}, 0)
+		for _, namespace := range namespaces {
+			values = append(values, resourceProvider(subscriptionId, namespace))
+		}
+
+		writeJson(w, http.StatusOK, map[string]interface{}{
+			"value": values,
+		})
+		return
+	}
+
+	writeJson(w, http.StatusOK, resourceProvider(subscriptionId, segments[3]))
+}
+
+func resourceProvider(subscriptionId, namespace string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                fmt.Sprintf("/subscriptions/%s/providers/%s", subscriptionId, namespace),
+		"namespace":         namespace,
+		"registrationState": "Registered",
+	}
+}
+
+// isResourceProvidersPath returns whether the path is to the list of Resource Providers within a
+// Subscription, a single Resource Provider, or to register/unregister a Resource Provider
+func isResourceProvidersPath(segments []string) bool {
+	if len(segments) < 3 || len(segments) > 5 {
+		return false
+	}
+	if !strings.EqualFold(segments[0], "subscriptions") || !strings.EqualFold(segments[2], "providers") {
+		return false
+	}
+
+	if len(segments) == 5 {
+		return strings.EqualFold(segments[4], "register") || strings.EqualFold(segments[4], "unregister")
+	}
+	return true
+}
+
+func splitPath(path string) []string {
+	segments := make([]string, 0)
+	for _, v := range strings.Split(path, "/") {
+		if v != "" {
+			segments = append(segments, v)
+		}
+	}
+	return segments
+}
+
+func resourceId(segments []string) string {
+	return "/" + strings.Join(segments, "/")
+}
+
+func isResourceGroup(segments []string) bool {
+	return len(segments) == 4 && strings.EqualFold(segments[0], "subscriptions") && strings.EqualFold(segments[2], "resourceGroups")
+}
+
+// parentSegments returns the segments of the Parent Resource, Resource Group or Subscription containing a Resource
+func parentSegments(segments []string) []string {
+	parent := segments[0 : len(segments)-2]
+	if len(parent) >= 2 && strings.EqualFold(parent[len(parent)-2], "providers") {
+		parent = parent[0 : len(parent)-2]
+	}
+	return parent
+}
+
+// namespaceForResource returns the Namespace of the Resource Provider for the Resource, e.g. `Microsoft.Network`
+func namespaceForResource(segments []string) string {
+	namespace, _ := resourceTypeForResource(segments)
+	return namespace
+}
+
+// resourceTypeForResource returns the Namespace of the Resource Provider and the Resource Type,
+// for example `Microsoft.Network` and `Microsoft.Network/virtualNetworks/subnets`
+func resourceTypeForResource(segments []string) (string, string) {
+	namespace := defaultNamespace
+	start := 2
+	for i := len(segments) - 2; i >= 0; i -= 2 {
+		if strings.EqualFold(segments[i], "providers") {
+			namespace = segments[i+1]
+			start = i + 2
+			break
+		}
+	}
+
+	types := []string{namespace}
+	for i := start; i < len(segments); i += 2 {
+		types = append(types, segments[i])
+	}
+	return namespace, strings.Join(types, "/")
+}
+
+func notFoundError(segments []string) error {
+	if isResourceGroup(segments) {
+		return &Error{
+			StatusCode: http.StatusNotFound,
+			Code:       "ResourceGroupNotFound",
+			Message:    fmt.Sprintf("Resource group '%s' could not be found.", segments[3]),
+		}
+	}
+
+	return NewNotFoundError(resourceId(segments))
+}
+
+func methodNotAllowed(r *http.Request) error {
+	return &Error{
+		StatusCode: http.StatusMethodNotAllowed,
+		Code:       "MethodNotAllowed",
+		Message:    fmt.Sprintf("The %s method is not supported for %q.", r.Method, r.URL.Path),
+	}
+}
+
+// normalizeResource sets the ID, Name, Type and Provisioning State of the Resource
+func normalizeResource(segments []string, resource map[string]interface{}, provisioningState string) {
+	_, resourceType := resourceTypeForResource(segments)
+
+	resource["id"] = resourceId(segments)
+	resource["name"] = segments[len(segments)-1]
+	resource["type"] = resourceType
+	setProvisioningState(resource, provisioningState)
+}
+
+func setProvisioningState(resource map[string]interface{}, provisioningState string) {
+	properties, ok := resource["properties"].(map[string]interface{})
+	if !ok {
+		properties = map[string]interface{}{}
+		resource["properties"] = properties
+	}
+	properties["provisioningState"] = provisioningState
+}
+
+// mergeResource merges the fields in the PATCH body into the existing Resource
+func mergeResource(existing, patch map[string]interface{}) map[string]interface{} {
+	for k, v := range patch {
+		existingValue, existingIsMap := existing[k].(map[string]interface{})
+		patchValue, patchIsMap := v.(map[string]interface{})
+		if existingIsMap && patchIsMap {
+			existing[k] = mergeResource(existingValue, patchValue)
+			continue
+		}
+
+		existing[k] = v
+	}
+
+	return existing
+}
+
+func copyResource(input map[string]interface{}) map[string]interface{} {
+	if input == nil {
+		return nil
+	}
+
+	contents, err := json.Marshal(input)
+	if err != nil {
+		panic(fmt.Sprintf("serializing resource: %+v", err))
+	}
+
+	output := map[string]interface{}{}
+	if err := json.Unmarshal(contents, &output); err != nil {
+		panic(fmt.Sprintf("deserializing resource: %+v", err))
+	}
+	return output
+}
@@ -0,0 +1,206 @@
+package fakearm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const (
+	// SubscriptionId is the ID of the Subscription used by the Client returned from the Server
+	SubscriptionId = "00000000-0000-0000-0000-000000000000"
+
+	// TenantId is the ID of the Tenant used by the Client returned from the Server
+	TenantId = "00000000-0000-0000-0000-000000000001"
+
+	// ClientId is the ID of the Service Principal used by the Client returned from the Server
+	ClientId = "00000000-0000-0000-0000-000000000002"
+
+	// ObjectId is the Object ID returned for the Service Principal used by the Client
+	ObjectId = "00000000-0000-0000-0000-000000000003"
+)
+
+var (
+	tokenPathRegex             = regexp.MustCompile(`(?i)^/[^/]+/oauth2(/v2\.0)?/token$`)
+	servicePrincipalsPathRegex = regexp.MustCompile(`(?i)^/[^/]+/servicePrincipals$`)
+)
+
+// Server is an in-memory fake of Azure Resource Manager (and the Azure Active Directory endpoints used to
+// authenticate) which provides generic Create/Read/Update/Delete semantics for any Resource ID, so that
+// the functionality using Resource Manager (such as the Exists functions used in the Acceptance Tests)
+// can be tested without access to Azure.
+//
+// Requests are sent to the Server through the SendDecorator (which is used by the Client), meaning that
+// the Server is used for requests to any host - and as such any Azure Environment.
+type Server struct {
+	lock sync.Mutex
+
+	// resources are the Resources which exist, keyed by the lower-cased Resource ID
+	resources map[string]map[string]interface{}
+
+	// operations are the Long Running Operations which have been started, keyed by ID
+	operations map[string]*operation
+
+	// resourceProviders is the behaviour of each Resource Provider, keyed by the lower-cased Namespace
+	resourceProviders map[string]ResourceProvider
+}
+
+func NewServer() *Server {
+	return &Server{
+		resources:         map[string]map[string]interface{}{},
+		operations:        map[string]*operation{},
+		resourceProviders: map[string]ResourceProvider{},
+	}
+}
+
+// RegisterResourceProvider customises the behaviour of the Resources within the specified
+// Resource Provider (for example `Microsoft.Network`)
+func (s *Server) RegisterResourceProvider(namespace string, resourceProvider ResourceProvider) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.resourceProviders[strings.ToLower(namespace)] = resourceProvider
+}
+
+// SendDecorator returns a SendDecorator which sends all requests to this Server, rather than to Azure
+func (s *Server) SendDecorator() autorest.SendDecorator {
+	return func(_ autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(s.send)
+	}
+}
+
+func (s *Server) send(r *http.Request) (*http.Response, error) {
+	if err := r.Context().Err(); err != nil {
+		return nil, err
+	}
+
+	recorder := httptest.NewRecorder()
+	s.ServeHTTP(recorder, r)
+
+	resp := recorder.Result()
+	resp.Request = r
+	return resp, nil
+}
+
+// ServeHTTP handles a request to the Server - which allows the Server to also be hosted using an httptest.Server
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+
+	switch {
+	case tokenPathRegex.MatchString(path):
+		s.token(w, r)
+
+	case servicePrincipalsPathRegex.MatchString(path):
+		writeJson(w, http.StatusOK, map[string]interface{}{
+			"value": []interface{}{
+				map[string]interface{}{
+					"objectId":   ObjectId,
+					"objectType": "ServicePrincipal",
+					"appId":      ClientId,
+				},
+			},
+		})
+
+	case strings.HasPrefix(path, operationsPath):
+		s.pollOperation(w, r, strings.TrimPrefix(path, operationsPath))
+
+	default:
+		s.resourceManager(w, r)
+	}
+}
+
+// token returns an access token for the resource specified in the request
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, &Error{
+			StatusCode: http.StatusBadRequest,
+			Code:       "invalid_request",
+			Message:    err.Error(),
+		})
+		return
+	}
+
+	now := time.Now()
+	writeJson(w, http.StatusOK, map[string]interface{}{
+		"access_token": "fake-access-token",
+		"expires_in":   "3600",
+		"expires_on":   strconv.FormatInt(now.Add(time.Hour).Unix(), 10),
+		"not_before":   strconv.FormatInt(now.Unix(), 10),
+		"resource":     r.PostForm.Get("resource"),
+		"token_type":   "Bearer",
+	})
+}
+
+func writeJson(w http.ResponseWriter, statusCode int, body interface{}) {
+	contents, err := json.Marshal(body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("serializing response: %+v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Content-Length", strconv.Itoa(len(contents)))
+	w.WriteHeader(statusCode)
+	w.Write(contents) // nolint: errcheck
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	if v, ok := err.(*Error); ok {
+		writeJson(w, v.StatusCode, v.body())
+		return
+	}
+
+	writeError(w, &Error{
+		StatusCode: http.StatusInternalServerError,
+		Code:       "InternalServerError",
+		Message:    err.Error(),
+	})
+}
+
+func readJson(r *http.Request) (map[string]interface{}, error) {
+	output := map[string]interface{}{}
+	if r.Body == nil {
+		return output, nil
+	}
+
+	buf := new(bytes.Buffer)
+	if _, err := buf.ReadFrom(r.Body); err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(buf.Bytes())) == 0 {
+		return output, nil
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+		return nil, &Error{
+			StatusCode: http.StatusBadRequest,
+			Code:       "InvalidRequestContent",
+			Message:    fmt.Sprintf("The request content was invalid and could not be deserialized: %+v", err),
+		}
+	}
+
+	return output, nil
+}
+
+// baseUri returns the scheme and host the request was sent to, which is used to build
+// the URIs of any Long Running Operations
+func baseUri(r *http.Request) string {
+	if r.URL.Host != "" {
+		return fmt.Sprintf("%s://%s", r.URL.Scheme, r.URL.Host)
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s", scheme, r.Host)
+}
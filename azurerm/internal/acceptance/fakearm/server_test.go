@@ -0,0 +1,347 @@
+package fakearm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/helpers"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+const virtualNetworksApiVersion = "2020-05-01"
+
+type resourceGroupResource struct{}
+
+func (resourceGroupResource) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	name := state.Attributes["name"]
+
+	resp, err := client.Resource.GroupsClient.Get(ctx, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving Resource Group %q: %+v", name, err)
+	}
+
+	return utils.Bool(resp.Properties != nil), nil
+}
+
+func TestResourceTypeForResource(t *testing.T) {
+	testData := []struct {
+		Name              string
+		Input             string
+		ExpectedNamespace string
+		ExpectedType      string
+		ExpectedParent    string
+	}{
+		{
+			Name:              "Resource Group",
+			Input:             "/subscriptions/1234/resourceGroups/example",
+			ExpectedNamespace: "Microsoft.Resources",
+			ExpectedType:      "Microsoft.Resources/resourceGroups",
+			ExpectedParent:    "/subscriptions/1234",
+		},
+		{
+			Name:              "Resource",
+			Input:             "/subscriptions/1234/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/network1",
+			ExpectedNamespace: "Microsoft.Network",
+			ExpectedType:      "Microsoft.Network/virtualNetworks",
+			ExpectedParent:    "/subscriptions/1234/resourceGroups/example",
+		},
+		{
+			Name:              "Nested Resource",
+			Input:             "/subscriptions/1234/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1",
+			ExpectedNamespace: "Microsoft.Network",
+			ExpectedType:      "Microsoft.Network/virtualNetworks/subnets",
+			ExpectedParent:    "/subscriptions/1234/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/network1",
+		},
+		{
+			Name:              "Extension Resource",
+			Input:             "/subscriptions/1234/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/network1/providers/Microsoft.Authorization/locks/lock1",
+			ExpectedNamespace: "Microsoft.Authorization",
+			ExpectedType:      "Microsoft.Authorization/locks",
+			ExpectedParent:    "/subscriptions/1234/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/network1",
+		},
+		{
+			Name:              "Tenant Level",
+			Input:             "/providers/Microsoft.Management/managementGroups/group1",
+			ExpectedNamespace: "Microsoft.Management",
+			ExpectedType:      "Microsoft.Management/managementGroups",
+			ExpectedParent:    "/",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		segments := splitPath(v.Input)
+		namespace, resourceType := resourceTypeForResource(segments)
+		if namespace != v.ExpectedNamespace {
+			t.Fatalf("Expected the Namespace to be %q but got %q", v.ExpectedNamespace, namespace)
+		}
+		if resourceType != v.ExpectedType {
+			t.Fatalf("Expected the Type to be %q but got %q", v.ExpectedType, resourceType)
+		}
+		if parent := resourceId(parentSegments(segments)); parent != v.ExpectedParent {
+			t.Fatalf("Expected the Parent to be %q but got %q", v.ExpectedParent, parent)
+		}
+	}
+}
+
+func TestServerResourceGroupLifecycle(t *testing.T) {
+	server := NewServer()
+	server.RegisterResourceProvider("Microsoft.Resources", ResourceProvider{
+		LongRunningOperations: LongRunningOperationAzureAsyncOperation,
+		PollsUntilCompleted:   2,
+	})
+
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+	ctx := client.StopContext
+	groupsClient := client.Resource.GroupsClient
+
+	if _, err := groupsClient.CreateOrUpdate(ctx, "example", resources.Group{
+		Location: utils.String("westeurope"),
+	}); err != nil {
+		t.Fatalf("creating Resource Group: %+v", err)
+	}
+
+	state := &terraform.State{
+		Version: 3,
+		Modules: []*terraform.ModuleState{
+			{
+				Path: []string{"root"},
+				Resources: map[string]*terraform.ResourceState{
+					"azurerm_resource_group.test": {
+						Type: "azurerm_resource_group",
+						Primary: &terraform.InstanceState{
+							ID: fmt.Sprintf("/subscriptions/%s/resourceGroups/example", SubscriptionId),
+							Attributes: map[string]string{
+								"name": "example",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if err := helpers.ExistsInAzure(client, resourceGroupResource{}, "azurerm_resource_group.test")(state); err != nil {
+		t.Fatalf("checking the Resource Group exists: %+v", err)
+	}
+
+	group, err := groupsClient.Get(ctx, "EXAMPLE")
+	if err != nil {
+		t.Fatalf("retrieving Resource Group: %+v", err)
+	}
+	if group.ID == nil || *group.ID != fmt.Sprintf("/subscriptions/%s/resourcegroups/example", SubscriptionId) {
+		t.Fatalf("expected the Resource ID to be returned but got %+v", group.ID)
+	}
+
+	future, err := groupsClient.Delete(ctx, "example")
+	if err != nil {
+		t.Fatalf("deleting Resource Group: %+v", err)
+	}
+	if err := helpers.ExistsInAzure(client, resourceGroupResource{}, "azurerm_resource_group.test")(state); err != nil {
+		t.Fatalf("expected the Resource Group to exist until the deletion completes: %+v", err)
+	}
+	if err := future.WaitForCompletionRef(ctx, groupsClient.Client); err != nil {
+		t.Fatalf("waiting for the deletion of the Resource Group: %+v", err)
+	}
+
+	if err := helpers.CheckDestroyedFunc(client, resourceGroupResource{}, "azurerm_resource_group", "azurerm_resource_group.test")(state); err != nil {
+		t.Fatalf("checking the Resource Group was destroyed: %+v", err)
+	}
+}
+
+func TestServerLocationLongRunningOperations(t *testing.T) {
+	server := NewServer()
+	server.RegisterResourceProvider("Microsoft.Network", ResourceProvider{
+		LongRunningOperations: LongRunningOperationLocation,
+		PollsUntilCompleted:   3,
+	})
+
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+	ctx := client.StopContext
+	resourcesClient := client.Resource.ResourcesClient
+
+	groupId := fmt.Sprintf("/subscriptions/%s/resourceGroups/example", SubscriptionId)
+	networkId := fmt.Sprintf("%s/providers/Microsoft.Network/virtualNetworks/network1", groupId)
+	subnetId := fmt.Sprintf("%s/subnets/subnet1", networkId)
+	network := resources.GenericResource{
+		Location: utils.String("westeurope"),
+		Properties: map[string]interface{}{
+			"addressSpace": map[string]interface{}{
+				"addressPrefixes": []string{"10.0.0.0/16"},
+			},
+		},
+	}
+
+	if _, err := resourcesClient.CreateOrUpdateByID(ctx, networkId, virtualNetworksApiVersion, network); err == nil {
+		t.Fatalf("expected an error creating a Virtual Network in a Resource Group which doesn't exist")
+	} else if !strings.Contains(err.Error(), "ResourceGroupNotFound") {
+		t.Fatalf("expected a ResourceGroupNotFound error but got: %+v", err)
+	}
+
+	server.SetResource(groupId, map[string]interface{}{
+		"location": "westeurope",
+	})
+
+	createFuture, err := resourcesClient.CreateOrUpdateByID(ctx, networkId, virtualNetworksApiVersion, network)
+	if err != nil {
+		t.Fatalf("creating Virtual Network: %+v", err)
+	}
+	if err := createFuture.WaitForCompletionRef(ctx, resourcesClient.Client); err != nil {
+		t.Fatalf("waiting for creation of Virtual Network: %+v", err)
+	}
+
+	if _, err := resourcesClient.CreateOrUpdateByID(ctx, subnetId, virtualNetworksApiVersion, resources.GenericResource{}); err != nil {
+		t.Fatalf("creating Subnet: %+v", err)
+	}
+
+	existing, err := resourcesClient.GetByID(ctx, networkId, virtualNetworksApiVersion)
+	if err != nil {
+		t.Fatalf("retrieving Virtual Network: %+v", err)
+	}
+	if existing.Type == nil || *existing.Type != "Microsoft.Network/virtualNetworks" {
+		t.Fatalf("expected the Type to be Microsoft.Network/virtualNetworks but got %+v", existing.Type)
+	}
+	properties, ok := existing.Properties.(map[string]interface{})
+	if !ok || properties["provisioningState"] != "Succeeded" || properties["addressSpace"] == nil {
+		t.Fatalf("expected the Virtual Network to have been provisioned but got %+v", existing.Properties)
+	}
+
+	deleteFuture, err := resourcesClient.DeleteByID(ctx, networkId, virtualNetworksApiVersion)
+	if err != nil {
+		t.Fatalf("deleting Virtual Network: %+v", err)
+	}
+	if err := deleteFuture.WaitForCompletionRef(ctx, resourcesClient.Client); err != nil {
+		t.Fatalf("waiting for deletion of Virtual Network: %+v", err)
+	}
+
+	for _, id := range []string{networkId, subnetId} {
+		resp, err := resourcesClient.GetByID(ctx, id, virtualNetworksApiVersion)
+		if err == nil || !utils.ResponseWasNotFound(resp.Response) {
+			t.Fatalf("expected a 404 retrieving %q after deletion but got %d: %+v", id, resp.StatusCode, err)
+		}
+	}
+	if _, exists := server.Resource(groupId); !exists {
+		t.Fatalf("expected the Resource Group to still exist")
+	}
+}
+
+func TestServerResourceProviderBehaviours(t *testing.T) {
+	server := NewServer()
+	server.RegisterResourceProvider("Microsoft.Storage", ResourceProvider{
+		OnCreateOrUpdate: func(id string, existing, resource map[string]interface{}) error {
+			if resource["sku"] == nil {
+				return &Error{
+					StatusCode: http.StatusBadRequest,
+					Code:       "MissingSku",
+					Message:    "The sku must be specified.",
+				}
+			}
+
+			resource["properties"] = map[string]interface{}{
+				"primaryEndpoints": map[string]interface{}{
+					"blob": "https://example.blob.core.windows.net/",
+				},
+			}
+			return nil
+		},
+		Actions: map[string]ActionFunc{
+			"listKeys": func(id string, resource map[string]interface{}, body map[string]interface{}) (interface{}, error) {
+				return map[string]interface{}{
+					"keys": []interface{}{
+						map[string]interface{}{
+							"keyName": "key1",
+							"value":   "c2VjcmV0",
+						},
+					},
+				}, nil
+			},
+		},
+	})
+	server.SetResource(fmt.Sprintf("/subscriptions/%s/resourceGroups/example", SubscriptionId), map[string]interface{}{})
+
+	accountId := fmt.Sprintf("/subscriptions/%s/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example", SubscriptionId)
+	send := func(method, path, body string) (int, map[string]interface{}) {
+		req, err := http.NewRequest(method, "https://management.azure.com"+path+"?api-version=2019-06-01", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+
+		resp, err := server.SendDecorator()(nil).Do(req)
+		if err != nil {
+			t.Fatalf("sending %s %s: %+v", method, path, err)
+		}
+		contents, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("reading response: %+v", err)
+		}
+
+		output := map[string]interface{}{}
+		if len(contents) > 0 {
+			if err := json.Unmarshal(contents, &output); err != nil {
+				t.Fatalf("parsing response %q: %+v", string(contents), err)
+			}
+		}
+		return resp.StatusCode, output
+	}
+
+	if statusCode, body := send(http.MethodPut, accountId, `{"location":"westeurope"}`); statusCode != http.StatusBadRequest {
+		t.Fatalf("expected a 400 when the sku is missing but got %d: %+v", statusCode, body)
+	}
+
+	statusCode, body := send(http.MethodPut, accountId, `{"location":"westeurope","sku":{"name":"Standard_LRS"}}`)
+	if statusCode != http.StatusCreated {
+		t.Fatalf("expected a 201 but got %d: %+v", statusCode, body)
+	}
+	if properties := body["properties"].(map[string]interface{}); properties["primaryEndpoints"] == nil {
+		t.Fatalf("expected the properties set by the Resource Provider to be returned but got %+v", body)
+	}
+
+	statusCode, body = send(http.MethodPatch, accountId, `{"tags":{"environment":"test"}}`)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected a 200 but got %d: %+v", statusCode, body)
+	}
+	if body["sku"] == nil || body["tags"] == nil {
+		t.Fatalf("expected the patched fields to be merged into the existing resource but got %+v", body)
+	}
+
+	statusCode, body = send(http.MethodPost, accountId+"/listKeys", "")
+	if statusCode != http.StatusOK || body["keys"] == nil {
+		t.Fatalf("expected the keys to be listed but got %d: %+v", statusCode, body)
+	}
+
+	if statusCode, body = send(http.MethodPost, accountId+"/regenerateKey", ""); statusCode != http.StatusNotFound {
+		t.Fatalf("expected a 404 for an unsupported action but got %d: %+v", statusCode, body)
+	}
+
+	statusCode, body = send(http.MethodGet, fmt.Sprintf("/subscriptions/%s/resourceGroups/example/providers/Microsoft.Storage/storageAccounts", SubscriptionId), "")
+	if values := body["value"].([]interface{}); statusCode != http.StatusOK || len(values) != 1 {
+		t.Fatalf("expected a single Storage Account to be listed but got %d: %+v", statusCode, body)
+	}
+
+	if statusCode, _ = send(http.MethodDelete, accountId, ""); statusCode != http.StatusOK {
+		t.Fatalf("expected a 200 deleting the Storage Account but got %d", statusCode)
+	}
+	if statusCode, _ = send(http.MethodGet, accountId, ""); statusCode != http.StatusNotFound {
+		t.Fatalf("expected a 404 after deletion but got %d", statusCode)
+	}
+	if statusCode, _ = send(http.MethodDelete, accountId, ""); statusCode != http.StatusNoContent {
+		t.Fatalf("expected a 204 deleting a Storage Account which doesn't exist but got %d", statusCode)
+	}
+}
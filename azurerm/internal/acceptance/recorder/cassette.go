@@ -0,0 +1,95 @@
+package recorder
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+)
+
+// cassette is the set of requests made (and values generated) during a single test
+type cassette struct {
+	// Seeds are the values generated during the test (such as the Random Integer), in the order
+	// they were generated, keyed by the name of the value
+	Seeds map[string][]string `json:"seeds"`
+
+	// Interactions are the requests made during the test, in the order they were made
+	Interactions []interaction `json:"interactions"`
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+type recordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers"`
+	Body       string      `json:"body"`
+
+	// BodyIsBase64 specifies that the Body is Base64 encoded, which is used when the body isn't valid UTF-8
+	BodyIsBase64 bool `json:"body_is_base64,omitempty"`
+}
+
+func newCassette() *cassette {
+	return &cassette{
+		Seeds:        map[string][]string{},
+		Interactions: make([]interaction, 0),
+	}
+}
+
+func loadCassette(path string) (*cassette, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette %q: %+v", path, err)
+	}
+
+	c := newCassette()
+	if err := json.Unmarshal(contents, c); err != nil {
+		return nil, fmt.Errorf("parsing cassette %q: %+v", path, err)
+	}
+
+	return c, nil
+}
+
+func (c cassette) save(path string) error {
+	contents, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("serializing cassette: %+v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating directory for cassette %q: %+v", path, err)
+	}
+
+	if err := ioutil.WriteFile(path, contents, 0644); err != nil {
+		return fmt.Errorf("writing cassette %q: %+v", path, err)
+	}
+
+	return nil
+}
+
+func newRecordedResponseBody(body []byte) (string, bool) {
+	if utf8.Valid(body) {
+		return string(body), false
+	}
+
+	return base64.StdEncoding.EncodeToString(body), true
+}
+
+func (r recordedResponse) body() ([]byte, error) {
+	if r.BodyIsBase64 {
+		return base64.StdEncoding.DecodeString(r.Body)
+	}
+
+	return []byte(r.Body), nil
+}
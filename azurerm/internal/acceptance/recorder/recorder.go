@@ -0,0 +1,324 @@
+package recorder
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const (
+	// ModeEnvironmentVariable is the Environment Variable used to specify the Mode
+	ModeEnvironmentVariable = "ARM_TEST_RECORDER_MODE"
+
+	// CassettesPathEnvironmentVariable is the Environment Variable which can be used to override the
+	// directory containing the cassettes - which defaults to `testdata/cassettes` within the package
+	CassettesPathEnvironmentVariable = "ARM_TEST_CASSETTES_PATH"
+)
+
+type Mode string
+
+const (
+	// ModeLive sends requests to Azure without recording them
+	ModeLive Mode = ""
+
+	// ModeRecord sends requests to Azure and saves the (sanitized) responses into a cassette for each test
+	ModeRecord Mode = "record"
+
+	// ModeReplay serves the responses from the cassette for each test, without sending requests to Azure
+	ModeReplay Mode = "replay"
+)
+
+// CurrentMode returns the Mode specified in the `ARM_TEST_RECORDER_MODE` Environment Variable
+func CurrentMode() Mode {
+	return Mode(os.Getenv(ModeEnvironmentVariable))
+}
+
+var (
+	lock    sync.Mutex
+	active  *Recorder
+	activeT *testing.T
+)
+
+// Start returns the Recorder for the specified test, which is stopped (saving the cassette when
+// recording) when the test completes - or nil when running against Azure. Since requests are sent
+// through the active Recorder, only a single test can be recorded or replayed at once.
+func Start(t *testing.T) *Recorder {
+	mode := CurrentMode()
+	if mode == ModeLive {
+		return nil
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	if activeT == t {
+		return active
+	}
+
+	if mode != ModeRecord && mode != ModeReplay {
+		t.Fatalf("`%s` must be either %q or %q but got %q", ModeEnvironmentVariable, ModeRecord, ModeReplay, mode)
+		return nil
+	}
+	if active != nil {
+		t.Fatalf("recorder: %q is already being recorded/replayed - tests can't be run in parallel when recording/replaying", activeT.Name())
+		return nil
+	}
+
+	recorder, err := newRecorder(mode, cassettePath(t.Name()))
+	if err != nil {
+		t.Fatalf("recorder: %+v", err)
+		return nil
+	}
+
+	active = recorder
+	activeT = t
+	t.Cleanup(func() {
+		lock.Lock()
+		active = nil
+		activeT = nil
+		lock.Unlock()
+
+		if err := recorder.stop(); err != nil {
+			t.Errorf("recorder: %+v", err)
+		}
+	})
+
+	return recorder
+}
+
+// SendDecorator returns a SendDecorator which sends requests via the active Recorder - or
+// directly to Azure when no Recorder is active
+func SendDecorator() autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			lock.Lock()
+			recorder := active
+			lock.Unlock()
+
+			if recorder == nil {
+				return s.Do(r)
+			}
+			return recorder.send(s, r)
+		})
+	}
+}
+
+// Recorder records the requests sent to Azure during a test into a cassette, or replays them from it
+type Recorder struct {
+	mode      Mode
+	path      string
+	sanitizer sanitizer
+
+	lock      sync.Mutex
+	cassette  *cassette
+	seeds     map[string]int
+	used      []bool
+	lastIndex map[string]int
+	errors    []error
+}
+
+func newRecorder(mode Mode, path string) (*Recorder, error) {
+	recorder := Recorder{
+		mode:      mode,
+		path:      path,
+		sanitizer: newSanitizer(),
+		cassette:  newCassette(),
+		seeds:     map[string]int{},
+		lastIndex: map[string]int{},
+	}
+
+	if mode == ModeReplay {
+		existing, err := loadCassette(path)
+		if err != nil {
+			return nil, fmt.Errorf("%+v - this test needs to be recorded by setting `%s` to %q", err, ModeEnvironmentVariable, ModeRecord)
+		}
+		recorder.cassette = existing
+		recorder.used = make([]bool, len(existing.Interactions))
+	}
+
+	return &recorder, nil
+}
+
+// Integer returns a value for the named seed (such as a Random Integer), which is generated when recording
+// and loaded from the cassette when replaying. Each call for the same name returns the next value.
+func (r *Recorder) Integer(name string, generate func() int) int {
+	value := r.String(name, func() string {
+		return strconv.Itoa(generate())
+	})
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		r.fail(fmt.Errorf("parsing seed %q: %+v", name, err))
+		return generate()
+	}
+	return i
+}
+
+// String returns a value for the named seed (such as a Random String), which is generated when recording
+// and loaded from the cassette when replaying. Each call for the same name returns the next value.
+func (r *Recorder) String(name string, generate func() string) string {
+	if r == nil {
+		return generate()
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	index := r.seeds[name]
+	r.seeds[name] = index + 1
+
+	if r.mode == ModeReplay {
+		values := r.cassette.Seeds[name]
+		if index < len(values) {
+			return values[index]
+		}
+
+		r.errors = append(r.errors, fmt.Errorf("no value was recorded for seed %q (#%d)", name, index))
+		return generate()
+	}
+
+	value := generate()
+	r.cassette.Seeds[name] = append(r.cassette.Seeds[name], value)
+	return value
+}
+
+func (r *Recorder) send(s autorest.Sender, req *http.Request) (*http.Response, error) {
+	if r.mode == ModeReplay {
+		return r.replay(req)
+	}
+
+	resp, err := s.Do(req)
+	if err != nil {
+		return resp, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("recorder: reading response body: %+v", err)
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	recordedBody, isBase64 := newRecordedResponseBody(r.sanitizer.Body(body))
+
+	r.lock.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction{
+		Request: recordedRequest{
+			Method: req.Method,
+			URL:    r.sanitizer.String(req.URL.String()),
+		},
+		Response: recordedResponse{
+			StatusCode:   resp.StatusCode,
+			Headers:      r.sanitizer.Headers(resp.Header),
+			Body:         recordedBody,
+			BodyIsBase64: isBase64,
+		},
+	})
+	r.lock.Unlock()
+
+	return resp, nil
+}
+
+// replay serves the first unused interaction recorded for this request - or, when each of these has been used (for
+// example when the test polls or refreshes an access token more often than when recorded), the last one of these
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	method := req.Method
+	url := r.sanitizer.String(req.URL.String())
+	key := method + " " + url
+
+	r.lock.Lock()
+	index := -1
+	for i, v := range r.cassette.Interactions {
+		if r.used[i] || v.Request.Method != method || v.Request.URL != url {
+			continue
+		}
+
+		index = i
+		r.used[i] = true
+		r.lastIndex[key] = i
+		break
+	}
+	if index == -1 {
+		if last, ok := r.lastIndex[key]; ok {
+			index = last
+		}
+	}
+	r.lock.Unlock()
+
+	if index == -1 {
+		err := fmt.Errorf("no interaction was recorded for %s %s", method, url)
+		r.fail(err)
+		return nil, fmt.Errorf("recorder: %+v", err)
+	}
+
+	recorded := r.cassette.Interactions[index].Response
+	body, err := recorded.body()
+	if err != nil {
+		return nil, fmt.Errorf("recorder: decoding response body: %+v", err)
+	}
+	body = []byte(r.sanitizer.Desanitize(string(body)))
+
+	headers := http.Header{}
+	for k, values := range recorded.Headers {
+		for _, v := range values {
+			headers.Add(k, r.sanitizer.Desanitize(v))
+		}
+	}
+
+	// poll Long Running Operations immediately, rather than waiting as when recorded
+	headers.Set("Retry-After", "0")
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        headers,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (r *Recorder) fail(err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.errors = append(r.errors, err)
+}
+
+func (r *Recorder) stop() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(r.errors) > 0 {
+		return fmt.Errorf("using cassette %q: %+v", r.path, r.errors)
+	}
+
+	if r.mode == ModeRecord {
+		return r.cassette.save(r.path)
+	}
+
+	return nil
+}
+
+var invalidFileNameCharacters = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+func cassettePath(testName string) string {
+	directory := os.Getenv(CassettesPathEnvironmentVariable)
+	if directory == "" {
+		directory = filepath.Join("testdata", "cassettes")
+	}
+
+	return filepath.Join(directory, invalidFileNameCharacters.ReplaceAllString(testName, "_")+".json")
+}
@@ -0,0 +1,205 @@
+package recorder
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const (
+	recordedSubscriptionId = "11111111-1111-1111-1111-111111111111"
+	replayedSubscriptionId = "22222222-2222-2222-2222-222222222222"
+)
+
+func TestSanitizerBody(t *testing.T) {
+	os.Setenv("ARM_SUBSCRIPTION_ID", recordedSubscriptionId)
+	defer os.Unsetenv("ARM_SUBSCRIPTION_ID")
+
+	testData := []struct {
+		Name     string
+		Input    string
+		Expected string
+	}{
+		{
+			Name:     "Not JSON",
+			Input:    "hello world",
+			Expected: "hello world",
+		},
+		{
+			Name:     "Subscription ID",
+			Input:    `{"id":"/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/example"}`,
+			Expected: `{"id":"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example"}`,
+		},
+		{
+			Name:     "Upper-cased Subscription ID",
+			Input:    `{"id": "/SUBSCRIPTIONS/11111111-1111-1111-1111-111111111111"}`,
+			Expected: `{"id": "/SUBSCRIPTIONS/00000000-0000-0000-0000-000000000000"}`,
+		},
+		{
+			Name:     "Access Token",
+			Input:    `{"access_token":"abc123","expires_in":"3599","token_type":"Bearer"}`,
+			Expected: `{"access_token":"cmVkYWN0ZWQ=","expires_in":"3599","token_type":"Bearer"}`,
+		},
+		{
+			Name:     "Storage Account Keys",
+			Input:    `{"keys":[{"keyName":"key1","value":"c2VjcmV0","permissions":"FULL"}]}`,
+			Expected: `{"keys":[{"keyName":"key1","permissions":"FULL","value":"cmVkYWN0ZWQ="}]}`,
+		},
+		{
+			Name:     "Nested Connection String",
+			Input:    `{"properties":{"primaryConnectionString":"Endpoint=sb://example","count":12345678901234567890}}`,
+			Expected: `{"properties":{"count":12345678901234567890,"primaryConnectionString":"cmVkYWN0ZWQ="}}`,
+		},
+		{
+			Name:     "Values Outside Of Keys",
+			Input:    `{"value":[{"name":"example"}]}`,
+			Expected: `{"value":[{"name":"example"}]}`,
+		},
+	}
+
+	s := newSanitizer()
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual := string(s.Body([]byte(v.Input)))
+		if actual != v.Expected {
+			t.Fatalf("Expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case fmt.Sprintf("/subscriptions/%s/providers/Microsoft.Storage/storageAccounts/example", recordedSubscriptionId):
+			w.Header().Set("Location", fmt.Sprintf("http://%s/subscriptions/%s/operations/1", r.Host, recordedSubscriptionId))
+			w.Header().Set("Retry-After", "10")
+			w.Header().Set("Set-Cookie", "session=abc123")
+			w.WriteHeader(http.StatusAccepted)
+
+		case fmt.Sprintf("/subscriptions/%s/operations/1", recordedSubscriptionId):
+			polls++
+			if polls == 1 {
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			fmt.Fprintf(w, `{"id":"/subscriptions/%s/providers/Microsoft.Storage/storageAccounts/example","keys":[{"keyName":"key1","value":"c2VjcmV0"}]}`, recordedSubscriptionId)
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "TestExample.json")
+	accountUrl := func(subscriptionId string) string {
+		return fmt.Sprintf("%s/subscriptions/%s/providers/Microsoft.Storage/storageAccounts/example", server.URL, subscriptionId)
+	}
+	operationUrl := func(subscriptionId string) string {
+		return fmt.Sprintf("%s/subscriptions/%s/operations/1", server.URL, subscriptionId)
+	}
+	send := func(r *Recorder, method, url string) (*http.Response, string) {
+		req, err := http.NewRequest(method, url, nil)
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+
+		resp, err := r.send(autorest.CreateSender(), req)
+		if err != nil {
+			t.Fatalf("sending %s %s: %+v", method, url, err)
+		}
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("reading body: %+v", err)
+		}
+		return resp, string(body)
+	}
+
+	// Record
+	os.Setenv("ARM_SUBSCRIPTION_ID", recordedSubscriptionId)
+	defer os.Unsetenv("ARM_SUBSCRIPTION_ID")
+
+	recording, err := newRecorder(ModeRecord, path)
+	if err != nil {
+		t.Fatalf("building recorder: %+v", err)
+	}
+	recordedInteger := recording.Integer("random_integer", func() int {
+		return 1234
+	})
+	send(recording, http.MethodPut, accountUrl(recordedSubscriptionId))
+	send(recording, http.MethodGet, operationUrl(recordedSubscriptionId))
+	_, recordedBody := send(recording, http.MethodGet, operationUrl(recordedSubscriptionId))
+	if !strings.Contains(recordedBody, "c2VjcmV0") {
+		t.Fatalf("expected the response returned when recording not to be sanitized but got %q", recordedBody)
+	}
+	if err := recording.stop(); err != nil {
+		t.Fatalf("saving cassette: %+v", err)
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading cassette: %+v", err)
+	}
+	for _, secret := range []string{recordedSubscriptionId, "c2VjcmV0", "abc123"} {
+		if strings.Contains(string(contents), secret) {
+			t.Fatalf("expected the cassette not to contain %q but got: %s", secret, string(contents))
+		}
+	}
+
+	// Replay, using a different Subscription ID
+	os.Setenv("ARM_SUBSCRIPTION_ID", replayedSubscriptionId)
+	server.Close()
+
+	replaying, err := newRecorder(ModeReplay, path)
+	if err != nil {
+		t.Fatalf("loading cassette: %+v", err)
+	}
+	if actual := replaying.Integer("random_integer", func() int {
+		return 5678
+	}); actual != recordedInteger {
+		t.Fatalf("expected the Random Integer to be %d but got %d", recordedInteger, actual)
+	}
+
+	resp, _ := send(replaying, http.MethodPut, accountUrl(replayedSubscriptionId))
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("expected a 202 but got %d", resp.StatusCode)
+	}
+	if location := resp.Header.Get("Location"); location != operationUrl(replayedSubscriptionId) {
+		t.Fatalf("expected the Location header to be %q but got %q", operationUrl(replayedSubscriptionId), location)
+	}
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "0" {
+		t.Fatalf("expected the Retry-After header to be 0 but got %q", retryAfter)
+	}
+
+	resp, _ = send(replaying, http.MethodGet, operationUrl(replayedSubscriptionId))
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("expected the first poll to return a 202 but got %d", resp.StatusCode)
+	}
+
+	// the last interaction is replayed for any further requests
+	for i := 0; i < 2; i++ {
+		resp, body := send(replaying, http.MethodGet, operationUrl(replayedSubscriptionId))
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected subsequent polls to return a 200 but got %d", resp.StatusCode)
+		}
+		if !strings.Contains(body, replayedSubscriptionId) {
+			t.Fatalf("expected the response to contain the replayed Subscription ID but got %q", body)
+		}
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, accountUrl(replayedSubscriptionId), nil)
+	if _, err := replaying.send(autorest.CreateSender(), req); err == nil {
+		t.Fatalf("expected an error for a request which wasn't recorded")
+	}
+	if err := replaying.stop(); err == nil {
+		t.Fatalf("expected an error when stopping after a request which wasn't recorded")
+	}
+}
@@ -0,0 +1,221 @@
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// redactedValue is used in place of secret values - which is valid Base64, since some
+// values (such as the Storage Account Keys) are decoded by the Provider
+const redactedValue = "cmVkYWN0ZWQ="
+
+// identifiers are the Environment Variables containing values which are scrubbed from the cassettes, and
+// the placeholder used in their place. When replaying, the placeholders are replaced with the values of
+// these Environment Variables (other than secrets), so that the tests can be replayed using other values.
+var identifiers = []struct {
+	environmentVariable string
+	placeholder         string
+	secret              bool
+}{
+	{
+		environmentVariable: "ARM_SUBSCRIPTION_ID",
+		placeholder:         "00000000-0000-0000-0000-000000000000",
+	},
+	{
+		environmentVariable: "ARM_SUBSCRIPTION_ID_ALT",
+		placeholder:         "00000000-0000-0000-0000-000000000001",
+	},
+	{
+		environmentVariable: "ARM_TENANT_ID",
+		placeholder:         "00000000-0000-0000-0000-000000000002",
+	},
+	{
+		environmentVariable: "ARM_CLIENT_ID",
+		placeholder:         "00000000-0000-0000-0000-000000000003",
+	},
+	{
+		environmentVariable: "ARM_CLIENT_ID_ALT",
+		placeholder:         "00000000-0000-0000-0000-000000000004",
+	},
+	{
+		environmentVariable: "ARM_CLIENT_SECRET",
+		placeholder:         redactedValue,
+		secret:              true,
+	},
+	{
+		environmentVariable: "ARM_CLIENT_SECRET_ALT",
+		placeholder:         redactedValue,
+		secret:              true,
+	},
+}
+
+// sensitiveJsonKeys are the (case-insensitive) keys within a JSON response whose values are redacted
+var sensitiveJsonKeys = map[string]struct{}{
+	"access_token":               {},
+	"refresh_token":              {},
+	"id_token":                   {},
+	"adminpassword":              {},
+	"administratorloginpassword": {},
+	"authorizationkey":           {},
+	"connectionstring":           {},
+	"password":                   {},
+	"primaryconnectionstring":    {},
+	"primarykey":                 {},
+	"primarymasterkey":           {},
+	"primaryreadonlymasterkey":   {},
+	"secondaryconnectionstring":  {},
+	"secondarykey":               {},
+	"secondarymasterkey":         {},
+	"secondaryreadonlymasterkey": {},
+	"sharedkey":                  {},
+}
+
+// droppedHeaders are the response headers which aren't recorded
+var droppedHeaders = []string{
+	"Set-Cookie",
+}
+
+type replacement struct {
+	pattern *regexp.Regexp
+	value   string
+}
+
+type sanitizer struct {
+	// sanitize replaces the values of the identifiers with their placeholders
+	sanitize []replacement
+
+	// desanitize replaces the placeholders of the (non-secret) identifiers with their values
+	desanitize []replacement
+}
+
+func newSanitizer() sanitizer {
+	s := sanitizer{
+		sanitize:   make([]replacement, 0),
+		desanitize: make([]replacement, 0),
+	}
+
+	for _, identifier := range identifiers {
+		value := os.Getenv(identifier.environmentVariable)
+		if value == "" {
+			continue
+		}
+
+		s.sanitize = append(s.sanitize, replacement{
+			pattern: regexp.MustCompile("(?i)" + regexp.QuoteMeta(value)),
+			value:   identifier.placeholder,
+		})
+
+		if !identifier.secret {
+			s.desanitize = append(s.desanitize, replacement{
+				pattern: regexp.MustCompile(regexp.QuoteMeta(identifier.placeholder)),
+				value:   value,
+			})
+		}
+	}
+
+	return s
+}
+
+// String replaces any identifiers in the input with their placeholders
+func (s sanitizer) String(input string) string {
+	return replaceAll(input, s.sanitize)
+}
+
+// Desanitize replaces any placeholders in the input with the values of the identifiers
+func (s sanitizer) Desanitize(input string) string {
+	return replaceAll(input, s.desanitize)
+}
+
+// Headers returns a sanitized copy of the specified response headers
+func (s sanitizer) Headers(input http.Header) http.Header {
+	output := http.Header{}
+	for k, values := range input {
+		for _, v := range values {
+			output.Add(k, s.String(v))
+		}
+	}
+
+	for _, k := range droppedHeaders {
+		output.Del(k)
+	}
+
+	return output
+}
+
+// Body returns a sanitized copy of the specified response body, with any identifiers
+// and the values of any sensitive fields within a JSON response replaced
+func (s sanitizer) Body(input []byte) []byte {
+	output := []byte(s.String(string(input)))
+
+	trimmed := bytes.TrimSpace(output)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return output
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	decoder.UseNumber()
+	var parsed interface{}
+	if err := decoder.Decode(&parsed); err != nil {
+		return output
+	}
+
+	if !redactJson(parsed, "") {
+		return output
+	}
+
+	redacted, err := json.Marshal(parsed)
+	if err != nil {
+		return output
+	}
+	return redacted
+}
+
+// redactJson redacts the values of any sensitive keys within the input, returning whether any values were redacted
+func redactJson(input interface{}, parentKey string) bool {
+	redacted := false
+
+	switch v := input.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if _, ok := value.(string); ok && isSensitiveJsonKey(key, parentKey) {
+				v[key] = redactedValue
+				redacted = true
+				continue
+			}
+
+			if redactJson(value, key) {
+				redacted = true
+			}
+		}
+
+	case []interface{}:
+		for _, item := range v {
+			if redactJson(item, parentKey) {
+				redacted = true
+			}
+		}
+	}
+
+	return redacted
+}
+
+func isSensitiveJsonKey(key, parentKey string) bool {
+	if _, ok := sensitiveJsonKeys[strings.ToLower(key)]; ok {
+		return true
+	}
+
+	// e.g. Storage Account Keys are returned as `{ "keys": [{ "keyName": "key1", "value": "..." }] }`
+	return strings.EqualFold(parentKey, "keys") && strings.EqualFold(key, "value")
+}
+
+func replaceAll(input string, replacements []replacement) string {
+	for _, r := range replacements {
+		input = r.pattern.ReplaceAllLiteralString(input, r.value)
+	}
+
+	return input
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/helpers"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/recorder"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/testclient"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/types"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/provider"
@@ -68,5 +69,17 @@ func (td TestData) runAcceptanceTest(t *testing.T, testCase resource.TestCase) {
 		},
 	}
 
+	// when recording/replaying, requests are sent via the Recorder for this test - as such tests can't be run in parallel
+	if recorder.CurrentMode() != recorder.ModeLive {
+		recorder.Start(t)
+		testCase.ProviderFactories["azurerm"] = func() (terraform.ResourceProvider, error) {
+			azurerm := provider.TestAzureProviderWithSendDecorators(recorder.SendDecorator())
+			return azurerm, nil
+		}
+
+		resource.Test(t, testCase)
+		return
+	}
+
 	resource.ParallelTest(t, testCase)
 }
@@ -5,15 +5,35 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"testing"
 
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/go-azure-helpers/authentication"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/recorder"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
 )
 
 var _client *clients.Client
 var clientLock = &sync.Mutex{}
 
+// Use overrides the Client returned from Build until the test completes, for example
+// to use a Client which sends requests to a fake of Azure Resource Manager
+func Use(t *testing.T, client *clients.Client) {
+	clientLock.Lock()
+	defer clientLock.Unlock()
+
+	existing := _client
+	_client = client
+	t.Cleanup(func() {
+		clientLock.Lock()
+		defer clientLock.Unlock()
+
+		_client = existing
+	})
+}
+
 func Build() (*clients.Client, error) {
 	clientLock.Lock()
 	defer clientLock.Unlock()
@@ -46,6 +66,12 @@ func Build() (*clients.Client, error) {
 			TerraformVersion:         os.Getenv("TERRAFORM_CORE_VERSION"),
 			Features:                 features.Default(),
 			StorageUseAzureAD:        false,
+			LogRedaction:             common.NewLogRedaction(nil),
+		}
+		if recorder.CurrentMode() != recorder.ModeLive {
+			clientBuilder.SendDecorators = []autorest.SendDecorator{
+				recorder.SendDecorator(),
+			}
 		}
 		client, err := clients.Build(context.TODO(), clientBuilder)
 		if err != nil {
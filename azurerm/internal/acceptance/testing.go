@@ -10,6 +10,7 @@ import (
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/hashicorp/go-azure-helpers/authentication"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
 )
 
 func PreCheck(t *testing.T) {
@@ -41,6 +42,11 @@ func EnvironmentName() string {
 }
 
 func Environment() (*azure.Environment, error) {
+	if path := os.Getenv("ARM_ENVIRONMENT_FILEPATH"); path != "" {
+		env, _, err := clients.EnvironmentFromFile(path)
+		return env, err
+	}
+
 	envName := EnvironmentName()
 	metadataURL := os.Getenv("ARM_METADATA_URL")
 	return authentication.AzureEnvironmentByNameFromEndpoint(context.TODO(), metadataURL, envName)
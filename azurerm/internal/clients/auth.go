@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/hashicorp/go-azure-helpers/authentication"
 )
@@ -41,3 +43,58 @@ func NewResourceManagerAccount(ctx context.Context, config authentication.Config
 	}
 	return &account, nil
 }
+
+// servicePrincipalObjectIdFunc returns a function which looks up the Object ID of the Service Principal
+// being used to authenticate, using the specified Sender
+func servicePrincipalObjectIdFunc(config authentication.Config, getAuthorizationToken authorizationTokenFunc, env azure.Environment, oauthConfig *authentication.OAuthConfig, sender autorest.Sender) func(ctx context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		graphAuth, err := getAuthorizationToken(sender, oauthConfig, env.GraphEndpoint)
+		if err != nil {
+			return "", err
+		}
+
+		client := graphrbac.NewServicePrincipalsClientWithBaseURI(env.GraphEndpoint, config.TenantID)
+		client.Authorizer = graphAuth
+		client.Sender = sender
+
+		filter := fmt.Sprintf("appId eq '%s'", config.ClientID)
+		result, err := client.List(ctx, filter)
+		if err != nil {
+			return "", fmt.Errorf("listing Service Principals: %+v", err)
+		}
+
+		if values := result.Values(); len(values) != 1 || values[0].ObjectID == nil {
+			return "", fmt.Errorf("unexpected Service Principal query result: %+v", values)
+		}
+
+		return *result.Values()[0].ObjectID, nil
+	}
+}
+
+// authorizationTokenFunc returns an Authorizer for the specified endpoint, which is either provided by the
+// authentication method within the `authentication.Config` or by an authentication method implemented in
+// the Provider (such as OIDCAuth)
+type authorizationTokenFunc func(sender autorest.Sender, oauthConfig *authentication.OAuthConfig, endpoint string) (autorest.Authorizer, error)
+
+// bearerAuthorizerCallback returns a BearerAuthorizer valid only for the Primary Tenant, this is equivalent to
+// `authentication.Config.BearerAuthorizerCallback` but using the specified authorizationTokenFunc
+func bearerAuthorizerCallback(getAuthorizationToken authorizationTokenFunc, sender autorest.Sender, oauthConfig *authentication.OAuthConfig) *autorest.BearerAuthorizerCallback {
+	return autorest.NewBearerAuthorizerCallback(sender, func(tenantID, resource string) (*autorest.BearerAuthorizer, error) {
+		// a BearerAuthorizer is only valid for the primary tenant
+		newAuthConfig := &authentication.OAuthConfig{
+			OAuth: oauthConfig.OAuth,
+		}
+
+		auth, err := getAuthorizationToken(sender, newAuthConfig, resource)
+		if err != nil {
+			return nil, err
+		}
+
+		cast, ok := auth.(*autorest.BearerAuthorizer)
+		if !ok {
+			return nil, fmt.Errorf("Error converting %+v to a BearerAuthorizer", auth)
+		}
+
+		return cast, nil
+	})
+}
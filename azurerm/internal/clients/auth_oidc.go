@@ -0,0 +1,105 @@
+package clients
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/hashicorp/go-azure-helpers/authentication"
+	"github.com/hashicorp/go-multierror"
+)
+
+// OIDCAuth authenticates as a Service Principal using a Federated Credential (Workload Identity Federation), by
+// exchanging an OIDC token issued by a trusted identity provider (for example a CI system) for an access token.
+//
+// This isn't supported by the version of go-azure-helpers we're using, so it's implemented here rather than
+// being an authentication method within the `authentication.Config`.
+type OIDCAuth struct {
+	ClientID string
+	TenantID string
+
+	// Token is the OIDC token which should be used - either this or TokenFilePath must be specified
+	Token string
+
+	// TokenFilePath is the path to a file containing the OIDC token, which is re-read each time the access
+	// token is refreshed, since these tokens are short-lived and are rotated (for example in Kubernetes)
+	TokenFilePath string
+}
+
+// Validate confirms that the OIDC configuration is complete
+func (a OIDCAuth) Validate() error {
+	var err *multierror.Error
+
+	fmtErrorMessage := "A %s must be configured when authenticating as a Service Principal using OIDC."
+
+	if a.ClientID == "" {
+		err = multierror.Append(err, fmt.Errorf(fmtErrorMessage, "Client ID"))
+	}
+	if a.TenantID == "" {
+		err = multierror.Append(err, fmt.Errorf(fmtErrorMessage, "Tenant ID"))
+	}
+	if a.Token == "" && a.TokenFilePath == "" {
+		err = multierror.Append(err, fmt.Errorf(fmtErrorMessage, "OIDC Token or OIDC Token File Path"))
+	}
+
+	return err.ErrorOrNil()
+}
+
+func (a OIDCAuth) getAuthorizationToken(sender autorest.Sender, oauth *authentication.OAuthConfig, endpoint string) (autorest.Authorizer, error) {
+	if oauth.OAuth == nil {
+		return nil, fmt.Errorf("getting Authorization Token for OIDC auth: an OAuth token wasn't configured correctly; please file a bug with more details")
+	}
+
+	secret := &federatedTokenSecret{
+		token:         a.Token,
+		tokenFilePath: a.TokenFilePath,
+	}
+	spt, err := adal.NewServicePrincipalTokenWithSecret(*oauth.OAuth, a.ClientID, endpoint, secret)
+	if err != nil {
+		return nil, err
+	}
+	spt.SetSender(sender)
+
+	return autorest.NewBearerAuthorizer(spt), nil
+}
+
+var _ adal.ServicePrincipalSecret = &federatedTokenSecret{}
+
+// federatedTokenSecret authenticates the token request using the OIDC token as a Client Assertion
+type federatedTokenSecret struct {
+	token         string
+	tokenFilePath string
+}
+
+// SetAuthenticationValues is called each time the access token is requested/refreshed
+func (s *federatedTokenSecret) SetAuthenticationValues(_ *adal.ServicePrincipalToken, values *url.Values) error {
+	token, err := s.assertion()
+	if err != nil {
+		return err
+	}
+
+	values.Set("client_assertion_type", "urn:ietf:params:oauth:client-assertion-type:jwt-bearer")
+	values.Set("client_assertion", token)
+	return nil
+}
+
+func (s *federatedTokenSecret) assertion() (string, error) {
+	if s.tokenFilePath == "" {
+		return s.token, nil
+	}
+
+	contents, err := ioutil.ReadFile(s.tokenFilePath)
+	if err != nil {
+		return "", fmt.Errorf("reading the OIDC Token from %q: %+v", s.tokenFilePath, err)
+	}
+
+	token := strings.TrimSpace(string(contents))
+	if token == "" {
+		return "", fmt.Errorf("the OIDC Token File %q was empty", s.tokenFilePath)
+	}
+
+	return token, nil
+}
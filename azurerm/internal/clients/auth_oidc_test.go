@@ -0,0 +1,127 @@
+package clients
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/hashicorp/go-azure-helpers/authentication"
+)
+
+func TestOIDCAuthValidate(t *testing.T) {
+	testData := []struct {
+		name     string
+		auth     OIDCAuth
+		expected bool
+	}{
+		{
+			name:     "empty",
+			auth:     OIDCAuth{},
+			expected: false,
+		},
+		{
+			name: "no token",
+			auth: OIDCAuth{
+				ClientID: "11111111-1111-1111-1111-111111111111",
+				TenantID: "22222222-2222-2222-2222-222222222222",
+			},
+			expected: false,
+		},
+		{
+			name: "token",
+			auth: OIDCAuth{
+				ClientID: "11111111-1111-1111-1111-111111111111",
+				TenantID: "22222222-2222-2222-2222-222222222222",
+				Token:    "token",
+			},
+			expected: true,
+		},
+		{
+			name: "token file path",
+			auth: OIDCAuth{
+				ClientID:      "11111111-1111-1111-1111-111111111111",
+				TenantID:      "22222222-2222-2222-2222-222222222222",
+				TokenFilePath: "/var/run/secrets/token",
+			},
+			expected: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		err := v.auth.Validate()
+		if (err == nil) != v.expected {
+			t.Fatalf("expected valid to be %t but got error: %+v", v.expected, err)
+		}
+	}
+}
+
+func TestOIDCAuthTokenFileIsReadOnRefresh(t *testing.T) {
+	assertions := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("parsing form: %+v", err)
+		}
+		if v := r.PostForm.Get("client_assertion_type"); v != "urn:ietf:params:oauth:client-assertion-type:jwt-bearer" {
+			t.Fatalf("unexpected `client_assertion_type`: %q", v)
+		}
+		if v := r.PostForm.Get("client_id"); v != "11111111-1111-1111-1111-111111111111" {
+			t.Fatalf("unexpected `client_id`: %q", v)
+		}
+		assertions = append(assertions, r.PostForm.Get("client_assertion"))
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"access-%d","token_type":"Bearer","expires_in":"3600","expires_on":"0","resource":"https://management.azure.com/"}`, len(assertions))
+	}))
+	defer server.Close()
+
+	tokenFilePath := filepath.Join(t.TempDir(), "token")
+	if err := ioutil.WriteFile(tokenFilePath, []byte("first\n"), 0600); err != nil {
+		t.Fatalf("writing token file: %+v", err)
+	}
+
+	oauth, err := adal.NewOAuthConfig(server.URL, "22222222-2222-2222-2222-222222222222")
+	if err != nil {
+		t.Fatalf("building OAuth config: %+v", err)
+	}
+
+	auth := OIDCAuth{
+		ClientID:      "11111111-1111-1111-1111-111111111111",
+		TenantID:      "22222222-2222-2222-2222-222222222222",
+		TokenFilePath: tokenFilePath,
+	}
+	authorizer, err := auth.getAuthorizationToken(server.Client(), &authentication.OAuthConfig{OAuth: oauth}, "https://management.azure.com/")
+	if err != nil {
+		t.Fatalf("building authorizer: %+v", err)
+	}
+
+	spt, ok := authorizer.(*autorest.BearerAuthorizer).TokenProvider().(*adal.ServicePrincipalToken)
+	if !ok {
+		t.Fatalf("expected a ServicePrincipalToken")
+	}
+
+	if err := spt.Refresh(); err != nil {
+		t.Fatalf("refreshing token: %+v", err)
+	}
+
+	// the token is rotated, so should be re-read for the next refresh
+	if err := ioutil.WriteFile(tokenFilePath, []byte("second"), 0600); err != nil {
+		t.Fatalf("writing token file: %+v", err)
+	}
+	if err := spt.Refresh(); err != nil {
+		t.Fatalf("refreshing token: %+v", err)
+	}
+
+	if len(assertions) != 2 || assertions[0] != "first" || assertions[1] != "second" {
+		t.Fatalf("expected the assertions to be `first` and `second` but got %+v", assertions)
+	}
+	if token := spt.OAuthToken(); token != "access-2" {
+		t.Fatalf("expected the access token to be `access-2` but got %q", token)
+	}
+}
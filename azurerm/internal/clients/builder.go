@@ -9,11 +9,12 @@ import (
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/hashicorp/go-azure-helpers/authentication"
-	"github.com/hashicorp/go-azure-helpers/sender"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/location"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/providercache"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/resourceproviders"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
 )
 
 type ClientBuilder struct {
@@ -25,6 +26,29 @@ type ClientBuilder struct {
 	StorageUseAzureAD           bool
 	TerraformVersion            string
 	Features                    features.UserFeatures
+	DefaultTags                 map[string]string
+	IgnoreTags                  tags.IgnoreConfig
+	Retries                     *common.RetryOptions
+	ConcurrencyLimits           common.ConcurrencyLimits
+
+	// SendDecorators are applied to the Sender used for all requests to Azure (including authentication),
+	// for example to record and replay requests in tests
+	SendDecorators []autorest.SendDecorator
+
+	// OIDCAuth, when specified, is used to authenticate instead of the authentication method within the AuthConfig
+	OIDCAuth *OIDCAuth
+
+	// EnvironmentFilePath is the path to a JSON document describing a Custom Azure Environment, which is used
+	// rather than looking up the Environment from the Azure MetaData Service when specified
+	EnvironmentFilePath string
+
+	// LogRedaction redacts secrets from the requests and responses which are logged (including
+	// those used to authenticate) - when nil these are logged as-is
+	LogRedaction *common.LogRedaction
+
+	// ProviderCacheDirectory is the directory used to cache metadata (such as the available Resource Providers
+	// and Locations) across runs of Terraform - when empty this is only cached in memory
+	ProviderCacheDirectory string
 }
 
 const azureStackEnvironmentError = `
@@ -36,33 +60,51 @@ Terraform instead offers a separate "azurestack" provider which supports the fun
 and API's available in Azure Stack via Azure Stack Profiles.
 `
 
-func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
-	// point folks towards the separate Azure Stack Provider when using Azure Stack
-	if strings.EqualFold(builder.AuthConfig.Environment, "AZURESTACKCLOUD") {
-		return nil, fmt.Errorf(azureStackEnvironmentError)
+// buildEnvironment returns the Azure Environment (and, when known, the Locations available within it) - which is
+// either loaded from the Environment File or retrieved from the Azure MetaData Service
+func buildEnvironment(ctx context.Context, builder ClientBuilder) (*azure.Environment, []string, error) {
+	if builder.EnvironmentFilePath != "" {
+		log.Printf("[DEBUG] Loading the Azure Environment from %q", builder.EnvironmentFilePath)
+		return EnvironmentFromFile(builder.EnvironmentFilePath)
 	}
 
 	isAzureStack, err := authentication.IsEnvironmentAzureStack(ctx, builder.AuthConfig.MetadataHost, builder.AuthConfig.Environment)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if isAzureStack {
-		return nil, fmt.Errorf(azureStackEnvironmentError)
+		return nil, nil, fmt.Errorf(azureStackEnvironmentError)
 	}
 
 	env, err := authentication.AzureEnvironmentByNameFromEndpoint(ctx, builder.AuthConfig.MetadataHost, builder.AuthConfig.Environment)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	// client declarations:
-	account, err := NewResourceManagerAccount(ctx, *builder.AuthConfig, *env, builder.SkipProviderRegistration)
-	if err != nil {
-		return nil, fmt.Errorf("Error building account: %+v", err)
+	return env, nil, nil
+}
+
+// authenticatedObjectIdFunc returns the function used to look up the Object ID of the Service Principal being used
+// to authenticate, which (unlike the lookup within the `authentication.Config`) supports OIDC, uses the Graph endpoint
+// from the Environment (which may have been loaded from a file) and uses the same Sender as all other requests.
+// When not authenticating as a Service Principal this returns nil, since the `authentication.Config` is used instead.
+func authenticatedObjectIdFunc(authConfig authentication.Config, getAuthorizationToken authorizationTokenFunc, env azure.Environment, oauthConfig *authentication.OAuthConfig, sender autorest.Sender) func(ctx context.Context) (string, error) {
+	if !authConfig.AuthenticatedAsAServicePrincipal {
+		return nil
 	}
 
-	client := Client{
-		Account: account,
+	return servicePrincipalObjectIdFunc(authConfig, getAuthorizationToken, env, oauthConfig, sender)
+}
+
+func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
+	// point folks towards the separate Azure Stack Provider when using Azure Stack
+	if strings.EqualFold(builder.AuthConfig.Environment, "AZURESTACKCLOUD") {
+		return nil, fmt.Errorf(azureStackEnvironmentError)
+	}
+
+	env, locations, err := buildEnvironment(ctx, builder)
+	if err != nil {
+		return nil, err
 	}
 
 	oauthConfig, err := builder.AuthConfig.BuildOAuthConfig(env.ActiveDirectoryEndpoint)
@@ -75,24 +117,46 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return nil, fmt.Errorf("Unable to configure OAuthConfig for tenant %s", builder.AuthConfig.TenantID)
 	}
 
-	sender := sender.BuildSender("AzureRM")
+	sender := autorest.DecorateSender(common.BuildSender("AzureRM", builder.LogRedaction), builder.SendDecorators...)
+
+	authConfig := *builder.AuthConfig
+	getAuthorizationToken := authorizationTokenFunc(authConfig.GetAuthorizationToken)
+	if builder.OIDCAuth != nil {
+		getAuthorizationToken = builder.OIDCAuth.getAuthorizationToken
+		authConfig.AuthenticatedAsAServicePrincipal = true
+	}
+	if getAuthenticatedObjectID := authenticatedObjectIdFunc(authConfig, getAuthorizationToken, *env, oauthConfig, sender); getAuthenticatedObjectID != nil {
+		authConfig.GetAuthenticatedObjectID = getAuthenticatedObjectID
+	}
+
+	// client declarations:
+	account, err := NewResourceManagerAccount(ctx, authConfig, *env, builder.SkipProviderRegistration)
+	if err != nil {
+		return nil, fmt.Errorf("Error building account: %+v", err)
+	}
+
+	client := Client{
+		Account:     account,
+		DefaultTags: builder.DefaultTags,
+		IgnoreTags:  builder.IgnoreTags,
+	}
 
 	// Resource Manager endpoints
 	endpoint := env.ResourceManagerEndpoint
-	auth, err := builder.AuthConfig.GetAuthorizationToken(sender, oauthConfig, env.TokenAudience)
+	auth, err := getAuthorizationToken(sender, oauthConfig, env.TokenAudience)
 	if err != nil {
 		return nil, err
 	}
 
 	// Graph Endpoints
 	graphEndpoint := env.GraphEndpoint
-	graphAuth, err := builder.AuthConfig.GetAuthorizationToken(sender, oauthConfig, graphEndpoint)
+	graphAuth, err := getAuthorizationToken(sender, oauthConfig, graphEndpoint)
 	if err != nil {
 		return nil, err
 	}
 
 	// Storage Endpoints
-	storageAuth, err := builder.AuthConfig.GetAuthorizationToken(sender, oauthConfig, env.ResourceIdentifiers.Storage)
+	storageAuth, err := getAuthorizationToken(sender, oauthConfig, env.ResourceIdentifiers.Storage)
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +164,7 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 	// Synapse Endpoints
 	var synapseAuth autorest.Authorizer = nil
 	if env.ResourceIdentifiers.Synapse != azure.NotAvailable {
-		synapseAuth, err = builder.AuthConfig.GetAuthorizationToken(sender, oauthConfig, env.ResourceIdentifiers.Synapse)
+		synapseAuth, err = getAuthorizationToken(sender, oauthConfig, env.ResourceIdentifiers.Synapse)
 		if err != nil {
 			return nil, err
 		}
@@ -109,7 +173,7 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 	}
 
 	// Key Vault Endpoints
-	keyVaultAuth := builder.AuthConfig.BearerAuthorizerCallback(sender, oauthConfig)
+	keyVaultAuth := bearerAuthorizerCallback(getAuthorizationToken, sender, oauthConfig)
 
 	o := &common.ClientOptions{
 		SubscriptionId:              builder.AuthConfig.SubscriptionID,
@@ -129,6 +193,11 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		Environment:                 *env,
 		Features:                    builder.Features,
 		StorageUseAzureAD:           builder.StorageUseAzureAD,
+		ConcurrencyLimiter:          common.NewConcurrencyLimiter(builder.ConcurrencyLimits),
+		Retries:                     builder.Retries,
+		SendDecorators:              builder.SendDecorators,
+		LogRedaction:                builder.LogRedaction,
+		ProviderCache:               providercache.New(builder.ProviderCacheDirectory, env.Name, builder.AuthConfig.SubscriptionID),
 	}
 
 	if err := client.Build(ctx, o); err != nil {
@@ -136,8 +205,15 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 	}
 
 	if features.EnhancedValidationEnabled() {
-		location.CacheSupportedLocations(ctx, env)
-		resourceproviders.CacheSupportedProviders(ctx, client.Resource.ProvidersClient)
+		// when using an Environment File the Azure MetaData Service may not be available, so these come from the file
+		if builder.EnvironmentFilePath != "" {
+			if len(locations) > 0 {
+				location.SeedSupportedLocations(locations)
+			}
+		} else {
+			location.CacheSupportedLocations(ctx, env, sender, o.ProviderCache)
+		}
+		resourceproviders.CacheSupportedProviders(ctx, client.Resource.ProvidersClient, o.ProviderCache)
 	}
 
 	return &client, nil
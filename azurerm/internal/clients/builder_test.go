@@ -0,0 +1,96 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/go-azure-helpers/authentication"
+)
+
+func TestAuthenticatedObjectIdFuncUsesEnvironmentFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/00000000-0000-0000-0000-000000000000/servicePrincipals" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if filter := r.URL.Query().Get("$filter"); filter != "appId eq '11111111-1111-1111-1111-111111111111'" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"value": [{"objectId": "22222222-2222-2222-2222-222222222222", "objectType": "ServicePrincipal"}]}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "environment.json")
+	contents := fmt.Sprintf(`{
+  "name": "ExampleCloud",
+  "resourceManagerEndpoint": "https://management.example.com/",
+  "activeDirectoryEndpoint": "https://login.example.com/",
+  "graphEndpoint": "%[1]s/",
+  "storageEndpointSuffix": "core.example.com",
+  "keyVaultDNSSuffix": "vault.example.com",
+  "resourceIdentifiers": {
+    "graph": "%[1]s/",
+    "keyVault": "https://vault.example.com",
+    "storage": "https://storage.example.com/"
+  }
+}`, server.URL)
+	if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatalf("writing environment file: %+v", err)
+	}
+
+	env, _, err := EnvironmentFromFile(path)
+	if err != nil {
+		t.Fatalf("loading environment file: %+v", err)
+	}
+
+	authConfig := authentication.Config{
+		AuthenticatedAsAServicePrincipal: true,
+		ClientID:                         "11111111-1111-1111-1111-111111111111",
+		Environment:                      "ExampleCloud",
+		TenantID:                         "00000000-0000-0000-0000-000000000000",
+	}
+	var requestedEndpoint string
+	getAuthorizationToken := func(_ autorest.Sender, _ *authentication.OAuthConfig, endpoint string) (autorest.Authorizer, error) {
+		requestedEndpoint = endpoint
+		return autorest.NullAuthorizer{}, nil
+	}
+
+	getAuthenticatedObjectID := authenticatedObjectIdFunc(authConfig, getAuthorizationToken, *env, &authentication.OAuthConfig{}, http.DefaultClient)
+	if getAuthenticatedObjectID == nil {
+		t.Fatalf("expected the Object ID to be looked up using the Environment from the file")
+	}
+
+	objectId, err := getAuthenticatedObjectID(context.Background())
+	if err != nil {
+		t.Fatalf("looking up the Object ID: %+v", err)
+	}
+	if objectId != "22222222-2222-2222-2222-222222222222" {
+		t.Fatalf("expected the Object ID to be %q but got %q", "22222222-2222-2222-2222-222222222222", objectId)
+	}
+	if requestedEndpoint != server.URL+"/" {
+		t.Fatalf("expected a token to be requested for the Graph endpoint %q but got %q", server.URL+"/", requestedEndpoint)
+	}
+}
+
+func TestAuthenticatedObjectIdFuncNotServicePrincipal(t *testing.T) {
+	authConfig := authentication.Config{
+		AuthenticatedAsAServicePrincipal: false,
+	}
+	getAuthorizationToken := func(_ autorest.Sender, _ *authentication.OAuthConfig, _ string) (autorest.Authorizer, error) {
+		return autorest.NullAuthorizer{}, nil
+	}
+
+	if v := authenticatedObjectIdFunc(authConfig, getAuthorizationToken, azure.PublicCloud, &authentication.OAuthConfig{}, http.DefaultClient); v != nil {
+		t.Fatalf("expected no lookup when not authenticating as a Service Principal")
+	}
+}
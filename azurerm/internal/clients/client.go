@@ -2,9 +2,14 @@ package clients
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/validation"
+	"github.com/hashicorp/go-uuid"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
 	advisor "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/advisor/client"
@@ -95,6 +100,7 @@ import (
 	trafficManager "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/trafficmanager/client"
 	vmware "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/vmware/client"
 	web "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/web/client"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
 )
 
 type Client struct {
@@ -104,6 +110,12 @@ type Client struct {
 	Account  *ResourceManagerAccount
 	Features features.UserFeatures
 
+	// DefaultTags are the Tags defined in the Provider block which should be assigned to every taggable Resource
+	DefaultTags map[string]string
+
+	// IgnoreTags are the Tags defined in the Provider block which are managed outside of Terraform
+	IgnoreTags tags.IgnoreConfig
+
 	Advisor               *advisor.Client
 	AnalysisServices      *analysisServices.Client
 	ApiManagement         *apiManagement.Client
@@ -192,6 +204,13 @@ type Client struct {
 	TrafficManager        *trafficManager.Client
 	Vmware                *vmware.Client
 	Web                   *web.Client
+
+	// options are the ClientOptions this Client was built from, which are used to build
+	// Clients for other Subscriptions on demand
+	options *common.ClientOptions
+
+	subscriptionClients     map[string]*Client
+	subscriptionClientsLock *sync.Mutex
 }
 
 // NOTE: it should be possible for this method to become Private once the top level Client's removed
@@ -203,6 +222,9 @@ func (client *Client) Build(ctx context.Context, o *common.ClientOptions) error
 
 	client.Features = o.Features
 	client.StopContext = ctx
+	client.options = o
+	client.subscriptionClients = map[string]*Client{}
+	client.subscriptionClientsLock = &sync.Mutex{}
 
 	client.Advisor = advisor.NewClient(o)
 	client.AnalysisServices = analysisServices.NewClient(o)
@@ -295,3 +317,56 @@ func (client *Client) Build(ctx context.Context, o *common.ClientOptions) error
 
 	return nil
 }
+
+// ForSubscription returns a Client for the specified Subscription using the same credentials (including any
+// Auxiliary Tenants) as this Client, allowing a Resource to be managed within a different Subscription to the one
+// configured in the Provider block. This Client is returned when the Subscription ID is empty or the same - otherwise
+// Clients are built on demand and cached for the lifetime of the Provider.
+func (client *Client) ForSubscription(subscriptionId string) (*Client, error) {
+	if subscriptionId == "" || strings.EqualFold(subscriptionId, client.Account.SubscriptionId) {
+		return client, nil
+	}
+
+	if _, err := uuid.ParseUUID(subscriptionId); err != nil {
+		return nil, fmt.Errorf("parsing Subscription ID %q: %+v", subscriptionId, err)
+	}
+
+	if client.options == nil {
+		return nil, fmt.Errorf("building a Client for Subscription %q: the Client hasn't been built", subscriptionId)
+	}
+
+	key := strings.ToLower(subscriptionId)
+	client.subscriptionClientsLock.Lock()
+	defer client.subscriptionClientsLock.Unlock()
+
+	if existing, ok := client.subscriptionClients[key]; ok {
+		return existing, nil
+	}
+
+	log.Printf("[DEBUG] Building a Client for Subscription %q..", subscriptionId)
+	options := *client.options
+	options.SubscriptionId = subscriptionId
+
+	// the on-disk cache is scoped to the Provider's Subscription
+	options.ProviderCache = nil
+
+	account := *client.Account
+	account.SubscriptionId = subscriptionId
+
+	subscriptionClient := &Client{
+		Account:     &account,
+		DefaultTags: client.DefaultTags,
+		IgnoreTags:  client.IgnoreTags,
+	}
+	if err := subscriptionClient.Build(client.StopContext, &options); err != nil {
+		return nil, fmt.Errorf("building Client for Subscription %q: %+v", subscriptionId, err)
+	}
+
+	// Clients for other Subscriptions are always built from the Provider's Client
+	subscriptionClient.subscriptionClients = client.subscriptionClients
+	subscriptionClient.subscriptionClientsLock = client.subscriptionClientsLock
+	subscriptionClient.options = client.options
+
+	client.subscriptionClients[key] = subscriptionClient
+	return subscriptionClient, nil
+}
@@ -0,0 +1,40 @@
+package clients
+
+import (
+	"testing"
+)
+
+func TestClientForSubscriptionReturnsSameClient(t *testing.T) {
+	client := &Client{
+		Account: &ResourceManagerAccount{
+			SubscriptionId: "aaaaaaaa-0000-0000-0000-000000000000",
+		},
+	}
+
+	for _, subscriptionId := range []string{"", "aaaaaaaa-0000-0000-0000-000000000000", "AAAAAAAA-0000-0000-0000-000000000000"} {
+		actual, err := client.ForSubscription(subscriptionId)
+		if err != nil {
+			t.Fatalf("expected no error for %q but got: %+v", subscriptionId, err)
+		}
+		if actual != client {
+			t.Fatalf("expected the same Client to be returned for %q", subscriptionId)
+		}
+	}
+}
+
+func TestClientForSubscriptionInvalid(t *testing.T) {
+	client := &Client{
+		Account: &ResourceManagerAccount{
+			SubscriptionId: "00000000-0000-0000-0000-000000000000",
+		},
+	}
+
+	if _, err := client.ForSubscription("not-a-subscription"); err == nil {
+		t.Fatalf("expected an error for an invalid Subscription ID")
+	}
+
+	// the Client hasn't been built, so there's nothing to build another Client from
+	if _, err := client.ForSubscription("11111111-1111-1111-1111-111111111111"); err == nil {
+		t.Fatalf("expected an error when the Client hasn't been built")
+	}
+}
@@ -0,0 +1,97 @@
+package clients
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/go-multierror"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/location"
+)
+
+// environmentFile is the JSON document describing a Custom Azure Environment, which allows the Provider to be used
+// in Clouds where the Azure MetaData Service isn't available (for example isolated or sovereign clouds). This is the
+// same format as `azure.EnvironmentFromFile`, with the addition of the Locations available within the Cloud
+type environmentFile struct {
+	azure.Environment
+
+	Locations []string `json:"locations"`
+}
+
+// EnvironmentFromFile loads the Custom Azure Environment (and the Locations available within it) from the specified file
+func EnvironmentFromFile(path string) (*azure.Environment, []string, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading the Environment File %q: %+v", path, err)
+	}
+
+	var file environmentFile
+	if err := json.Unmarshal(contents, &file); err != nil {
+		return nil, nil, fmt.Errorf("parsing the Environment File %q: %+v", path, err)
+	}
+
+	if err := file.validate(); err != nil {
+		return nil, nil, fmt.Errorf("validating the Environment File %q: %+v", path, err)
+	}
+
+	env := file.Environment
+	if env.TokenAudience == "" {
+		env.TokenAudience = env.ResourceManagerEndpoint
+	}
+
+	// optional services which aren't specified aren't available within this Cloud
+	identifiers := []*string{
+		&env.ResourceIdentifiers.Datalake,
+		&env.ResourceIdentifiers.Batch,
+		&env.ResourceIdentifiers.OperationalInsights,
+		&env.ResourceIdentifiers.Synapse,
+		&env.ResourceIdentifiers.ServiceBus,
+	}
+	for _, v := range identifiers {
+		if *v == "" {
+			*v = azure.NotAvailable
+		}
+	}
+
+	locations := make([]string, 0, len(file.Locations))
+	for _, v := range file.Locations {
+		locations = append(locations, location.Normalize(v))
+	}
+
+	return &env, locations, nil
+}
+
+func (f environmentFile) validate() error {
+	var err *multierror.Error
+
+	required := map[string]string{
+		"name":                         f.Name,
+		"resourceManagerEndpoint":      f.ResourceManagerEndpoint,
+		"activeDirectoryEndpoint":      f.ActiveDirectoryEndpoint,
+		"graphEndpoint":                f.GraphEndpoint,
+		"storageEndpointSuffix":        f.StorageEndpointSuffix,
+		"keyVaultDNSSuffix":            f.KeyVaultDNSSuffix,
+		"resourceIdentifiers.graph":    f.ResourceIdentifiers.Graph,
+		"resourceIdentifiers.keyVault": f.ResourceIdentifiers.KeyVault,
+		"resourceIdentifiers.storage":  f.ResourceIdentifiers.Storage,
+	}
+	for _, key := range sortedKeys(required) {
+		if strings.TrimSpace(required[key]) == "" {
+			err = multierror.Append(err, fmt.Errorf("`%s` must be specified", key))
+		}
+	}
+
+	return err.ErrorOrNil()
+}
+
+func sortedKeys(input map[string]string) []string {
+	keys := make([]string, 0, len(input))
+	for k := range input {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
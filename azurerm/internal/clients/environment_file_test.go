@@ -0,0 +1,79 @@
+package clients
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+func TestEnvironmentFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "environment.json")
+	contents := `{
+  "name": "ExampleCloud",
+  "resourceManagerEndpoint": "https://management.example.com/",
+  "activeDirectoryEndpoint": "https://login.example.com/",
+  "graphEndpoint": "https://graph.example.com/",
+  "storageEndpointSuffix": "core.example.com",
+  "keyVaultDNSSuffix": "vault.example.com",
+  "resourceIdentifiers": {
+    "graph": "https://graph.example.com/",
+    "keyVault": "https://vault.example.com",
+    "storage": "https://storage.example.com/"
+  },
+  "locations": ["East Example", "westexample"]
+}`
+	if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatalf("writing environment file: %+v", err)
+	}
+
+	env, locations, err := EnvironmentFromFile(path)
+	if err != nil {
+		t.Fatalf("loading environment file: %+v", err)
+	}
+
+	if env.Name != "ExampleCloud" {
+		t.Fatalf("expected the name to be `ExampleCloud` but got %q", env.Name)
+	}
+	if env.StorageEndpointSuffix != "core.example.com" {
+		t.Fatalf("expected the storage endpoint suffix to be `core.example.com` but got %q", env.StorageEndpointSuffix)
+	}
+	if env.TokenAudience != "https://management.example.com/" {
+		t.Fatalf("expected the token audience to default to the Resource Manager endpoint but got %q", env.TokenAudience)
+	}
+	if env.ResourceIdentifiers.Synapse != azure.NotAvailable {
+		t.Fatalf("expected the Synapse resource identifier to be %q but got %q", azure.NotAvailable, env.ResourceIdentifiers.Synapse)
+	}
+
+	expectedLocations := []string{"eastexample", "westexample"}
+	if !reflect.DeepEqual(locations, expectedLocations) {
+		t.Fatalf("expected the locations %+v but got %+v", expectedLocations, locations)
+	}
+}
+
+func TestEnvironmentFromFileInvalid(t *testing.T) {
+	testData := map[string]string{
+		"not json":        `{`,
+		"empty":           `{}`,
+		"missing storage": `{"name": "ExampleCloud", "resourceManagerEndpoint": "https://management.example.com/", "activeDirectoryEndpoint": "https://login.example.com/", "graphEndpoint": "https://graph.example.com/", "keyVaultDNSSuffix": "vault.example.com", "resourceIdentifiers": {"graph": "https://graph.example.com/", "keyVault": "https://vault.example.com"}}`,
+	}
+
+	for name, contents := range testData {
+		t.Logf("[DEBUG] Testing %q", name)
+
+		path := filepath.Join(t.TempDir(), "environment.json")
+		if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+			t.Fatalf("writing environment file: %+v", err)
+		}
+
+		if _, _, err := EnvironmentFromFile(path); err == nil {
+			t.Fatalf("expected an error for %q", name)
+		}
+	}
+
+	if _, _, err := EnvironmentFromFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Fatalf("expected an error when the file doesn't exist")
+	}
+}
@@ -7,9 +7,9 @@ import (
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
-	"github.com/hashicorp/go-azure-helpers/sender"
 	"github.com/hashicorp/terraform-plugin-sdk/meta"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/providercache"
 	"github.com/terraform-providers/terraform-provider-azurerm/version"
 )
 
@@ -33,17 +33,56 @@ type ClientOptions struct {
 	Environment                 azure.Environment
 	Features                    features.UserFeatures
 	StorageUseAzureAD           bool
+
+	// ConcurrencyLimiter limits the number of concurrent requests sent to each Resource Provider - when nil
+	// the number of concurrent requests is unlimited
+	ConcurrencyLimiter *ConcurrencyLimiter
+
+	// Retries configures how requests are retried - when nil autorest's defaults are used
+	Retries *RetryOptions
+
+	// SendDecorators are applied to the Sender used by each client, for example to record and replay requests in tests
+	SendDecorators []autorest.SendDecorator
+
+	// ProviderCache caches metadata looked up by the clients across runs of Terraform - when nil nothing is cached
+	ProviderCache *providercache.Cache
+
+	// LogRedaction redacts secrets from the requests and responses which are logged - when nil these are
+	// logged as-is (other than the Authorization header)
+	LogRedaction *LogRedaction
 }
 
 func (o ClientOptions) ConfigureClient(c *autorest.Client, authorizer autorest.Authorizer) {
 	setUserAgent(c, o.TerraformVersion, o.PartnerId, o.DisableTerraformPartnerID)
 
 	c.Authorizer = authorizer
-	c.Sender = sender.BuildSender("AzureRM")
+	c.Sender = BuildSender("AzureRM", o.LogRedaction)
 	c.SkipResourceProviderRegistration = o.SkipProviderReg
 	if !o.DisableCorrelationRequestID {
 		c.RequestInspector = withCorrelationRequestID(correlationRequestID())
 	}
+
+	o.ConfigureSender(c)
+}
+
+// ConfigureSender configures the Sender used by the specified client (or autorest's default Sender when unset),
+// which allows Data Plane clients which are otherwise configured separately to send requests in the same manner
+func (o ClientOptions) ConfigureSender(c *autorest.Client) {
+	if len(o.SendDecorators) > 0 {
+		sender := c.Sender
+		if sender == nil {
+			sender = autorest.CreateSender()
+		}
+		c.Sender = autorest.DecorateSender(sender, o.SendDecorators...)
+	}
+
+	// the concurrency limit is applied to each attempt, so that a slot isn't held whilst waiting to retry
+	if o.ConcurrencyLimiter != nil {
+		o.ConcurrencyLimiter.ConfigureClient(c)
+	}
+	if o.Retries != nil {
+		o.Retries.ConfigureClient(c)
+	}
 }
 
 func setUserAgent(client *autorest.Client, tfVersion, partnerID string, disableTerraformPartnerID bool) {
@@ -0,0 +1,147 @@
+package common
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tracing"
+)
+
+// ConcurrencyLimits specify the maximum number of concurrent requests which can be sent to
+// Resource Manager for each Resource Provider within each Subscription
+type ConcurrencyLimits struct {
+	// Default is the limit used for Resource Providers which aren't defined in ResourceProviders,
+	// where 0 means that the number of concurrent requests is unlimited
+	Default int
+
+	// ResourceProviders is a map of the Resource Provider Namespace (e.g. `Microsoft.Network`)
+	// to the limit for that Resource Provider
+	ResourceProviders map[string]int
+}
+
+// ConcurrencyLimiter gates outgoing requests to Resource Manager according to the ConcurrencyLimits,
+// which is shared across all clients so that the limits apply to the Provider as a whole
+type ConcurrencyLimiter struct {
+	limits ConcurrencyLimits
+
+	lock       sync.Mutex
+	semaphores map[string]chan struct{}
+}
+
+func NewConcurrencyLimiter(limits ConcurrencyLimits) *ConcurrencyLimiter {
+	resourceProviders := make(map[string]int, len(limits.ResourceProviders))
+	for k, v := range limits.ResourceProviders {
+		resourceProviders[strings.ToLower(k)] = v
+	}
+
+	return &ConcurrencyLimiter{
+		limits: ConcurrencyLimits{
+			Default:           limits.Default,
+			ResourceProviders: resourceProviders,
+		},
+		semaphores: map[string]chan struct{}{},
+	}
+}
+
+// ConfigureClient configures the specified client to wait for a free slot before sending each request
+func (l *ConcurrencyLimiter) ConfigureClient(c *autorest.Client) {
+	sender := c.Sender
+	if sender == nil {
+		sender = autorest.CreateSender()
+	}
+	c.Sender = autorest.DecorateSender(sender, l.withConcurrencyLimit())
+}
+
+func (l *ConcurrencyLimiter) withConcurrencyLimit() autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			subscriptionId, namespace, ok := parseResourceProviderFromUri(r.URL.Path)
+			if !ok {
+				return s.Do(r)
+			}
+
+			semaphore := l.semaphore(subscriptionId, namespace)
+			if semaphore == nil {
+				return s.Do(r)
+			}
+
+			select {
+			case semaphore <- struct{}{}:
+			default:
+				start := time.Now()
+				_, span := tracing.StartSpan(r.Context(), "ConcurrencyLimit.Wait")
+				span.SetAttribute("azure.resource_provider", namespace)
+				span.SetAttribute("azurerm.concurrency_limit", cap(semaphore))
+				select {
+				case semaphore <- struct{}{}:
+					span.End(nil)
+					log.Printf("[DEBUG] Waited %s for a free slot to send %s %s (limit for %q is %d)", time.Since(start), r.Method, r.URL.Path, namespace, cap(semaphore))
+				case <-r.Context().Done():
+					span.End(r.Context().Err())
+					return nil, r.Context().Err()
+				}
+			}
+			defer func() {
+				<-semaphore
+			}()
+
+			return s.Do(r)
+		})
+	}
+}
+
+// semaphore returns the semaphore for the specified Resource Provider within the specified Subscription,
+// or nil if the number of concurrent requests is unlimited
+func (l *ConcurrencyLimiter) semaphore(subscriptionId, namespace string) chan struct{} {
+	namespace = strings.ToLower(namespace)
+	limit, ok := l.limits.ResourceProviders[namespace]
+	if !ok {
+		limit = l.limits.Default
+	}
+	if limit <= 0 {
+		return nil
+	}
+
+	key := strings.ToLower(subscriptionId) + "/" + namespace
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	semaphore, ok := l.semaphores[key]
+	if !ok {
+		semaphore = make(chan struct{}, limit)
+		l.semaphores[key] = semaphore
+	}
+	return semaphore
+}
+
+// parseResourceProviderFromUri returns the Subscription ID and the Namespace of the Resource Provider being
+// called from the specified Resource Manager URI. Requests made to nested resources (for example Management
+// Locks on a Virtual Network) are sent to the last Resource Provider in the URI, whereas requests to the
+// Resource Group/Subscription are sent to `Microsoft.Resources`.
+func parseResourceProviderFromUri(path string) (subscriptionId string, namespace string, ok bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		switch strings.ToLower(segments[i]) {
+		case "subscriptions":
+			if subscriptionId == "" {
+				subscriptionId = segments[i+1]
+			}
+		case "providers":
+			namespace = segments[i+1]
+		}
+	}
+
+	if subscriptionId == "" && namespace == "" {
+		return "", "", false
+	}
+	if namespace == "" {
+		namespace = "Microsoft.Resources"
+	}
+
+	return subscriptionId, namespace, true
+}
@@ -0,0 +1,135 @@
+package common
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+func TestParseResourceProviderFromUri(t *testing.T) {
+	testData := []struct {
+		Name                   string
+		Input                  string
+		ExpectedSubscriptionId string
+		ExpectedNamespace      string
+		ExpectedOk             bool
+	}{
+		{
+			Name:       "Data Plane",
+			Input:      "/secrets/example/1234",
+			ExpectedOk: false,
+		},
+		{
+			Name:                   "Resource Group",
+			Input:                  "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+			ExpectedSubscriptionId: "00000000-0000-0000-0000-000000000000",
+			ExpectedNamespace:      "Microsoft.Resources",
+			ExpectedOk:             true,
+		},
+		{
+			Name:                   "Resource",
+			Input:                  "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example",
+			ExpectedSubscriptionId: "00000000-0000-0000-0000-000000000000",
+			ExpectedNamespace:      "Microsoft.Network",
+			ExpectedOk:             true,
+		},
+		{
+			Name:                   "Nested Resource Provider",
+			Input:                  "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example/providers/Microsoft.Authorization/locks/example",
+			ExpectedSubscriptionId: "00000000-0000-0000-0000-000000000000",
+			ExpectedNamespace:      "Microsoft.Authorization",
+			ExpectedOk:             true,
+		},
+		{
+			Name:              "Tenant Level",
+			Input:             "/providers/Microsoft.Management/managementGroups/example",
+			ExpectedNamespace: "Microsoft.Management",
+			ExpectedOk:        true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		subscriptionId, namespace, ok := parseResourceProviderFromUri(v.Input)
+		if ok != v.ExpectedOk {
+			t.Fatalf("Expected ok to be %t but got %t", v.ExpectedOk, ok)
+		}
+		if subscriptionId != v.ExpectedSubscriptionId {
+			t.Fatalf("Expected the Subscription ID to be %q but got %q", v.ExpectedSubscriptionId, subscriptionId)
+		}
+		if namespace != v.ExpectedNamespace {
+			t.Fatalf("Expected the Namespace to be %q but got %q", v.ExpectedNamespace, namespace)
+		}
+	}
+}
+
+func TestConcurrencyLimiterLimitsRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			existing := atomic.LoadInt32(&maxInFlight)
+			if current <= existing || atomic.CompareAndSwapInt32(&maxInFlight, existing, current) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	limiter := NewConcurrencyLimiter(ConcurrencyLimits{
+		Default: 10,
+		ResourceProviders: map[string]int{
+			"microsoft.network": 2,
+		},
+	})
+	client := autorest.NewClientWithUserAgent("")
+	limiter.ConfigureClient(&client)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req, err := http.NewRequest(http.MethodPut, server.URL+"/subscriptions/1234/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example", nil)
+			if err != nil {
+				t.Errorf("building request: %+v", err)
+				return
+			}
+			if _, err := client.Send(req); err != nil {
+				t.Errorf("sending request: %+v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if actual := atomic.LoadInt32(&maxInFlight); actual > 2 {
+		t.Fatalf("Expected at most 2 concurrent requests but got %d", actual)
+	}
+}
+
+func TestConcurrencyLimiterUnlimited(t *testing.T) {
+	limiter := NewConcurrencyLimiter(ConcurrencyLimits{
+		ResourceProviders: map[string]int{
+			"Microsoft.Network": 2,
+		},
+	})
+
+	if limiter.semaphore("1234", "Microsoft.Compute") != nil {
+		t.Fatalf("Expected requests to Microsoft.Compute to be unlimited")
+	}
+	if limiter.semaphore("1234", "MICROSOFT.NETWORK") == nil {
+		t.Fatalf("Expected requests to Microsoft.Network to be limited")
+	}
+}
@@ -39,3 +39,9 @@ func correlationRequestID() string {
 
 	return msCorrelationRequestID
 }
+
+// CorrelationRequestID returns the UUID sent in the `x-ms-correlation-request-id` header
+// for each request made by this Provider, for example to be included in log messages
+func CorrelationRequestID() string {
+	return correlationRequestID()
+}
@@ -0,0 +1,151 @@
+package common
+
+import (
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"regexp"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-helpers/sender"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tracing"
+)
+
+const redacted = "[REDACTED]"
+
+// DefaultLogRedactionKeys are the names of the JSON properties whose values are redacted from the
+// requests and responses which are logged, in addition to any configured in the Provider block
+var DefaultLogRedactionKeys = []string{
+	"accessKey",
+	"access_token",
+	"accountKey",
+	"accountSasToken",
+	"adminPassword",
+	"administratorLoginPassword",
+	"clientSecret",
+	"connectionString",
+	"password",
+	"primaryAccessKey",
+	"primaryConnectionString",
+	"primaryKey",
+	"primaryMasterKey",
+	"primaryReadonlyMasterKey",
+	"refresh_token",
+	"sasToken",
+	"secondaryAccessKey",
+	"secondaryConnectionString",
+	"secondaryKey",
+	"secondaryMasterKey",
+	"secondaryReadonlyMasterKey",
+	"secret",
+	"serviceSasToken",
+	"storageAccountKey",
+}
+
+var (
+	// the Authorization header contains the Bearer Token (or the Shared Key when using Storage) and the
+	// auxiliary header contains the Bearer Tokens for each of the Auxiliary Tenants
+	logRedactionHeaders = regexp.MustCompile(`(?im)^(Authorization|X-Ms-Authorization-Auxiliary|X-Ms-Copy-Source-Authorization):.*$`)
+
+	// SAS Tokens and Connection Strings can appear both in URIs and within the bodies of requests/responses - and the
+	// Client Secret, Client Assertion (e.g. the OIDC Token) and Refresh Token within form-encoded requests for a Token
+	logRedactionParameters = regexp.MustCompile(`(?i)(\bsig=|\bAccountKey=|\bSharedAccessKey=|\bclient_secret=|\bclient_assertion=|\brefresh_token=)[^&;"'\s]+`)
+
+	// `value` contains the secret when retrieving Key Vault Secrets and listing Keys (e.g. `listKeys`)
+	logRedactionValue = regexp.MustCompile(`(?i)("value")(\s*:\s*)"(?:[^"\\]|\\.)*"`)
+)
+
+// LogRedaction redacts secrets from the requests and responses which are logged (when running with `TF_LOG=DEBUG`)
+// such that these aren't exposed in the logs, for example when running Terraform in CI
+type LogRedaction struct {
+	properties *regexp.Regexp
+}
+
+// NewLogRedaction returns a LogRedaction which redacts the values of the default JSON properties along
+// with any additional JSON properties specified (which are matched case-insensitively)
+func NewLogRedaction(additionalKeys []string) *LogRedaction {
+	keys := make([]string, 0, len(DefaultLogRedactionKeys)+len(additionalKeys))
+	for _, key := range DefaultLogRedactionKeys {
+		keys = append(keys, regexp.QuoteMeta(key))
+	}
+	for _, key := range additionalKeys {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, regexp.QuoteMeta(key))
+		}
+	}
+
+	return &LogRedaction{
+		properties: regexp.MustCompile(`(?i)("(?:` + strings.Join(keys, "|") + `)")(\s*:\s*)"(?:[^"\\]|\\.)*"`),
+	}
+}
+
+// Redact returns the request/response dump with any secrets redacted, where the path of the request
+// determines whether the `value` property is considered to be a secret
+func (r LogRedaction) Redact(dump string, requestPath string) string {
+	output := logRedactionHeaders.ReplaceAllString(dump, "$1: "+redacted)
+	output = logRedactionParameters.ReplaceAllString(output, "${1}"+redacted)
+	output = r.properties.ReplaceAllString(output, `$1$2"`+redacted+`"`)
+
+	if isSecretValuePath(requestPath) {
+		output = logRedactionValue.ReplaceAllString(output, `$1$2"`+redacted+`"`)
+	}
+
+	return output
+}
+
+// isSecretValuePath returns whether the `value` property of a request to/response from the specified path is a
+// secret - which is the case for Key Vault Secrets and for actions which list or regenerate Keys/Connection Strings
+func isSecretValuePath(path string) bool {
+	path = strings.ToLower(strings.TrimSuffix(path, "/"))
+	if strings.Contains(path, "/secrets/") || strings.HasSuffix(path, "/secrets") {
+		return true
+	}
+
+	action := path[strings.LastIndex(path, "/")+1:]
+	return strings.HasPrefix(action, "list") || strings.HasPrefix(action, "regenerate")
+}
+
+// BuildSender returns the Sender used to send requests to Azure, which logs each request and response - with
+// any secrets redacted when a LogRedaction is specified - and records a Span for each when tracing is enabled
+func BuildSender(providerName string, redaction *LogRedaction) autorest.Sender {
+	if redaction == nil {
+		return autorest.DecorateSender(sender.BuildSender(providerName), tracing.WithRequestSpans())
+	}
+
+	return autorest.DecorateSender(&http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+		},
+	}, withRedactedRequestLogging(providerName, *redaction), tracing.WithRequestSpans())
+}
+
+func withRedactedRequestLogging(providerName string, redaction LogRedaction) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			url := redaction.Redact(r.URL.String(), r.URL.Path)
+
+			// dump request to wire format
+			if dump, err := httputil.DumpRequestOut(r, true); err == nil {
+				log.Printf("[DEBUG] %s Request: \n%s\n", providerName, redaction.Redact(string(dump), r.URL.Path))
+			} else {
+				// fallback to basic message
+				log.Printf("[DEBUG] %s Request: %s to %s\n", providerName, r.Method, url)
+			}
+
+			resp, err := s.Do(r)
+			if resp != nil {
+				// dump response to wire format
+				if dump, err2 := httputil.DumpResponse(resp, true); err2 == nil {
+					log.Printf("[DEBUG] %s Response for %s: \n%s\n", providerName, url, redaction.Redact(string(dump), r.URL.Path))
+				} else {
+					// fallback to basic message
+					log.Printf("[DEBUG] %s Response: %s for %s\n", providerName, resp.Status, url)
+				}
+			} else {
+				log.Printf("[DEBUG] Request to %s completed with no response", url)
+			}
+			return resp, err
+		})
+	}
+}
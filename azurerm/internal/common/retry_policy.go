@@ -0,0 +1,239 @@
+package common
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tracing"
+)
+
+const (
+	headerRateLimitRemainingReads  = "x-ms-ratelimit-remaining-subscription-reads"
+	headerRateLimitRemainingWrites = "x-ms-ratelimit-remaining-subscription-writes"
+	headerRetryAfter               = "Retry-After"
+
+	// rateLimitThreshold is the number of remaining requests below which subsequent requests
+	// are delayed, to avoid being throttled
+	rateLimitThreshold = 10
+)
+
+// RetryOptions configure how requests to both the Resource Manager and Data Plane API's are retried
+type RetryOptions struct {
+	// MaxRetries is the maximum number of times a request is retried
+	MaxRetries int
+
+	// MinBackoff is the delay before the first retry, which is doubled for each subsequent retry
+	MinBackoff time.Duration
+
+	// MaxBackoff is the maximum delay between retries
+	MaxBackoff time.Duration
+
+	// HonourRateLimitHeaders specifies whether the delay specified in the `Retry-After` header should
+	// be used rather than the backoff, and whether requests should be delayed when the
+	// `x-ms-ratelimit-remaining-subscription-*` headers indicate that requests are about to be throttled
+	HonourRateLimitHeaders bool
+}
+
+// ConfigureClient configures the specified client to retry requests according to these RetryOptions
+func (o RetryOptions) ConfigureClient(c *autorest.Client) {
+	// autorest is responsible for retrying the request (which allows the request to be retried after
+	// the Resource Provider has been registered) - however the delay between retries is applied by the
+	// retry policy so that this can be bounded, as such autorest's own backoff is disabled
+	c.RetryAttempts = o.MaxRetries
+	c.RetryDuration = 0
+
+	sender := c.Sender
+	if sender == nil {
+		sender = autorest.CreateSender()
+	}
+	c.Sender = autorest.DecorateSender(sender, withRetryPolicy(o))
+}
+
+// backoff returns the delay before the specified (zero-indexed) retry
+func (o RetryOptions) backoff(attempt int) time.Duration {
+	delay := float64(o.MinBackoff) * math.Pow(2, float64(attempt))
+	if delay > float64(o.MaxBackoff) {
+		return o.MaxBackoff
+	}
+
+	return time.Duration(delay)
+}
+
+// retryDelay returns the delay before retrying the specified request, and whether it should be retried
+func (o RetryOptions) retryDelay(resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if attempt >= o.MaxRetries || autorest.IsTokenRefreshError(err) {
+		return 0, false
+	}
+
+	if err == nil && !autorest.ResponseHasStatusCode(resp, autorest.StatusCodesForRetry...) {
+		return 0, false
+	}
+
+	if o.HonourRateLimitHeaders {
+		if delay, ok := retryAfter(resp); ok {
+			return delay, true
+		}
+	}
+
+	return o.backoff(attempt), true
+}
+
+// rateLimitDelay returns the delay which should be applied to subsequent requests of the same
+// kind, based on the number of remaining requests reported by Resource Manager
+func (o RetryOptions) rateLimitDelay(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+
+	header := headerRateLimitRemainingWrites
+	if isReadRequest(resp.Request) {
+		header = headerRateLimitRemainingReads
+	}
+
+	remaining, err := strconv.Atoi(resp.Header.Get(header))
+	if err != nil || remaining >= rateLimitThreshold {
+		return 0
+	}
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	delay := o.MinBackoff * time.Duration(rateLimitThreshold-remaining) / rateLimitThreshold
+	if delay > o.MaxBackoff {
+		return o.MaxBackoff
+	}
+	return delay
+}
+
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := resp.Header.Get(headerRetryAfter)
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := time.Parse(time.RFC1123, value); err == nil {
+		if delay := time.Until(t); delay > 0 {
+			return delay, true
+		}
+	}
+
+	return 0, false
+}
+
+func isReadRequest(r *http.Request) bool {
+	return r == nil || r.Method == http.MethodGet || r.Method == http.MethodHead
+}
+
+// rateLimits tracks when requests can next be sent for each kind of request (reads or writes)
+// to each host - which is shared across clients since the limits apply to the Subscription
+var rateLimits = &rateLimitTracker{
+	notBefore: map[string]time.Time{},
+}
+
+type rateLimitTracker struct {
+	lock      sync.Mutex
+	notBefore map[string]time.Time
+}
+
+func (t *rateLimitTracker) delay(key string) time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return time.Until(t.notBefore[key])
+}
+
+func (t *rateLimitTracker) slowDown(key string, delay time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if notBefore := time.Now().Add(delay); notBefore.After(t.notBefore[key]) {
+		t.notBefore[key] = notBefore
+	}
+}
+
+func rateLimitKey(r *http.Request) string {
+	kind := "writes"
+	if isReadRequest(r) {
+		kind = "reads"
+	}
+	return strings.ToLower(r.URL.Host) + "/" + kind
+}
+
+func withRetryPolicy(options RetryOptions) autorest.SendDecorator {
+	// the same request is sent for each retry, as such the number of attempts for each
+	// request is tracked so that the backoff can be calculated
+	var lock sync.Mutex
+	attempts := make(map[*http.Request]int)
+
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			key := rateLimitKey(r)
+			if options.HonourRateLimitHeaders {
+				if delay := rateLimits.delay(key); delay > 0 {
+					log.Printf("[DEBUG] Delaying %s %s by %s to avoid being throttled", r.Method, r.URL, delay)
+					if !sleep(r, delay, "Throttling.Delay") {
+						return nil, r.Context().Err()
+					}
+				}
+			}
+
+			resp, err := s.Do(r)
+
+			if options.HonourRateLimitHeaders {
+				if delay := options.rateLimitDelay(resp); delay > 0 {
+					rateLimits.slowDown(key, delay)
+				}
+			}
+
+			lock.Lock()
+			attempt := attempts[r]
+			delay, retry := options.retryDelay(resp, err, attempt)
+			if retry {
+				attempts[r] = attempt + 1
+			} else {
+				delete(attempts, r)
+			}
+			lock.Unlock()
+
+			if !retry {
+				return resp, err
+			}
+
+			log.Printf("[DEBUG] Retrying %s %s in %s (attempt %d of %d)", r.Method, r.URL, delay, attempt+1, options.MaxRetries)
+			if resp != nil {
+				// autorest would otherwise wait for this duration again
+				resp.Header.Del(headerRetryAfter)
+			}
+			if !sleep(r, delay, "Retry.Backoff") {
+				lock.Lock()
+				delete(attempts, r)
+				lock.Unlock()
+			}
+
+			return resp, err
+		})
+	}
+}
+
+func sleep(r *http.Request, delay time.Duration, spanName string) bool {
+	_, span := tracing.StartSpan(r.Context(), spanName)
+	span.SetAttribute("azurerm.delay_ms", delay.Milliseconds())
+
+	select {
+	case <-time.After(delay):
+		span.End(nil)
+		return true
+	case <-r.Context().Done():
+		span.End(r.Context().Err())
+		return false
+	}
+}
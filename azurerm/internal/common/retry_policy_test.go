@@ -0,0 +1,245 @@
+package common
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+func TestRetryOptionsBackoff(t *testing.T) {
+	options := RetryOptions{
+		MaxRetries: 10,
+		MinBackoff: 5 * time.Second,
+		MaxBackoff: 30 * time.Second,
+	}
+
+	testData := []struct {
+		Attempt  int
+		Expected time.Duration
+	}{
+		{
+			Attempt:  0,
+			Expected: 5 * time.Second,
+		},
+		{
+			Attempt:  1,
+			Expected: 10 * time.Second,
+		},
+		{
+			Attempt:  2,
+			Expected: 20 * time.Second,
+		},
+		{
+			Attempt:  3,
+			Expected: 30 * time.Second,
+		},
+		{
+			Attempt:  9,
+			Expected: 30 * time.Second,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test Attempt %d", v.Attempt)
+
+		if actual := options.backoff(v.Attempt); actual != v.Expected {
+			t.Fatalf("Expected %s but got %s", v.Expected, actual)
+		}
+	}
+}
+
+func TestRetryOptionsRetryDelay(t *testing.T) {
+	response := func(statusCode int, retryAfter string) *http.Response {
+		resp := &http.Response{
+			StatusCode: statusCode,
+			Header:     http.Header{},
+		}
+		if retryAfter != "" {
+			resp.Header.Set(headerRetryAfter, retryAfter)
+		}
+		return resp
+	}
+
+	testData := []struct {
+		Name          string
+		Honour        bool
+		Response      *http.Response
+		Attempt       int
+		ExpectedDelay time.Duration
+		ExpectedRetry bool
+	}{
+		{
+			Name:          "Success",
+			Response:      response(http.StatusOK, ""),
+			ExpectedRetry: false,
+		},
+		{
+			Name:          "Not Found",
+			Response:      response(http.StatusNotFound, ""),
+			ExpectedRetry: false,
+		},
+		{
+			Name:          "Service Unavailable",
+			Response:      response(http.StatusServiceUnavailable, ""),
+			Attempt:       1,
+			ExpectedDelay: 2 * time.Second,
+			ExpectedRetry: true,
+		},
+		{
+			Name:          "Retries Exhausted",
+			Response:      response(http.StatusServiceUnavailable, ""),
+			Attempt:       3,
+			ExpectedRetry: false,
+		},
+		{
+			Name:          "Throttled Ignoring Retry-After",
+			Response:      response(http.StatusTooManyRequests, "17"),
+			ExpectedDelay: time.Second,
+			ExpectedRetry: true,
+		},
+		{
+			Name:          "Throttled Honouring Retry-After",
+			Honour:        true,
+			Response:      response(http.StatusTooManyRequests, "17"),
+			ExpectedDelay: 17 * time.Second,
+			ExpectedRetry: true,
+		},
+		{
+			Name:          "Throttled Honouring Invalid Retry-After",
+			Honour:        true,
+			Response:      response(http.StatusTooManyRequests, "soon"),
+			ExpectedDelay: time.Second,
+			ExpectedRetry: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		options := RetryOptions{
+			MaxRetries:             3,
+			MinBackoff:             time.Second,
+			MaxBackoff:             10 * time.Second,
+			HonourRateLimitHeaders: v.Honour,
+		}
+		delay, retry := options.retryDelay(v.Response, nil, v.Attempt)
+		if retry != v.ExpectedRetry {
+			t.Fatalf("Expected retry to be %t but got %t", v.ExpectedRetry, retry)
+		}
+		if delay != v.ExpectedDelay {
+			t.Fatalf("Expected a delay of %s but got %s", v.ExpectedDelay, delay)
+		}
+	}
+}
+
+func TestRetryOptionsRateLimitDelay(t *testing.T) {
+	options := RetryOptions{
+		MinBackoff: 10 * time.Second,
+		MaxBackoff: time.Minute,
+	}
+
+	testData := []struct {
+		Name     string
+		Method   string
+		Header   string
+		Value    string
+		Expected time.Duration
+	}{
+		{
+			Name:     "No Header",
+			Method:   http.MethodGet,
+			Expected: 0,
+		},
+		{
+			Name:     "Plenty Of Reads Remaining",
+			Method:   http.MethodGet,
+			Header:   headerRateLimitRemainingReads,
+			Value:    "11999",
+			Expected: 0,
+		},
+		{
+			Name:     "Few Reads Remaining",
+			Method:   http.MethodGet,
+			Header:   headerRateLimitRemainingReads,
+			Value:    "5",
+			Expected: 5 * time.Second,
+		},
+		{
+			Name:     "No Writes Remaining",
+			Method:   http.MethodPut,
+			Header:   headerRateLimitRemainingWrites,
+			Value:    "0",
+			Expected: 10 * time.Second,
+		},
+		{
+			Name:     "Reads Header On A Write",
+			Method:   http.MethodDelete,
+			Header:   headerRateLimitRemainingReads,
+			Value:    "0",
+			Expected: 0,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		resp := &http.Response{
+			Header: http.Header{},
+			Request: &http.Request{
+				Method: v.Method,
+			},
+		}
+		if v.Header != "" {
+			resp.Header.Set(v.Header, v.Value)
+		}
+
+		if actual := options.rateLimitDelay(resp); actual != v.Expected {
+			t.Fatalf("Expected %s but got %s", v.Expected, actual)
+		}
+	}
+}
+
+func TestRetryOptionsConfigureClient(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.Header().Set(headerRetryAfter, "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := autorest.NewClientWithUserAgent("")
+	RetryOptions{
+		MaxRetries: 5,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 10 * time.Millisecond,
+	}.ConfigureClient(&client)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+
+	// this mirrors how the generated clients send requests
+	start := time.Now()
+	resp, err := client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected a 200 but got %d", resp.StatusCode)
+	}
+	if requests != 3 {
+		t.Fatalf("Expected 3 requests but got %d", requests)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("Expected the `Retry-After` header to be ignored but the request took %s", elapsed)
+	}
+}
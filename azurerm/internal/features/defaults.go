@@ -3,6 +3,18 @@ package features
 func Default() UserFeatures {
 	return UserFeatures{
 		// NOTE: ensure all nested objects are fully populated
+		ApiManagement: SoftDeleteFeatures{
+			RecoverSoftDeleted:       false,
+			PurgeSoftDeleteOnDestroy: false,
+		},
+		AppConfiguration: SoftDeleteFeatures{
+			RecoverSoftDeleted:       false,
+			PurgeSoftDeleteOnDestroy: false,
+		},
+		CognitiveAccount: SoftDeleteFeatures{
+			RecoverSoftDeleted:       false,
+			PurgeSoftDeleteOnDestroy: false,
+		},
 		KeyVault: KeyVaultFeatures{
 			PurgeSoftDeleteOnDestroy:    true,
 			RecoverSoftDeletedKeyVaults: true,
@@ -10,9 +22,19 @@ func Default() UserFeatures {
 		LogAnalyticsWorkspace: LogAnalyticsWorkspaceFeatures{
 			PermanentlyDeleteOnDestroy: false,
 		},
+		ManagementLock: ManagementLockFeatures{
+			TemporarilyLiftManagedLocks: false,
+		},
 		Network: NetworkFeatures{
 			RelaxedLocking: false,
 		},
+		RecoveryServicesVault: SoftDeleteFeatures{
+			RecoverSoftDeleted:       false,
+			PurgeSoftDeleteOnDestroy: false,
+		},
+		ResourceGroup: ResourceGroupFeatures{
+			MoveResourcesOnGroupChange: false,
+		},
 		TemplateDeployment: TemplateDeploymentFeatures{
 			DeleteNestedItemsDuringDeletion: true,
 		},
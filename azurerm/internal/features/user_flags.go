@@ -5,8 +5,14 @@ type UserFeatures struct {
 	VirtualMachineScaleSet VirtualMachineScaleSetFeatures
 	KeyVault               KeyVaultFeatures
 	Network                NetworkFeatures
+	ResourceGroup          ResourceGroupFeatures
 	TemplateDeployment     TemplateDeploymentFeatures
 	LogAnalyticsWorkspace  LogAnalyticsWorkspaceFeatures
+	ManagementLock         ManagementLockFeatures
+	ApiManagement          SoftDeleteFeatures
+	AppConfiguration       SoftDeleteFeatures
+	CognitiveAccount       SoftDeleteFeatures
+	RecoveryServicesVault  SoftDeleteFeatures
 }
 
 type VirtualMachineFeatures struct {
@@ -23,10 +29,22 @@ type KeyVaultFeatures struct {
 	RecoverSoftDeletedKeyVaults bool
 }
 
+// SoftDeleteFeatures returns the behaviour for Soft Deleted Key Vaults and the Certificates, Keys and Secrets within them
+func (f KeyVaultFeatures) SoftDeleteFeatures() SoftDeleteFeatures {
+	return SoftDeleteFeatures{
+		RecoverSoftDeleted:       f.RecoverSoftDeletedKeyVaults,
+		PurgeSoftDeleteOnDestroy: f.PurgeSoftDeleteOnDestroy,
+	}
+}
+
 type NetworkFeatures struct {
 	RelaxedLocking bool
 }
 
+type ResourceGroupFeatures struct {
+	MoveResourcesOnGroupChange bool
+}
+
 type TemplateDeploymentFeatures struct {
 	DeleteNestedItemsDuringDeletion bool
 }
@@ -34,3 +52,14 @@ type TemplateDeploymentFeatures struct {
 type LogAnalyticsWorkspaceFeatures struct {
 	PermanentlyDeleteOnDestroy bool
 }
+
+type ManagementLockFeatures struct {
+	TemporarilyLiftManagedLocks bool
+}
+
+// SoftDeleteFeatures controls the behaviour of a Resource which is Soft Deleted (rather than being permanently
+// deleted) when it's deleted - see the Soft Delete helpers within the `sdk` package
+type SoftDeleteFeatures struct {
+	RecoverSoftDeleted       bool
+	PurgeSoftDeleteOnDestroy bool
+}
@@ -3,21 +3,94 @@ package location
 import (
 	"context"
 	"log"
+	"sync"
+	"time"
 
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/providercache"
+)
+
+const (
+	locationsCacheKey = "locations"
+	locationsCacheTTL = 24 * time.Hour
 )
 
 // supportedLocations can be (validly) nil - as such this shouldn't be relied on
 var supportedLocations *[]string
 
+// refreshLocations is set when the supported locations were loaded from the on-disk cache (which could be
+// out of date), allowing these to be retrieved from the API once should a location not be found
+var (
+	refreshLocations     func() (*[]string, error)
+	refreshLocationsLock = sync.Mutex{}
+)
+
 // CacheSupportedLocations attempts to retrieve the supported locations from the Azure MetaData Service
-// and caches them, for used in enhanced validation
-func CacheSupportedLocations(ctx context.Context, env *azure.Environment) {
-	locs, err := availableAzureLocations(ctx, env)
+// (or the on-disk cache, when enabled) using the specified Sender and caches them, for used in enhanced validation
+func CacheSupportedLocations(ctx context.Context, env *azure.Environment, sender autorest.Sender, cache *providercache.Cache) {
+	refresh := func() (*[]string, error) {
+		locs, err := availableAzureLocations(ctx, env, sender)
+		if err != nil {
+			return nil, err
+		}
+
+		if locs.Locations != nil {
+			cache.Set(locationsCacheKey, *locs.Locations)
+		}
+		return locs.Locations, nil
+	}
+
+	var locations []string
+	if cache.Get(locationsCacheKey, locationsCacheTTL, &locations) {
+		log.Printf("[DEBUG] Loaded %d locations from the on-disk cache", len(locations))
+		refreshLocationsLock.Lock()
+		refreshLocations = refresh
+		refreshLocationsLock.Unlock()
+
+		supportedLocations = &locations
+		return
+	}
+
+	locs, err := refresh()
 	if err != nil {
 		log.Printf("[DEBUG] error retrieving locations: %s. Enhanced validation will be unavailable", err)
 		return
 	}
 
-	supportedLocations = locs.Locations
+	supportedLocations = locs
+}
+
+// refreshCachedLocations retrieves the supported locations from the Azure MetaData Service if these were loaded
+// from the on-disk cache, returning whether these were refreshed
+func refreshCachedLocations() bool {
+	refreshLocationsLock.Lock()
+	defer refreshLocationsLock.Unlock()
+
+	if refreshLocations == nil {
+		return false
+	}
+
+	refresh := refreshLocations
+	refreshLocations = nil
+
+	log.Printf("[DEBUG] Cache Miss - refreshing the locations loaded from the on-disk cache..")
+	locations, err := refresh()
+	if err != nil || locations == nil {
+		log.Printf("[DEBUG] error refreshing locations: %v", err)
+		return false
+	}
+
+	supportedLocations = locations
+	return true
+}
+
+// SeedSupportedLocations caches the specified locations for use in enhanced validation, rather than retrieving
+// these from the Azure MetaData Service - for example when these are specified in an Environment File
+func SeedSupportedLocations(locations []string) {
+	refreshLocationsLock.Lock()
+	refreshLocations = nil
+	refreshLocationsLock.Unlock()
+
+	supportedLocations = &locations
 }
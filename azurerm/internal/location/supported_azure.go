@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
 )
 
@@ -26,26 +27,23 @@ type metaDataResponse struct {
 	CloudEndpoint map[string]cloudEndpoint `json:"cloudEndpoint"`
 }
 
-// availableAzureLocations returns a list of the Azure Locations which are available on the specified endpoint
-func availableAzureLocations(ctx context.Context, env *azure.Environment) (*SupportedLocations, error) {
+// availableAzureLocations returns a list of the Azure Locations which are available on the specified endpoint,
+// using the specified Sender to make the request
+func availableAzureLocations(ctx context.Context, env *azure.Environment, sender autorest.Sender) (*SupportedLocations, error) {
 	// e.g. https://management.azure.com/ but we need management.azure.com
 	endpoint := strings.TrimPrefix(env.ResourceManagerEndpoint, "https://")
 	endpoint = strings.TrimSuffix(endpoint, "/")
 
 	uri := fmt.Sprintf("https://%s//metadata/endpoints?api-version=2018-01-01", endpoint)
-	client := http.Client{
-		Transport: &http.Transport{
-			Proxy: http.ProxyFromEnvironment,
-		},
-	}
 	req, err := http.NewRequestWithContext(ctx, "GET", uri, nil)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := client.Do(req)
+	resp, err := sender.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("retrieving supported locations from Azure MetaData service: %+v", err)
 	}
+	defer resp.Body.Close()
 	var out metaDataResponse
 	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
 		return nil, fmt.Errorf("deserializing JSON from Azure MetaData service: %+v", err)
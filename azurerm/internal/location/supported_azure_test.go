@@ -0,0 +1,36 @@
+package location
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+func TestAvailableAzureLocationsUsesSender(t *testing.T) {
+	var requestedUri string
+	sender := autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		requestedUri = r.URL.String()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"cloudEndpoint": {"public": {"endpoint": "management.azure.com", "locations": ["westeurope", "indiasouth"]}}}`)),
+		}, nil
+	})
+
+	locations, err := availableAzureLocations(context.Background(), &azure.PublicCloud, sender)
+	if err != nil {
+		t.Fatalf("retrieving the available locations: %+v", err)
+	}
+
+	if expected := "https://management.azure.com//metadata/endpoints?api-version=2018-01-01"; requestedUri != expected {
+		t.Fatalf("expected the request to be sent to %q but got %q", expected, requestedUri)
+	}
+	if expected := []string{"westeurope", "southindia"}; locations.Locations == nil || !reflect.DeepEqual(*locations.Locations, expected) {
+		t.Fatalf("expected the locations to be %+v but got %+v", expected, locations.Locations)
+	}
+}
@@ -37,12 +37,11 @@ func enhancedValidation(i interface{}, k string) ([]string, []error) {
 
 	// supportedLocations can be nil if the users offline
 	if supportedLocations != nil {
-		found := false
-		for _, loc := range *supportedLocations {
-			if normalizedUserInput == Normalize(loc) {
-				found = true
-				break
-			}
+		found := isSupportedLocation(normalizedUserInput)
+
+		// the locations loaded from the on-disk cache could be out of date
+		if !found && normalizedUserInput != "global" && refreshCachedLocations() {
+			found = isSupportedLocation(normalizedUserInput)
 		}
 
 		if !found {
@@ -60,3 +59,13 @@ func enhancedValidation(i interface{}, k string) ([]string, []error) {
 
 	return nil, nil
 }
+
+func isSupportedLocation(normalizedInput string) bool {
+	for _, loc := range *supportedLocations {
+		if normalizedInput == Normalize(loc) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,5 +1,7 @@
 package locks
 
+import "context"
+
 // armMutexKV is the instance of MutexKV for ARM resources
 var armMutexKV = NewMutexKV()
 
@@ -7,33 +9,129 @@ func ByID(id string) {
 	armMutexKV.Lock(id)
 }
 
+// ByIDWithContext locks the ID, returning an error if the context is cancelled (for example when
+// the operation times out) before the lock is acquired - in which case the ID shouldn't be unlocked
+func ByIDWithContext(ctx context.Context, id string) error {
+	return armMutexKV.LockWithContext(ctx, id)
+}
+
+// MultipleByIDWithContext locks each of the IDs in a canonical (sorted) order, returning an error if the
+// context is cancelled before all of the IDs are locked - in which case none of the IDs remain locked
+func MultipleByIDWithContext(ctx context.Context, ids ...string) error {
+	return armMutexKV.LockMultipleWithContext(ctx, ids)
+}
+
 // handle the case of using the same name for different kinds of resources
 func ByName(name string, resourceType string) {
-	updatedName := resourceType + "." + name
-	armMutexKV.Lock(updatedName)
+	armMutexKV.Lock(keyForName(name, resourceType))
 }
 
+// ByNameWithContext locks the name, returning an error if the context is cancelled (for example when
+// the operation times out) before the lock is acquired - in which case the name shouldn't be unlocked
+func ByNameWithContext(ctx context.Context, name string, resourceType string) error {
+	return armMutexKV.LockWithContext(ctx, keyForName(name, resourceType))
+}
+
+// MultipleByName locks each of the names, which are locked in a canonical (sorted) order
+// so that callers locking overlapping sets of names can't deadlock
 func MultipleByName(names *[]string, resourceType string) {
-	newSlice := removeDuplicatesFromStringArray(*names)
+	_ = armMutexKV.LockMultipleWithContext(context.Background(), keysForNames(*names, resourceType))
+}
 
-	for _, name := range newSlice {
-		ByName(name, resourceType)
-	}
+// MultipleByNameWithContext locks each of the names in a canonical (sorted) order, returning an error if the
+// context is cancelled before all of the names are locked - in which case none of the names remain locked
+func MultipleByNameWithContext(ctx context.Context, names *[]string, resourceType string) error {
+	return armMutexKV.LockMultipleWithContext(ctx, keysForNames(*names, resourceType))
+}
+
+// NameLock is a name (of a kind of resource) to lock alongside others using MultipleNamesWithContext
+type NameLock struct {
+	Name         string
+	ResourceType string
+
+	// Read locks the name for reading, for resources which are only read (for example the parent
+	// of the resource being modified) - such that others only reading it can proceed in parallel
+	Read bool
+}
+
+// MultipleNamesWithContext locks each of the names (which can be of different kinds of resources) in a canonical
+// (sorted) order, returning an error if the context is cancelled before all of the names are locked - in which case
+// none of the names remain locked. Caller is responsible for calling UnlockMultipleNames when this returns nil
+func MultipleNamesWithContext(ctx context.Context, names ...NameLock) error {
+	writeKeys, readKeys := keysForNameLocks(names)
+	return armMutexKV.LockMultipleReadWriteWithContext(ctx, writeKeys, readKeys)
+}
+
+// ReadByID locks the ID for reading, which allows multiple readers of the same ID (for example
+// child resources which only read the parent) to proceed in parallel whilst excluding writers
+func ReadByID(id string) {
+	armMutexKV.RLock(id)
+}
+
+// ReadByIDWithContext locks the ID for reading, returning an error if the context is cancelled before the lock is acquired
+func ReadByIDWithContext(ctx context.Context, id string) error {
+	return armMutexKV.RLockWithContext(ctx, id)
+}
+
+// ReadByName locks the name for reading, which allows multiple readers of the same name to
+// proceed in parallel whilst excluding writers
+func ReadByName(name string, resourceType string) {
+	armMutexKV.RLock(keyForName(name, resourceType))
+}
+
+// ReadByNameWithContext locks the name for reading, returning an error if the context is cancelled before the lock is acquired
+func ReadByNameWithContext(ctx context.Context, name string, resourceType string) error {
+	return armMutexKV.RLockWithContext(ctx, keyForName(name, resourceType))
 }
 
 func UnlockByID(id string) {
 	armMutexKV.Unlock(id)
 }
 
+func UnlockMultipleByID(ids ...string) {
+	armMutexKV.UnlockMultiple(ids)
+}
+
 func UnlockByName(name string, resourceType string) {
-	updatedName := resourceType + "." + name
-	armMutexKV.Unlock(updatedName)
+	armMutexKV.Unlock(keyForName(name, resourceType))
 }
 
 func UnlockMultipleByName(names *[]string, resourceType string) {
-	newSlice := removeDuplicatesFromStringArray(*names)
+	armMutexKV.UnlockMultiple(keysForNames(*names, resourceType))
+}
+
+func UnlockMultipleNames(names ...NameLock) {
+	writeKeys, readKeys := keysForNameLocks(names)
+	armMutexKV.UnlockMultipleReadWrite(writeKeys, readKeys)
+}
+
+func ReadUnlockByID(id string) {
+	armMutexKV.RUnlock(id)
+}
+
+func ReadUnlockByName(name string, resourceType string) {
+	armMutexKV.RUnlock(keyForName(name, resourceType))
+}
+
+func keyForName(name string, resourceType string) string {
+	return resourceType + "." + name
+}
+
+func keysForNames(names []string, resourceType string) []string {
+	keys := make([]string, 0, len(names))
+	for _, name := range names {
+		keys = append(keys, keyForName(name, resourceType))
+	}
+	return keys
+}
 
-	for _, name := range newSlice {
-		UnlockByName(name, resourceType)
+func keysForNameLocks(names []NameLock) (writeKeys []string, readKeys []string) {
+	for _, name := range names {
+		if name.Read {
+			readKeys = append(readKeys, keyForName(name.Name, name.ResourceType))
+		} else {
+			writeKeys = append(writeKeys, keyForName(name.Name, name.ResourceType))
+		}
 	}
+	return writeKeys, readKeys
 }
@@ -1,48 +1,292 @@
 package locks
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"sort"
 	"sync"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tracing"
 )
 
-// mutexKV is a simple key/value store for arbitrary mutexes. It can be used to
+// mutexKV is a simple key/value store for arbitrary read/write mutexes. It can be used to
 // serialize changes across arbitrary collaborators that share knowledge of the
 // keys they must serialize on.
+//
+// Entries are only held whilst a key is locked (or being waited on) and are evicted once
+// they're idle, so that the store doesn't grow for the lifetime of the Provider.
 type mutexKV struct {
 	lock  sync.Mutex
-	store map[string]*sync.Mutex
+	store map[string]*mutexEntry
+}
+
+// mutexEntry is the state of the lock for a single key, which is protected by mutexKV.lock
+type mutexEntry struct {
+	// readers is the number of read locks currently held
+	readers int
+
+	// writer is whether the write lock is currently held
+	writer bool
+
+	// waiting is the number of callers waiting on this key, and waitingWriters the subset of
+	// those waiting for the write lock - new readers wait whilst a writer is waiting so that
+	// writers aren't starved by a steady stream of readers
+	waiting        int
+	waitingWriters int
+
+	// released is closed (and replaced) whenever this key is unlocked, waking any callers waiting on it
+	released chan struct{}
+}
+
+func (e *mutexEntry) idle() bool {
+	return !e.writer && e.readers == 0 && e.waiting == 0
+}
+
+func (e *mutexEntry) canAcquire(write bool) bool {
+	if write {
+		return !e.writer && e.readers == 0
+	}
+
+	return !e.writer && e.waitingWriters == 0
 }
 
 // Locks the mutex for the given key. Caller is responsible for calling Unlock
 // for the same key
 func (m *mutexKV) Lock(key string) {
+	// a context which is never cancelled can't fail
+	_ = m.LockWithContext(context.Background(), key)
+}
+
+// LockWithContext locks the mutex for the given key, returning an error if the context is
+// cancelled (or times out) before the lock is acquired. Caller is responsible for calling
+// Unlock for the same key when this returns nil
+func (m *mutexKV) LockWithContext(ctx context.Context, key string) error {
 	log.Printf("[DEBUG] Locking %q", key)
-	m.get(key).Lock()
+	if err := m.acquire(ctx, key, true); err != nil {
+		return err
+	}
 	log.Printf("[DEBUG] Locked %q", key)
+	return nil
 }
 
 // Unlock the mutex for the given key. Caller must have called Lock for the same key first
 func (m *mutexKV) Unlock(key string) {
 	log.Printf("[DEBUG] Unlocking %q", key)
-	m.get(key).Unlock()
+	m.release(key, true)
 	log.Printf("[DEBUG] Unlocked %q", key)
 }
 
-// Returns a mutex for the given key, no guarantee of its lock status
-func (m *mutexKV) get(key string) *sync.Mutex {
+// RLock locks the mutex for the given key for reading, which can be held by multiple callers
+// at once but not at the same time as the (write) lock. Caller is responsible for calling
+// RUnlock for the same key
+func (m *mutexKV) RLock(key string) {
+	_ = m.RLockWithContext(context.Background(), key)
+}
+
+// RLockWithContext locks the mutex for the given key for reading, returning an error if the
+// context is cancelled (or times out) before the lock is acquired. Caller is responsible for
+// calling RUnlock for the same key when this returns nil
+func (m *mutexKV) RLockWithContext(ctx context.Context, key string) error {
+	log.Printf("[DEBUG] Read Locking %q", key)
+	if err := m.acquire(ctx, key, false); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] Read Locked %q", key)
+	return nil
+}
+
+// RUnlock unlocks the mutex for the given key for reading. Caller must have called RLock for the same key first
+func (m *mutexKV) RUnlock(key string) {
+	log.Printf("[DEBUG] Read Unlocking %q", key)
+	m.release(key, false)
+	log.Printf("[DEBUG] Read Unlocked %q", key)
+}
+
+// LockMultipleWithContext locks the mutexes for each of the given keys in a canonical (sorted) order, such that
+// callers locking overlapping sets of keys can't deadlock. If the context is cancelled (or times out) before all
+// of the locks are acquired, any locks already acquired are released and an error is returned. Caller is
+// responsible for calling UnlockMultiple for the same keys when this returns nil
+func (m *mutexKV) LockMultipleWithContext(ctx context.Context, keys []string) error {
+	return m.LockMultipleReadWriteWithContext(ctx, keys, nil)
+}
+
+// UnlockMultiple unlocks the mutexes for each of the given keys, in the reverse order to which they were locked
+func (m *mutexKV) UnlockMultiple(keys []string) {
+	m.UnlockMultipleReadWrite(keys, nil)
+}
+
+// LockMultipleReadWriteWithContext locks the mutexes for each of the writeKeys, and for reading for each of the
+// readKeys, in a single canonical (sorted) order - a key in both is locked for writing. If the context is cancelled
+// before all of the locks are acquired, any locks already acquired are released and an error is returned. Caller
+// is responsible for calling UnlockMultipleReadWrite for the same keys when this returns nil
+func (m *mutexKV) LockMultipleReadWriteWithContext(ctx context.Context, writeKeys []string, readKeys []string) error {
+	keys := canonicalKeyLocks(writeKeys, readKeys)
+	for i, key := range keys {
+		lock := m.LockWithContext
+		if !key.write {
+			lock = m.RLockWithContext
+		}
+
+		if err := lock(ctx, key.key); err != nil {
+			m.unlockKeys(keys[:i])
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UnlockMultipleReadWrite unlocks the mutexes for each of the writeKeys and readKeys, in the reverse order to which they were locked
+func (m *mutexKV) UnlockMultipleReadWrite(writeKeys []string, readKeys []string) {
+	m.unlockKeys(canonicalKeyLocks(writeKeys, readKeys))
+}
+
+func (m *mutexKV) unlockKeys(keys []keyLock) {
+	for i := len(keys) - 1; i >= 0; i-- {
+		if keys[i].write {
+			m.Unlock(keys[i].key)
+		} else {
+			m.RUnlock(keys[i].key)
+		}
+	}
+}
+
+func (m *mutexKV) acquire(ctx context.Context, key string, write bool) error {
 	m.lock.Lock()
-	defer m.lock.Unlock()
-	mutex, ok := m.store[key]
+	entry, ok := m.store[key]
 	if !ok {
-		mutex = &sync.Mutex{}
-		m.store[key] = mutex
+		entry = &mutexEntry{
+			released: make(chan struct{}),
+		}
+		m.store[key] = entry
+	}
+
+	if entry.canAcquire(write) {
+		entry.take(write)
+		m.lock.Unlock()
+		return nil
+	}
+
+	entry.waiting++
+	if write {
+		entry.waitingWriters++
+	}
+
+	// the time spent waiting for the lock is recorded, since this can account for much of the duration of an operation
+	_, span := tracing.StartSpan(ctx, "Lock.Wait")
+	span.SetAttribute("lock.key", key)
+	span.SetAttribute("lock.write", write)
+
+	for {
+		released := entry.released
+		m.lock.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			m.lock.Lock()
+			entry.waiting--
+			if write {
+				entry.waitingWriters--
+
+				// readers may have been waiting on this writer
+				entry.broadcast()
+			}
+			m.evictIfIdle(key, entry)
+			m.lock.Unlock()
+
+			span.End(ctx.Err())
+			return fmt.Errorf("waiting to lock %q: %+v", key, ctx.Err())
+		}
+
+		m.lock.Lock()
+		if entry.canAcquire(write) {
+			entry.waiting--
+			if write {
+				entry.waitingWriters--
+			}
+			entry.take(write)
+			m.lock.Unlock()
+
+			span.End(nil)
+			return nil
+		}
+	}
+}
+
+func (m *mutexKV) release(key string, write bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	entry, ok := m.store[key]
+	if !ok || (write && !entry.writer) || (!write && entry.readers == 0) {
+		panic(fmt.Sprintf("unlock of unlocked key %q", key))
+	}
+
+	if write {
+		entry.writer = false
+	} else {
+		entry.readers--
+	}
+
+	entry.broadcast()
+	m.evictIfIdle(key, entry)
+}
+
+func (m *mutexKV) evictIfIdle(key string, entry *mutexEntry) {
+	if entry.idle() {
+		delete(m.store, key)
+	}
+}
+
+func (e *mutexEntry) take(write bool) {
+	if write {
+		e.writer = true
+	} else {
+		e.readers++
+	}
+}
+
+func (e *mutexEntry) broadcast() {
+	close(e.released)
+	e.released = make(chan struct{})
+}
+
+// canonicalKeys returns the unique keys in sorted order, which is the order in which multiple keys are locked
+func canonicalKeys(keys []string) []string {
+	output := removeDuplicatesFromStringArray(keys)
+	sort.Strings(output)
+	return output
+}
+
+// keyLock is one of multiple keys being locked, either for reading or writing
+type keyLock struct {
+	key   string
+	write bool
+}
+
+// canonicalKeyLocks returns the unique keys in sorted order, where keys which are both read and written are written
+func canonicalKeyLocks(writeKeys []string, readKeys []string) []keyLock {
+	written := make(map[string]bool, len(writeKeys))
+	for _, key := range writeKeys {
+		written[key] = true
+	}
+
+	keys := canonicalKeys(append(append([]string{}, writeKeys...), readKeys...))
+	output := make([]keyLock, 0, len(keys))
+	for _, key := range keys {
+		output = append(output, keyLock{
+			key:   key,
+			write: written[key],
+		})
 	}
-	return mutex
+	return output
 }
 
 // Returns a properly initialized mutexKV
 func NewMutexKV() *mutexKV {
 	return &mutexKV{
-		store: make(map[string]*sync.Mutex),
+		store: make(map[string]*mutexEntry),
 	}
 }
@@ -0,0 +1,198 @@
+package locks
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMutexKVLockWithContextTimesOut(t *testing.T) {
+	m := NewMutexKV()
+	m.Lock("example")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := m.LockWithContext(ctx, "example"); err == nil {
+		t.Fatalf("expected an error when the context times out before the lock is acquired")
+	}
+
+	m.Unlock("example")
+	if err := m.LockWithContext(context.Background(), "example"); err != nil {
+		t.Fatalf("expected the lock to be acquired once released but got: %+v", err)
+	}
+	m.Unlock("example")
+
+	if len(m.store) != 0 {
+		t.Fatalf("expected no entries once unlocked but got %d", len(m.store))
+	}
+}
+
+func TestMutexKVReadLocksAreShared(t *testing.T) {
+	m := NewMutexKV()
+	m.RLock("example")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := m.RLockWithContext(ctx, "example"); err != nil {
+		t.Fatalf("expected multiple read locks to be held at once but got: %+v", err)
+	}
+
+	writeCtx, writeCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer writeCancel()
+	if err := m.LockWithContext(writeCtx, "example"); err == nil {
+		t.Fatalf("expected the write lock not to be acquired whilst read locks are held")
+	}
+
+	m.RUnlock("example")
+	m.RUnlock("example")
+	if err := m.LockWithContext(ctx, "example"); err != nil {
+		t.Fatalf("expected the write lock to be acquired once the read locks are released but got: %+v", err)
+	}
+	m.Unlock("example")
+
+	if len(m.store) != 0 {
+		t.Fatalf("expected no entries once unlocked but got %d", len(m.store))
+	}
+}
+
+func TestMutexKVWaitingWriterBlocksNewReaders(t *testing.T) {
+	m := NewMutexKV()
+	m.RLock("example")
+
+	locked := make(chan struct{})
+	go func() {
+		m.Lock("example")
+		close(locked)
+	}()
+
+	// wait for the writer to be waiting
+	for {
+		m.lock.Lock()
+		waiting := m.store["example"].waitingWriters
+		m.lock.Unlock()
+		if waiting == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := m.RLockWithContext(ctx, "example"); err == nil {
+		t.Fatalf("expected a new reader to wait for the waiting writer")
+	}
+
+	m.RUnlock("example")
+	<-locked
+	m.Unlock("example")
+}
+
+func TestMutexKVLockMultipleWithContextOverlapping(t *testing.T) {
+	m := NewMutexKV()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// callers locking overlapping sets of keys in different orders would deadlock without a canonical order
+	inputs := [][]string{
+		{"a", "b", "c"},
+		{"c", "b", "a"},
+		{"b", "c", "a", "b"},
+		{"c", "a"},
+	}
+
+	wg := sync.WaitGroup{}
+	errors := make(chan error, len(inputs)*50)
+	for i := 0; i < 50; i++ {
+		for _, keys := range inputs {
+			wg.Add(1)
+			go func(keys []string) {
+				defer wg.Done()
+				if err := m.LockMultipleWithContext(ctx, keys); err != nil {
+					errors <- err
+					return
+				}
+				m.UnlockMultiple(keys)
+			}(keys)
+		}
+	}
+	wg.Wait()
+	close(errors)
+
+	for err := range errors {
+		t.Fatalf("expected all of the keys to be locked but got: %+v", err)
+	}
+	if len(m.store) != 0 {
+		t.Fatalf("expected no entries once unlocked but got %d", len(m.store))
+	}
+}
+
+func TestMutexKVLockMultipleWithContextReleasesOnTimeout(t *testing.T) {
+	m := NewMutexKV()
+	m.Lock("b")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := m.LockMultipleWithContext(ctx, []string{"c", "b", "a"}); err == nil {
+		t.Fatalf("expected an error when the context times out before all of the locks are acquired")
+	}
+
+	// "a" should have been released
+	if err := m.LockWithContext(context.Background(), "a"); err != nil {
+		t.Fatalf("expected %q to have been released but got: %+v", "a", err)
+	}
+	m.Unlock("a")
+	m.Unlock("b")
+
+	if len(m.store) != 0 {
+		t.Fatalf("expected no entries once unlocked but got %d", len(m.store))
+	}
+}
+
+func TestCanonicalKeys(t *testing.T) {
+	actual := canonicalKeys([]string{"c", "a", "b", "a"})
+	expected := []string{"a", "b", "c"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestMutexKVLockMultipleReadWriteWithContextSharesReadKeys(t *testing.T) {
+	m := NewMutexKV()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// two callers writing different keys whilst reading the same (parent) key can proceed in parallel
+	if err := m.LockMultipleReadWriteWithContext(ctx, []string{"child1"}, []string{"parent"}); err != nil {
+		t.Fatalf("locking the first child: %+v", err)
+	}
+	if err := m.LockMultipleReadWriteWithContext(ctx, []string{"child2"}, []string{"parent"}); err != nil {
+		t.Fatalf("expected the parent to be shared between readers but got: %+v", err)
+	}
+
+	// but exclude writers of the parent
+	if err := m.LockWithContext(ctx, "parent"); err == nil {
+		t.Fatalf("expected an error when writing a key which is locked for reading")
+	}
+
+	m.UnlockMultipleReadWrite([]string{"child1"}, []string{"parent"})
+	m.UnlockMultipleReadWrite([]string{"child2"}, []string{"parent"})
+
+	if len(m.store) != 0 {
+		t.Fatalf("expected no entries once unlocked but got %d", len(m.store))
+	}
+}
+
+func TestCanonicalKeyLocks(t *testing.T) {
+	actual := canonicalKeyLocks([]string{"c", "a"}, []string{"b", "a", "d"})
+	expected := []keyLock{
+		{key: "a", write: true},
+		{key: "b", write: false},
+		{key: "c", write: true},
+		{key: "d", write: false},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+}
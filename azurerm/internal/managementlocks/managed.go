@@ -0,0 +1,47 @@
+package managementlocks
+
+import (
+	"strings"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+)
+
+// Only the Management Locks which are managed by Terraform can be lifted, since these are recreated by Terraform
+// should restoring them fail. The Provider has no visibility of the rest of the configuration (and is restarted
+// between the refresh, plan and apply) - as such when the `temporarily_lift_managed_locks` feature is enabled the
+// `azurerm_management_lock` resource registers each Lock it manages within the Provider Cache.
+
+// managedTTL is the length of time a Lock remains registered as managed, which is renewed each time it's refreshed
+const managedTTL = 30 * 24 * time.Hour
+
+// RegisterManaged records that the Management Lock `lockId` is managed by Terraform, when the
+// `temporarily_lift_managed_locks` feature is enabled
+func RegisterManaged(client *clients.Client, lockId string) {
+	if !client.Features.ManagementLock.TemporarilyLiftManagedLocks {
+		return
+	}
+
+	client.Resource.ProviderCache.Set(managedCacheKey(lockId), true)
+}
+
+// UnregisterManaged records that the Management Lock `lockId` is no longer managed by Terraform
+func UnregisterManaged(client *clients.Client, lockId string) {
+	forgetIndexedLock(lockId)
+
+	if !client.Features.ManagementLock.TemporarilyLiftManagedLocks {
+		return
+	}
+
+	client.Resource.ProviderCache.Delete(managedCacheKey(lockId))
+}
+
+func isManaged(client *clients.Client, lockId string) bool {
+	var managed bool
+	return client.Resource.ProviderCache.Get(managedCacheKey(lockId), managedTTL, &managed) && managed
+}
+
+func managedCacheKey(lockId string) string {
+	// Resource ID's are case-insensitive
+	return "managed-management-locks/" + strings.ToLower(lockId)
+}
@@ -0,0 +1,473 @@
+package managementlocks
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2016-09-01/locks"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/providercache"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// Management Locks apply to the Scope they're defined on and everything beneath it, as such a Resource can
+// be locked by a Lock on the Resource itself, any Parent Resource, the Resource Group or the Subscription.
+//
+// Azure only reports these once the Delete/Update is sent (and for some Resources only once nested/dependent
+// Resources have already been removed) - so these are checked up-front to fail fast with a clear error.
+
+// Operation is the operation being performed against a Resource which a Management Lock may prevent
+type Operation string
+
+const (
+	OperationDelete Operation = "deleted"
+	OperationUpdate Operation = "updated"
+)
+
+const locksSegment = "/providers/Microsoft.Authorization/locks/"
+
+// RestoreFunc restores any Management Locks which were lifted, once the operation has completed - `succeeded` is
+// whether the operation succeeded, since Locks on a Resource which has been deleted are deleted alongside it
+type RestoreFunc func(ctx context.Context, succeeded bool) error
+
+type blockingLock struct {
+	id    string
+	scope string
+	lock  locks.ManagementLockObject
+}
+
+// CheckForLocks checks whether any Management Lock prevents the Resource `resourceId` from being deleted or updated,
+// returning an error naming the Lock(s) if so.
+//
+// When the `temporarily_lift_managed_locks` feature is enabled any Locks managed by Terraform
+// are instead removed - in which case the returned function must be called once the operation has completed (regardless
+// of whether it succeeded) to restore them. The returned function is always non-nil.
+func CheckForLocks(ctx context.Context, client *clients.Client, resourceId string, operation Operation) (RestoreFunc, error) {
+	noop := func(_ context.Context, _ bool) error { return nil }
+
+	scopes := scopesForResource(resourceId)
+	if len(scopes) == 0 {
+		return noop, nil
+	}
+
+	blocking, err := findBlockingLocks(ctx, client.Resource.LocksClient, scopes, operation)
+	if err != nil {
+		// the principal may not have permission to read Management Locks, so let Azure be the judge
+		log.Printf("[WARN] Unable to check for Management Locks on %q - skipping: %+v", resourceId, err)
+		return noop, nil
+	}
+
+	// Locks lifted by another operation which is still in progress don't show up in Azure, but still need to
+	// be restored only once this operation has also completed
+	blocking = append(blocking, currentlyLiftedLocks(scopes, operation, blocking)...)
+	if len(blocking) == 0 {
+		return noop, nil
+	}
+
+	if !client.Features.ManagementLock.TemporarilyLiftManagedLocks {
+		return noop, lockedError(resourceId, operation, blocking, false)
+	}
+
+	unmanaged := make([]blockingLock, 0)
+	for _, v := range blocking {
+		if !isManaged(client, v.id) {
+			unmanaged = append(unmanaged, v)
+		}
+	}
+	if len(unmanaged) > 0 {
+		return noop, lockedError(resourceId, operation, unmanaged, true)
+	}
+
+	lifted := make([]blockingLock, 0)
+	restore := func(ctx context.Context, succeeded bool) error {
+		errors := make([]string, 0)
+		for _, v := range lifted {
+			// Locks on (or beneath) a Resource which has been deleted are deleted alongside it
+			if operation == OperationDelete && succeeded && isWithinScope(v.scope, resourceId) {
+				forgetLock(client, v)
+				continue
+			}
+
+			if err := restoreLock(ctx, client.Resource.LocksClient, v); err != nil {
+				errors = append(errors, err.Error())
+			}
+		}
+		if len(errors) > 0 {
+			return fmt.Errorf("restoring the Management Locks lifted whilst %q was %s:\n\n%s", resourceId, operation, strings.Join(errors, "\n"))
+		}
+		return nil
+	}
+
+	for _, v := range blocking {
+		if err := liftLock(ctx, client.Resource.LocksClient, v); err != nil {
+			if restoreErr := restore(ctx, false); restoreErr != nil {
+				log.Printf("[WARN] %+v", restoreErr)
+			}
+			return noop, err
+		}
+		lifted = append(lifted, v)
+	}
+
+	return restore, nil
+}
+
+func findBlockingLocks(ctx context.Context, client *locks.ManagementLocksClient, scopes []string, operation Operation) ([]blockingLock, error) {
+	existing, err := indexForSubscription(scopes[0]).list(ctx, client, scopes[0])
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]blockingLock, 0)
+	for _, v := range existing {
+		if !v.appliesTo(scopes, operation) {
+			continue
+		}
+
+		// the Locks within the Subscription are only listed once, so confirm this Lock still exists
+		exists, err := lockStillExists(ctx, client, v)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			log.Printf("[DEBUG] The Management Lock %q has been deleted since the Locks were listed - ignoring", v.id)
+			forgetIndexedLock(v.id)
+			continue
+		}
+
+		output = append(output, v)
+	}
+	sort.Slice(output, func(i, j int) bool {
+		return strings.ToLower(output[i].id) < strings.ToLower(output[j].id)
+	})
+	return output, nil
+}
+
+// The Management Locks within each Subscription are listed once, the first time a Resource within it is updated or
+// deleted, and then retained for the lifetime of the Provider - rather than listing the Locks at each Scope for every
+// Resource being updated or deleted. Locks created after this are instead reported by Azure once the request is sent,
+// whilst Locks which would prevent an operation are confirmed to still exist, since these may have been deleted since.
+var (
+	indexes     = map[string]*subscriptionIndex{}
+	indexesLock = sync.Mutex{}
+)
+
+type subscriptionIndex struct {
+	lock   sync.Mutex
+	loaded bool
+	err    error
+	locks  map[string]blockingLock
+}
+
+func indexForSubscription(subscriptionScope string) *subscriptionIndex {
+	indexesLock.Lock()
+	defer indexesLock.Unlock()
+
+	key := strings.ToLower(subscriptionScope)
+	index, ok := indexes[key]
+	if !ok {
+		index = &subscriptionIndex{}
+		indexes[key] = index
+	}
+	return index
+}
+
+// list returns the Management Locks within the Subscription, which are listed the first time this is called
+func (i *subscriptionIndex) list(ctx context.Context, client *locks.ManagementLocksClient, subscriptionScope string) ([]blockingLock, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if !i.loaded {
+		locks, err := listLocksWithinScope(ctx, client, subscriptionScope)
+		// an operation which times out (or is cancelled) whilst listing shouldn't prevent the next from trying again
+		if err != nil && ctx.Err() != nil {
+			return nil, err
+		}
+
+		i.locks = locks
+		i.err = err
+		i.loaded = true
+	}
+
+	if i.err != nil {
+		return nil, i.err
+	}
+
+	output := make([]blockingLock, 0, len(i.locks))
+	for _, v := range i.locks {
+		output = append(output, v)
+	}
+	return output, nil
+}
+
+// forgetIndexedLock removes the Management Lock `lockId` from the Locks listed for its Subscription, once it's been deleted
+func forgetIndexedLock(lockId string) {
+	indexesLock.Lock()
+	defer indexesLock.Unlock()
+
+	for _, index := range indexes {
+		index.lock.Lock()
+		delete(index.locks, strings.ToLower(lockId))
+		index.lock.Unlock()
+	}
+}
+
+// lockStillExists returns whether the listed Management Lock still exists in Azure - Locks which are currently
+// lifted are deleted in Azure, but are restored once the operations which lifted them complete, so are retained
+func lockStillExists(ctx context.Context, client *locks.ManagementLocksClient, v blockingLock) (bool, error) {
+	if isLifted(v.id) {
+		return true, nil
+	}
+
+	resp, err := client.GetByScope(ctx, v.scope, *v.lock.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return false, nil
+		}
+		return false, fmt.Errorf("retrieving the Management Lock %q: %+v", v.id, err)
+	}
+
+	return true, nil
+}
+
+// listLocksWithinScope lists the Management Locks defined on `scope` and each Scope beneath it
+func listLocksWithinScope(ctx context.Context, client *locks.ManagementLocksClient, scope string) (map[string]blockingLock, error) {
+	output := make(map[string]blockingLock)
+
+	// when no filter is specified the Locks at (and above) the Scope and those beneath it are returned
+	iterator, err := client.ListByScopeComplete(ctx, scope, "")
+	if err != nil {
+		return nil, fmt.Errorf("listing the Management Locks for %q: %+v", scope, err)
+	}
+	for iterator.NotDone() {
+		lock := iterator.Value()
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("listing the Management Locks for %q: %+v", scope, err)
+		}
+
+		if lock.ID == nil || lock.Name == nil || lock.ManagementLockProperties == nil {
+			continue
+		}
+		output[strings.ToLower(*lock.ID)] = blockingLock{
+			id:    *lock.ID,
+			scope: scopeForLock(*lock.ID),
+			lock:  lock,
+		}
+	}
+
+	return output, nil
+}
+
+// appliesTo returns whether this Lock prevents the operation on the Resource with the specified Scopes
+func (l blockingLock) appliesTo(scopes []string, operation Operation) bool {
+	resourceId := scopes[len(scopes)-1]
+	inScope := operation == OperationDelete && isWithinScope(l.scope, resourceId)
+	for _, scope := range scopes {
+		if strings.EqualFold(l.scope, scope) {
+			inScope = true
+		}
+	}
+	if !inScope {
+		return false
+	}
+
+	// `CanNotDelete` Locks allow the Resource to be updated
+	level := l.lock.ManagementLockProperties.Level
+	return level == locks.ReadOnly || (level == locks.CanNotDelete && operation == OperationDelete)
+}
+
+func lockedError(resourceId string, operation Operation, blocking []blockingLock, liftingEnabled bool) error {
+	lines := make([]string, 0)
+	for _, v := range blocking {
+		lines = append(lines, fmt.Sprintf("* %q (Level %q) on the Scope %q", *v.lock.Name, string(v.lock.ManagementLockProperties.Level), v.scope))
+	}
+
+	message := fmt.Sprintf("%q can't be %s since it's locked by the following Management Locks:\n\n%s\n\n", resourceId, operation, strings.Join(lines, "\n"))
+	if liftingEnabled {
+		return fmt.Errorf(`%sThese Management Locks aren't managed by Terraform and so can't be lifted automatically - they need to be removed before the Resource can be %s.
+Management Locks are only registered as managed by Terraform whilst the Provider Cache is enabled (by setting the %q Environment Variable)`, message, operation, providercache.EnvironmentVariable)
+	}
+
+	return fmt.Errorf(`%sThese Management Locks need to be removed before the Resource can be %s. Alternatively, Management Locks managed by
+Terraform can be temporarily lifted by setting the field 'temporarily_lift_managed_locks' within the 'management_lock' block
+in the 'features' block of the Provider to 'true' and enabling the Provider Cache (by setting the %q Environment Variable)`, message, operation, providercache.EnvironmentVariable)
+}
+
+// Locks are lifted once for all the Resources within the Scope of the Lock (for example when deleting several Resources
+// within a locked Resource Group in parallel) and restored once the last of these operations has completed
+var (
+	liftedLocks     = map[string]*liftedLock{}
+	liftedLocksLock = sync.Mutex{}
+)
+
+type liftedLock struct {
+	count int
+	lock  blockingLock
+}
+
+// currentlyLiftedLocks returns the Locks which are currently lifted that would otherwise prevent the operation, excluding
+// those within `existing`
+func currentlyLiftedLocks(scopes []string, operation Operation, existing []blockingLock) []blockingLock {
+	liftedLocksLock.Lock()
+	defer liftedLocksLock.Unlock()
+
+	output := make([]blockingLock, 0)
+	for key, v := range liftedLocks {
+		found := false
+		for _, e := range existing {
+			if strings.EqualFold(e.id, key) {
+				found = true
+				break
+			}
+		}
+		if found || !v.lock.appliesTo(scopes, operation) {
+			continue
+		}
+
+		output = append(output, v.lock)
+	}
+
+	sort.Slice(output, func(i, j int) bool {
+		return strings.ToLower(output[i].id) < strings.ToLower(output[j].id)
+	})
+	return output
+}
+
+func isLifted(lockId string) bool {
+	liftedLocksLock.Lock()
+	defer liftedLocksLock.Unlock()
+
+	_, ok := liftedLocks[strings.ToLower(lockId)]
+	return ok
+}
+
+func liftLock(ctx context.Context, client *locks.ManagementLocksClient, v blockingLock) error {
+	liftedLocksLock.Lock()
+	defer liftedLocksLock.Unlock()
+
+	key := strings.ToLower(v.id)
+	if existing, ok := liftedLocks[key]; ok {
+		existing.count++
+		return nil
+	}
+
+	log.Printf("[DEBUG] Temporarily lifting the Management Lock %q..", v.id)
+	if _, err := client.DeleteByScope(ctx, v.scope, *v.lock.Name); err != nil {
+		return fmt.Errorf("temporarily lifting the Management Lock %q: %+v", v.id, err)
+	}
+
+	liftedLocks[key] = &liftedLock{
+		count: 1,
+		lock:  v,
+	}
+	return nil
+}
+
+func restoreLock(ctx context.Context, client *locks.ManagementLocksClient, v blockingLock) error {
+	liftedLocksLock.Lock()
+	defer liftedLocksLock.Unlock()
+
+	key := strings.ToLower(v.id)
+	existing, ok := liftedLocks[key]
+	if !ok {
+		return nil
+	}
+	existing.count--
+	if existing.count > 0 {
+		return nil
+	}
+
+	// should this fail the Lock will be recreated by Terraform during the next apply, since it's managed by Terraform
+	delete(liftedLocks, key)
+
+	log.Printf("[DEBUG] Restoring the Management Lock %q..", v.id)
+	lock := locks.ManagementLockObject{
+		ManagementLockProperties: &locks.ManagementLockProperties{
+			Level:  v.lock.ManagementLockProperties.Level,
+			Notes:  v.lock.ManagementLockProperties.Notes,
+			Owners: v.lock.ManagementLockProperties.Owners,
+		},
+	}
+	if _, err := client.CreateOrUpdateByScope(ctx, v.scope, *v.lock.Name, lock); err != nil {
+		return fmt.Errorf("restoring the Management Lock %q: %+v", v.id, err)
+	}
+
+	return nil
+}
+
+// forgetLock stops tracking a lifted Lock which no longer needs to be restored, since the Resource it was defined on
+// has been deleted
+func forgetLock(client *clients.Client, v blockingLock) {
+	liftedLocksLock.Lock()
+	defer liftedLocksLock.Unlock()
+
+	key := strings.ToLower(v.id)
+	existing, ok := liftedLocks[key]
+	if !ok {
+		return
+	}
+	existing.count--
+	if existing.count > 0 {
+		return
+	}
+
+	delete(liftedLocks, key)
+	UnregisterManaged(client, v.id)
+}
+
+// scopesForResource returns each Scope which a Management Lock affecting the Resource `resourceId` can be defined
+// on, starting with the Subscription and ending with the Resource itself - or nothing if this isn't a Resource ID
+func scopesForResource(resourceId string) []string {
+	segments := strings.Split(strings.TrimPrefix(resourceId, "/"), "/")
+	if len(segments) < 2 || !strings.EqualFold(segments[0], "subscriptions") || segments[1] == "" {
+		return nil
+	}
+
+	scope := func(i int) string {
+		return "/" + strings.Join(segments[0:i], "/")
+	}
+
+	scopes := []string{scope(2)}
+	i := 2
+	if len(segments) >= 4 && strings.EqualFold(segments[2], "resourceGroups") {
+		i = 4
+		scopes = append(scopes, scope(i))
+	}
+
+	for i < len(segments) {
+		// a Resource (or an Extension Resource) within a Resource Provider, or a Nested Resource within its Parent
+		length := 2
+		if strings.EqualFold(segments[i], "providers") {
+			length = 4
+		}
+		if i+length > len(segments) {
+			break
+		}
+
+		i += length
+		scopes = append(scopes, scope(i))
+	}
+
+	if !strings.EqualFold(scopes[len(scopes)-1], resourceId) {
+		return nil
+	}
+
+	return scopes
+}
+
+// scopeForLock returns the Scope on which the Management Lock `lockId` is defined
+func scopeForLock(lockId string) string {
+	index := strings.LastIndex(strings.ToLower(lockId), strings.ToLower(locksSegment))
+	if index == -1 {
+		return lockId
+	}
+
+	return lockId[0:index]
+}
+
+func isWithinScope(id string, scope string) bool {
+	return strings.EqualFold(id, scope) || strings.HasPrefix(strings.ToLower(id), strings.ToLower(scope)+"/")
+}
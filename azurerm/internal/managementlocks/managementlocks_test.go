@@ -0,0 +1,330 @@
+package managementlocks
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/fakearm"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/providercache"
+)
+
+func TestScopesForResource(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected []string
+	}{
+		{
+			Input:    "",
+			Expected: nil,
+		},
+		{
+			Input:    "https://example.blob.core.windows.net/container",
+			Expected: nil,
+		},
+		{
+			Input: "/subscriptions/11111111-1111-1111-1111-111111111111",
+			Expected: []string{
+				"/subscriptions/11111111-1111-1111-1111-111111111111",
+			},
+		},
+		{
+			Input: "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/group1",
+			Expected: []string{
+				"/subscriptions/11111111-1111-1111-1111-111111111111",
+				"/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/group1",
+			},
+		},
+		{
+			Input: "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1",
+			Expected: []string{
+				"/subscriptions/11111111-1111-1111-1111-111111111111",
+				"/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/group1",
+				"/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1",
+				"/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1",
+			},
+		},
+		{
+			// an Extension Resource
+			Input: "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1/providers/Microsoft.Insights/diagnosticSettings/setting1",
+			Expected: []string{
+				"/subscriptions/11111111-1111-1111-1111-111111111111",
+				"/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/group1",
+				"/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1",
+				"/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1/providers/Microsoft.Insights/diagnosticSettings/setting1",
+			},
+		},
+		{
+			// a Resource within the Subscription
+			Input: "/subscriptions/11111111-1111-1111-1111-111111111111/providers/Microsoft.Security/pricings/VirtualMachines",
+			Expected: []string{
+				"/subscriptions/11111111-1111-1111-1111-111111111111",
+				"/subscriptions/11111111-1111-1111-1111-111111111111/providers/Microsoft.Security/pricings/VirtualMachines",
+			},
+		},
+		{
+			// an incomplete Resource ID
+			Input:    "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks",
+			Expected: nil,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual := scopesForResource(v.Input)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+type testData struct {
+	server     *fakearm.Server
+	client     *clients.Client
+	groupId    string
+	resourceId string
+}
+
+func setup(t *testing.T, liftManagedLocks bool) testData {
+	indexesLock.Lock()
+	indexes = map[string]*subscriptionIndex{}
+	indexesLock.Unlock()
+
+	server := fakearm.NewServer()
+	groupId := fmt.Sprintf("/subscriptions/%s/resourceGroups/group1", fakearm.SubscriptionId)
+	server.SetResource(groupId, map[string]interface{}{"location": "westeurope"})
+	resourceId := fmt.Sprintf("%s/providers/Microsoft.Network/virtualNetworks/network1", groupId)
+	server.SetResource(resourceId, map[string]interface{}{"location": "westeurope"})
+
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+	client.Features.ManagementLock.TemporarilyLiftManagedLocks = liftManagedLocks
+	client.Resource.ProviderCache = providercache.New(t.TempDir(), "public", fakearm.SubscriptionId)
+
+	return testData{
+		server:     server,
+		client:     client,
+		groupId:    groupId,
+		resourceId: resourceId,
+	}
+}
+
+func (td testData) lock(scope, name, level string) string {
+	id := fmt.Sprintf("%s/providers/Microsoft.Authorization/locks/%s", scope, name)
+	td.server.SetResource(id, map[string]interface{}{
+		"properties": map[string]interface{}{
+			"level": level,
+			"notes": "locked",
+		},
+	})
+	return id
+}
+
+func TestCheckForLocksNoLocks(t *testing.T) {
+	td := setup(t, false)
+
+	restore, err := CheckForLocks(td.client.StopContext, td.client, td.resourceId, OperationDelete)
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if err := restore(td.client.StopContext, true); err != nil {
+		t.Fatalf("restoring: %+v", err)
+	}
+}
+
+func TestCheckForLocksLocked(t *testing.T) {
+	testData := []struct {
+		Name        string
+		Level       string
+		Operation   Operation
+		ExpectError bool
+	}{
+		{
+			Name:        "CanNotDelete on Delete",
+			Level:       "CanNotDelete",
+			Operation:   OperationDelete,
+			ExpectError: true,
+		},
+		{
+			Name:        "CanNotDelete on Update",
+			Level:       "CanNotDelete",
+			Operation:   OperationUpdate,
+			ExpectError: false,
+		},
+		{
+			Name:        "ReadOnly on Delete",
+			Level:       "ReadOnly",
+			Operation:   OperationDelete,
+			ExpectError: true,
+		},
+		{
+			Name:        "ReadOnly on Update",
+			Level:       "ReadOnly",
+			Operation:   OperationUpdate,
+			ExpectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		td := setup(t, false)
+		td.lock(td.groupId, "lock1", v.Level)
+
+		_, err := CheckForLocks(td.client.StopContext, td.client, td.resourceId, v.Operation)
+		if !v.ExpectError {
+			if err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			continue
+		}
+
+		if err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !strings.Contains(err.Error(), `"lock1"`) || !strings.Contains(err.Error(), "temporarily_lift_managed_locks") {
+			t.Fatalf("expected the error to name the lock and the feature but got: %+v", err)
+		}
+	}
+}
+
+func TestCheckForLocksLiftsManagedLocks(t *testing.T) {
+	td := setup(t, true)
+	lockId := td.lock(td.groupId, "lock1", "CanNotDelete")
+	RegisterManaged(td.client, lockId)
+
+	restore, err := CheckForLocks(td.client.StopContext, td.client, td.resourceId, OperationDelete)
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if _, exists := td.server.Resource(lockId); exists {
+		t.Fatalf("expected the lock %q to have been lifted", lockId)
+	}
+
+	// a second operation whilst the lock is lifted should also restore it
+	restoreSecond, err := CheckForLocks(td.client.StopContext, td.client, td.resourceId, OperationDelete)
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	if err := restore(td.client.StopContext, true); err != nil {
+		t.Fatalf("restoring: %+v", err)
+	}
+	if _, exists := td.server.Resource(lockId); exists {
+		t.Fatalf("expected the lock %q not to be restored until the second operation completes", lockId)
+	}
+
+	if err := restoreSecond(td.client.StopContext, true); err != nil {
+		t.Fatalf("restoring: %+v", err)
+	}
+	restored, exists := td.server.Resource(lockId)
+	if !exists {
+		t.Fatalf("expected the lock %q to have been restored", lockId)
+	}
+	properties := restored["properties"].(map[string]interface{})
+	if properties["level"] != "CanNotDelete" || properties["notes"] != "locked" {
+		t.Fatalf("expected the lock to be restored with the same properties but got %+v", properties)
+	}
+}
+
+func TestCheckForLocksDoesNotRestoreLocksOnDeletedResource(t *testing.T) {
+	td := setup(t, true)
+	lockId := td.lock(td.resourceId, "lock1", "CanNotDelete")
+	RegisterManaged(td.client, lockId)
+
+	restore, err := CheckForLocks(td.client.StopContext, td.client, td.resourceId, OperationDelete)
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if err := restore(td.client.StopContext, true); err != nil {
+		t.Fatalf("restoring: %+v", err)
+	}
+	if _, exists := td.server.Resource(lockId); exists {
+		t.Fatalf("expected the lock %q on the deleted resource not to be restored", lockId)
+	}
+	if isManaged(td.client, lockId) {
+		t.Fatalf("expected the lock %q to no longer be registered", lockId)
+	}
+}
+
+func TestCheckForLocksUnmanagedLocks(t *testing.T) {
+	td := setup(t, true)
+	managedId := td.lock(td.groupId, "managed", "CanNotDelete")
+	RegisterManaged(td.client, managedId)
+	unmanagedId := td.lock(td.groupId, "unmanaged", "ReadOnly")
+
+	_, err := CheckForLocks(td.client.StopContext, td.client, td.resourceId, OperationDelete)
+	if err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+	if !strings.Contains(err.Error(), `"unmanaged"`) || strings.Contains(err.Error(), `"managed"`) {
+		t.Fatalf("expected the error to name only the unmanaged lock but got: %+v", err)
+	}
+
+	for _, id := range []string{managedId, unmanagedId} {
+		if _, exists := td.server.Resource(id); !exists {
+			t.Fatalf("expected the lock %q not to have been lifted", id)
+		}
+	}
+}
+
+func TestCheckForLocksListsLocksOncePerSubscription(t *testing.T) {
+	td := setup(t, false)
+
+	if _, err := CheckForLocks(td.client.StopContext, td.client, td.resourceId, OperationDelete); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	// Locks created after the Locks within the Subscription have been listed are reported by Azure instead
+	td.lock(td.groupId, "lock1", "CanNotDelete")
+	if _, err := CheckForLocks(td.client.StopContext, td.client, td.resourceId, OperationDelete); err != nil {
+		t.Fatalf("expected the Locks within the Subscription not to be listed again but got: %+v", err)
+	}
+}
+
+func TestCheckForLocksLockDeletedSinceListing(t *testing.T) {
+	td := setup(t, false)
+	td.lock(td.groupId, "lock1", "CanNotDelete")
+
+	if _, err := CheckForLocks(td.client.StopContext, td.client, td.resourceId, OperationDelete); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+
+	// a Lock deleted outside of Terraform after the Locks within the Subscription have been listed no longer prevents the deletion
+	if _, err := td.client.Resource.LocksClient.DeleteByScope(td.client.StopContext, td.groupId, "lock1"); err != nil {
+		t.Fatalf("deleting the lock: %+v", err)
+	}
+	if _, err := CheckForLocks(td.client.StopContext, td.client, td.resourceId, OperationDelete); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+}
+
+func TestCheckForLocksUnregisteredLock(t *testing.T) {
+	td := setup(t, false)
+	lockId := td.lock(td.groupId, "lock1", "CanNotDelete")
+
+	if _, err := CheckForLocks(td.client.StopContext, td.client, td.resourceId, OperationDelete); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+
+	// a Lock deleted by the `azurerm_management_lock` resource no longer prevents the deletion
+	UnregisterManaged(td.client, lockId)
+	if _, err := CheckForLocks(td.client.StopContext, td.client, td.resourceId, OperationDelete); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+}
+
+func TestRegisterManagedRequiresFeature(t *testing.T) {
+	td := setup(t, false)
+	lockId := td.lock(td.groupId, "lock1", "CanNotDelete")
+
+	RegisterManaged(td.client, lockId)
+	if isManaged(td.client, lockId) {
+		t.Fatalf("expected the lock %q not to be registered when the feature is disabled", lockId)
+	}
+}
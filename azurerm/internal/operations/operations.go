@@ -0,0 +1,135 @@
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// Long Running Operations which are still in progress when a Create times out (or Terraform is interrupted) are
+// persisted into the (partial) State alongside the Resource ID, so that the next apply can resume polling the same
+// operation - rather than the Resource being missing from the State and needing to be imported.
+//
+// Since Terraform marks any Resource which fails to be created as tainted (meaning it'd be destroyed and recreated
+// during the next apply), the creation instead succeeds with the pending operation recorded - which is resumed by the
+// next Update, which CustomizeDiff ensures is planned.
+
+// PendingCreationKey is the name of the attribute containing the pending Long Running Operation creating the Resource
+const PendingCreationKey = "pending_creation_operation"
+
+// maxAge is the length of time after which a persisted operation is no longer resumed, since the polling
+// URL's returned from Azure Resource Manager are only valid for a limited period of time
+const maxAge = 24 * time.Hour
+
+type pendingOperation struct {
+	StartedAt time.Time       `json:"startedAt"`
+	Future    json.RawMessage `json:"future"`
+}
+
+// Schema returns the schema for the attribute containing the pending Long Running Operation creating the Resource
+func Schema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+}
+
+// WaitForCreation waits for the Long Running Operation creating the Resource `resourceId` to complete - should the
+// context be cancelled or time out first, the Resource ID and the operation are persisted into the State so that
+// this can be resumed by ResumeCreation during the next apply, in which case this returns that the creation is pending
+// (rather than an error) and the Resource should be returned as-is, without being read.
+func WaitForCreation(ctx context.Context, client autorest.Client, d *schema.ResourceData, resourceId string, future azure.FutureAPI) (bool, error) {
+	startedAt := time.Now()
+	err := future.WaitForCompletionRef(ctx, client)
+	if err == nil || ctx.Err() == nil {
+		return false, err
+	}
+
+	if persistErr := persist(d, startedAt, future); persistErr != nil {
+		log.Printf("[WARN] Unable to persist the Long Running Operation creating %q: %+v", resourceId, persistErr)
+		return false, err
+	}
+	d.SetId(resourceId)
+
+	log.Printf("[WARN] The Long Running Operation creating %q is still in progress (%+v) and has been saved into the State - this will be resumed during the next apply", resourceId, err)
+	return true, nil
+}
+
+// ResumeCreation resumes waiting for the Long Running Operation persisted into the State by WaitForCreation (if any),
+// which should be called prior to updating the Resource. This returns whether a pending creation was resumed, in
+// which case any configuration applied after the Resource was created hasn't yet been applied.
+func ResumeCreation(ctx context.Context, client autorest.Client, d *schema.ResourceData) (bool, error) {
+	raw := d.Get(PendingCreationKey).(string)
+	if raw == "" {
+		return false, nil
+	}
+
+	var operation pendingOperation
+	if err := json.Unmarshal([]byte(raw), &operation); err != nil {
+		log.Printf("[WARN] Unable to parse the Long Running Operation creating %q: %+v", d.Id(), err)
+		return true, d.Set(PendingCreationKey, "")
+	}
+
+	if time.Since(operation.StartedAt) > maxAge {
+		log.Printf("[DEBUG] Ignoring the Long Running Operation creating %q since it was started at %s", d.Id(), operation.StartedAt.Format(time.RFC3339))
+		return true, d.Set(PendingCreationKey, "")
+	}
+
+	future := &azure.Future{}
+	if err := future.UnmarshalJSON(operation.Future); err != nil {
+		log.Printf("[WARN] Unable to parse the Long Running Operation creating %q: %+v", d.Id(), err)
+		return true, d.Set(PendingCreationKey, "")
+	}
+
+	log.Printf("[DEBUG] Resuming the Long Running Operation creating %q (started at %s)..", d.Id(), operation.StartedAt.Format(time.RFC3339))
+	if err := future.WaitForCompletionRef(ctx, client); err != nil {
+		if ctx.Err() != nil {
+			if persistErr := persist(d, operation.StartedAt, future); persistErr != nil {
+				log.Printf("[WARN] Unable to persist the Long Running Operation creating %q: %+v", d.Id(), persistErr)
+			}
+			return true, fmt.Errorf("%+v\n\nThe Long Running Operation creating %q is still in progress and will be resumed during the next apply", err, d.Id())
+		}
+
+		// the operation either failed or can no longer be polled, in which case it's not resumed again
+		if setErr := d.Set(PendingCreationKey, ""); setErr != nil {
+			return true, setErr
+		}
+		return true, fmt.Errorf("waiting for the Long Running Operation creating %q: %+v", d.Id(), err)
+	}
+
+	log.Printf("[DEBUG] The Long Running Operation creating %q has completed", d.Id())
+	return true, d.Set(PendingCreationKey, "")
+}
+
+// CustomizeDiff plans an Update whilst a Long Running Operation creating the Resource is pending, such that
+// this is resumed by ResumeCreation during the next apply
+func CustomizeDiff(d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || d.Get(PendingCreationKey).(string) == "" {
+		return nil
+	}
+
+	return d.SetNewComputed(PendingCreationKey)
+}
+
+func persist(d *schema.ResourceData, startedAt time.Time, future azure.FutureAPI) error {
+	raw, err := future.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("serializing the Future: %+v", err)
+	}
+
+	contents, err := json.Marshal(pendingOperation{
+		StartedAt: startedAt,
+		Future:    raw,
+	})
+	if err != nil {
+		return err
+	}
+
+	return d.Set(PendingCreationKey, string(contents))
+}
@@ -0,0 +1,215 @@
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const testResourceId = "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.ApiManagement/service/service1"
+
+type testServer struct {
+	*httptest.Server
+
+	lock   sync.Mutex
+	status string
+}
+
+func newTestServer() *testServer {
+	s := &testServer{
+		status: "InProgress",
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "1")
+		switch r.URL.Path {
+		case "/operation":
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"status": s.status,
+			})
+
+		default:
+			w.Header().Set("Azure-AsyncOperation", s.URL+"/operation")
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id": testResourceId,
+			})
+		}
+	}))
+	return s
+}
+
+func (s *testServer) setStatus(status string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.status = status
+}
+
+func (s *testServer) create(t *testing.T) azure.FutureAPI {
+	req, err := http.NewRequest(http.MethodPut, s.URL+testResourceId, nil)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	future, err := azure.NewFutureFromResponse(resp)
+	if err != nil {
+		t.Fatalf("building future: %+v", err)
+	}
+	return &future
+}
+
+func testResourceData(t *testing.T) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		PendingCreationKey: Schema(),
+	}, map[string]interface{}{})
+}
+
+func TestWaitForCreationCompleted(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+	server.setStatus("Succeeded")
+
+	d := testResourceData(t)
+	pending, err := WaitForCreation(context.Background(), autorest.NewClientWithUserAgent(""), d, testResourceId, server.create(t))
+	if err != nil {
+		t.Fatalf("waiting for creation: %+v", err)
+	}
+	if pending {
+		t.Fatalf("expected the creation not to be pending")
+	}
+
+	if v := d.Get(PendingCreationKey).(string); v != "" {
+		t.Fatalf("expected no operation to be persisted but got %q", v)
+	}
+}
+
+func TestWaitForCreationTimeoutThenResume(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+	client := autorest.NewClientWithUserAgent("")
+
+	d := testResourceData(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	pending, err := WaitForCreation(ctx, client, d, testResourceId, server.create(t))
+	if err != nil {
+		t.Fatalf("expected no error when the context times out but got: %+v", err)
+	}
+	if !pending {
+		t.Fatalf("expected the creation to be pending when the context times out")
+	}
+
+	if d.Id() != testResourceId {
+		t.Fatalf("expected the ID to be set to %q but got %q", testResourceId, d.Id())
+	}
+	if d.Get(PendingCreationKey).(string) == "" {
+		t.Fatalf("expected the operation to be persisted")
+	}
+
+	server.setStatus("Succeeded")
+	resumed, err := ResumeCreation(context.Background(), client, d)
+	if err != nil {
+		t.Fatalf("resuming: %+v", err)
+	}
+	if !resumed {
+		t.Fatalf("expected the pending creation to be resumed")
+	}
+
+	if v := d.Get(PendingCreationKey).(string); v != "" {
+		t.Fatalf("expected the operation to be removed once completed but got %q", v)
+	}
+}
+
+func TestResumeCreationTimeout(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+	client := autorest.NewClientWithUserAgent("")
+
+	d := testResourceData(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if pending, err := WaitForCreation(ctx, client, d, testResourceId, server.create(t)); err != nil || !pending {
+		t.Fatalf("expected the creation to be pending when the context times out but got %t / %+v", pending, err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := ResumeCreation(ctx, client, d); err == nil {
+		t.Fatalf("expected an error when the context times out but didn't get one")
+	}
+
+	if d.Get(PendingCreationKey).(string) == "" {
+		t.Fatalf("expected the operation to remain persisted")
+	}
+}
+
+func TestResumeCreationFailed(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+	client := autorest.NewClientWithUserAgent("")
+
+	d := testResourceData(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if pending, err := WaitForCreation(ctx, client, d, testResourceId, server.create(t)); err != nil || !pending {
+		t.Fatalf("expected the creation to be pending when the context times out but got %t / %+v", pending, err)
+	}
+
+	server.setStatus("Failed")
+	if _, err := ResumeCreation(context.Background(), client, d); err == nil {
+		t.Fatalf("expected an error when the operation failed but didn't get one")
+	}
+
+	if v := d.Get(PendingCreationKey).(string); v != "" {
+		t.Fatalf("expected the operation to be removed once failed but got %q", v)
+	}
+}
+
+func TestResumeCreationNotPersisted(t *testing.T) {
+	d := testResourceData(t)
+	resumed, err := ResumeCreation(context.Background(), autorest.NewClientWithUserAgent(""), d)
+	if err != nil {
+		t.Fatalf("resuming: %+v", err)
+	}
+	if resumed {
+		t.Fatalf("expected nothing to be resumed")
+	}
+}
+
+func TestResumeCreationExpired(t *testing.T) {
+	contents, err := json.Marshal(pendingOperation{
+		StartedAt: time.Now().Add(-2 * maxAge),
+		Future:    json.RawMessage(`{}`),
+	})
+	if err != nil {
+		t.Fatalf("serializing: %+v", err)
+	}
+
+	d := testResourceData(t)
+	d.SetId(testResourceId)
+	if err := d.Set(PendingCreationKey, string(contents)); err != nil {
+		t.Fatalf("setting: %+v", err)
+	}
+
+	if _, err := ResumeCreation(context.Background(), autorest.NewClientWithUserAgent(""), d); err != nil {
+		t.Fatalf("resuming: %+v", err)
+	}
+	if v := d.Get(PendingCreationKey).(string); v != "" {
+		t.Fatalf("expected an expired operation to be ignored but got %q", v)
+	}
+}
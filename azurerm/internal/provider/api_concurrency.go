@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
+)
+
+const apiConcurrencyDefaultKey = "default"
+
+var resourceProviderNamespaceRegex = regexp.MustCompile(`^[A-Za-z0-9]+\.[A-Za-z0-9.]+$`)
+
+func validateApiConcurrency(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(map[string]interface{})
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be a map", k))
+		return
+	}
+
+	for key, raw := range v {
+		if !strings.EqualFold(key, apiConcurrencyDefaultKey) && !resourceProviderNamespaceRegex.MatchString(key) {
+			errors = append(errors, fmt.Errorf("%q must be either %q or a Resource Provider Namespace (for example `Microsoft.Network`) but got %q", k, apiConcurrencyDefaultKey, key))
+			continue
+		}
+
+		limit, err := strconv.Atoi(fmt.Sprintf("%v", raw))
+		if err != nil || limit < 1 {
+			errors = append(errors, fmt.Errorf("the value for %q within %q must be a number greater than 0 but got %v", key, k, raw))
+		}
+	}
+
+	return warnings, errors
+}
+
+func expandApiConcurrency(input map[string]interface{}) common.ConcurrencyLimits {
+	limits := common.ConcurrencyLimits{
+		ResourceProviders: map[string]int{},
+	}
+
+	for k, v := range input {
+		if strings.EqualFold(k, apiConcurrencyDefaultKey) {
+			limits.Default = v.(int)
+			continue
+		}
+
+		limits.ResourceProviders[k] = v.(int)
+	}
+
+	return limits
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
+)
+
+func TestExpandApiConcurrency(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    map[string]interface{}
+		Expected common.ConcurrencyLimits
+	}{
+		{
+			Name:  "Empty",
+			Input: map[string]interface{}{},
+			Expected: common.ConcurrencyLimits{
+				ResourceProviders: map[string]int{},
+			},
+		},
+		{
+			Name: "Default and Resource Providers",
+			Input: map[string]interface{}{
+				"default":           20,
+				"Microsoft.Network": 5,
+			},
+			Expected: common.ConcurrencyLimits{
+				Default: 20,
+				ResourceProviders: map[string]int{
+					"Microsoft.Network": 5,
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test Case: %q", v.Name)
+		result := expandApiConcurrency(v.Input)
+		if !reflect.DeepEqual(result, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, result)
+		}
+	}
+}
+
+func TestValidateApiConcurrency(t *testing.T) {
+	testData := []struct {
+		Name        string
+		Input       map[string]interface{}
+		ExpectError bool
+	}{
+		{
+			Name: "Valid",
+			Input: map[string]interface{}{
+				"default":                20,
+				"Microsoft.Network":      5,
+				"Microsoft.DBforMariaDB": "2",
+			},
+		},
+		{
+			Name: "Invalid Key",
+			Input: map[string]interface{}{
+				"network": 5,
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "Zero",
+			Input: map[string]interface{}{
+				"default": 0,
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "Not A Number",
+			Input: map[string]interface{}{
+				"Microsoft.Network": "five",
+			},
+			ExpectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test Case: %q", v.Name)
+		_, errors := validateApiConcurrency(v.Input, "api_concurrency")
+		if v.ExpectError != (len(errors) > 0) {
+			t.Fatalf("Expected an error to be %t but got %+v", v.ExpectError, errors)
+		}
+	}
+}
@@ -9,6 +9,12 @@ func schemaFeatures(supportLegacyTestSuite bool) *schema.Schema {
 	// NOTE: if there's only one nested field these want to be Required (since there's no point
 	//       specifying the block otherwise) - however for 2+ they should be optional
 	features := map[string]*schema.Schema{
+		"api_management": schemaSoftDeleteFeatures(),
+
+		"app_configuration": schemaSoftDeleteFeatures(),
+
+		"cognitive_account": schemaSoftDeleteFeatures(),
+
 		"key_vault": {
 			Type:     schema.TypeList,
 			Optional: true,
@@ -40,6 +46,20 @@ func schemaFeatures(supportLegacyTestSuite bool) *schema.Schema {
 			},
 		},
 
+		"management_lock": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"temporarily_lift_managed_locks": {
+						Type:     schema.TypeBool,
+						Required: true,
+					},
+				},
+			},
+		},
+
 		"network": {
 			Type:     schema.TypeList,
 			Optional: true,
@@ -54,6 +74,35 @@ func schemaFeatures(supportLegacyTestSuite bool) *schema.Schema {
 			},
 		},
 
+		// Azure Backup doesn't support purging Soft Deleted items, which are purged automatically once the retention period has passed
+		"recovery_services_vault": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"recover_soft_deleted": {
+						Type:     schema.TypeBool,
+						Required: true,
+					},
+				},
+			},
+		},
+
+		"resource_group": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"move_resources_on_group_change": {
+						Type:     schema.TypeBool,
+						Required: true,
+					},
+				},
+			},
+		},
+
 		"template_deployment": {
 			Type:     schema.TypeList,
 			Optional: true,
@@ -124,6 +173,29 @@ func schemaFeatures(supportLegacyTestSuite bool) *schema.Schema {
 	}
 }
 
+// schemaSoftDeleteFeatures returns the schema for a block controlling the behaviour of a Resource which supports Soft Delete
+func schemaSoftDeleteFeatures() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"recover_soft_deleted": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"purge_soft_delete_on_destroy": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+			},
+		},
+	}
+}
+
 func expandFeatures(input []interface{}) features.UserFeatures {
 	// these are the defaults if omitted from the config
 	features := features.Default()
@@ -134,6 +206,11 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 
 	val := input[0].(map[string]interface{})
 
+	features.ApiManagement = expandSoftDeleteFeatures(val["api_management"], features.ApiManagement)
+	features.AppConfiguration = expandSoftDeleteFeatures(val["app_configuration"], features.AppConfiguration)
+	features.CognitiveAccount = expandSoftDeleteFeatures(val["cognitive_account"], features.CognitiveAccount)
+	features.RecoveryServicesVault = expandSoftDeleteFeatures(val["recovery_services_vault"], features.RecoveryServicesVault)
+
 	if raw, ok := val["key_vault"]; ok {
 		items := raw.([]interface{})
 		if len(items) > 0 {
@@ -157,6 +234,16 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 		}
 	}
 
+	if raw, ok := val["management_lock"]; ok {
+		items := raw.([]interface{})
+		if len(items) > 0 {
+			managementLockRaw := items[0].(map[string]interface{})
+			if v, ok := managementLockRaw["temporarily_lift_managed_locks"]; ok {
+				features.ManagementLock.TemporarilyLiftManagedLocks = v.(bool)
+			}
+		}
+	}
+
 	if raw, ok := val["network"]; ok {
 		items := raw.([]interface{})
 		if len(items) > 0 {
@@ -167,6 +254,16 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 		}
 	}
 
+	if raw, ok := val["resource_group"]; ok {
+		items := raw.([]interface{})
+		if len(items) > 0 {
+			resourceGroupRaw := items[0].(map[string]interface{})
+			if v, ok := resourceGroupRaw["move_resources_on_group_change"]; ok {
+				features.ResourceGroup.MoveResourcesOnGroupChange = v.(bool)
+			}
+		}
+	}
+
 	if raw, ok := val["template_deployment"]; ok {
 		items := raw.([]interface{})
 		if len(items) > 0 {
@@ -202,3 +299,22 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 
 	return features
 }
+
+func expandSoftDeleteFeatures(input interface{}, defaults features.SoftDeleteFeatures) features.SoftDeleteFeatures {
+	output := defaults
+
+	items, ok := input.([]interface{})
+	if !ok || len(items) == 0 || items[0] == nil {
+		return output
+	}
+
+	raw := items[0].(map[string]interface{})
+	if v, ok := raw["recover_soft_deleted"]; ok {
+		output.RecoverSoftDeleted = v.(bool)
+	}
+	if v, ok := raw["purge_soft_delete_on_destroy"]; ok {
+		output.PurgeSoftDeleteOnDestroy = v.(bool)
+	}
+
+	return output
+}
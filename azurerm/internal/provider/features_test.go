@@ -18,13 +18,31 @@ func TestExpandFeatures(t *testing.T) {
 			Name:  "Empty Block",
 			Input: []interface{}{},
 			Expected: features.UserFeatures{
+				ApiManagement: features.SoftDeleteFeatures{
+					RecoverSoftDeleted:       false,
+					PurgeSoftDeleteOnDestroy: false,
+				},
+				AppConfiguration: features.SoftDeleteFeatures{
+					RecoverSoftDeleted:       false,
+					PurgeSoftDeleteOnDestroy: false,
+				},
+				CognitiveAccount: features.SoftDeleteFeatures{
+					RecoverSoftDeleted:       false,
+					PurgeSoftDeleteOnDestroy: false,
+				},
 				KeyVault: features.KeyVaultFeatures{
 					PurgeSoftDeleteOnDestroy:    true,
 					RecoverSoftDeletedKeyVaults: true,
 				},
+				ManagementLock: features.ManagementLockFeatures{
+					TemporarilyLiftManagedLocks: false,
+				},
 				Network: features.NetworkFeatures{
 					RelaxedLocking: false,
 				},
+				ResourceGroup: features.ResourceGroupFeatures{
+					MoveResourcesOnGroupChange: false,
+				},
 				TemplateDeployment: features.TemplateDeploymentFeatures{
 					DeleteNestedItemsDuringDeletion: true,
 				},
@@ -43,6 +61,24 @@ func TestExpandFeatures(t *testing.T) {
 			Name: "Complete Enabled",
 			Input: []interface{}{
 				map[string]interface{}{
+					"api_management": []interface{}{
+						map[string]interface{}{
+							"recover_soft_deleted":         true,
+							"purge_soft_delete_on_destroy": true,
+						},
+					},
+					"app_configuration": []interface{}{
+						map[string]interface{}{
+							"recover_soft_deleted":         true,
+							"purge_soft_delete_on_destroy": true,
+						},
+					},
+					"cognitive_account": []interface{}{
+						map[string]interface{}{
+							"recover_soft_deleted":         true,
+							"purge_soft_delete_on_destroy": true,
+						},
+					},
 					"key_vault": []interface{}{
 						map[string]interface{}{
 							"purge_soft_delete_on_destroy":    true,
@@ -54,11 +90,26 @@ func TestExpandFeatures(t *testing.T) {
 							"permanently_delete_on_destroy": true,
 						},
 					},
+					"management_lock": []interface{}{
+						map[string]interface{}{
+							"temporarily_lift_managed_locks": true,
+						},
+					},
 					"network": []interface{}{
 						map[string]interface{}{
 							"relaxed_locking": true,
 						},
 					},
+					"recovery_services_vault": []interface{}{
+						map[string]interface{}{
+							"recover_soft_deleted": true,
+						},
+					},
+					"resource_group": []interface{}{
+						map[string]interface{}{
+							"move_resources_on_group_change": true,
+						},
+					},
 					"template_deployment": []interface{}{
 						map[string]interface{}{
 							"delete_nested_items_during_deletion": true,
@@ -78,6 +129,18 @@ func TestExpandFeatures(t *testing.T) {
 				},
 			},
 			Expected: features.UserFeatures{
+				ApiManagement: features.SoftDeleteFeatures{
+					RecoverSoftDeleted:       true,
+					PurgeSoftDeleteOnDestroy: true,
+				},
+				AppConfiguration: features.SoftDeleteFeatures{
+					RecoverSoftDeleted:       true,
+					PurgeSoftDeleteOnDestroy: true,
+				},
+				CognitiveAccount: features.SoftDeleteFeatures{
+					RecoverSoftDeleted:       true,
+					PurgeSoftDeleteOnDestroy: true,
+				},
 				KeyVault: features.KeyVaultFeatures{
 					PurgeSoftDeleteOnDestroy:    true,
 					RecoverSoftDeletedKeyVaults: true,
@@ -85,9 +148,19 @@ func TestExpandFeatures(t *testing.T) {
 				LogAnalyticsWorkspace: features.LogAnalyticsWorkspaceFeatures{
 					PermanentlyDeleteOnDestroy: true,
 				},
+				ManagementLock: features.ManagementLockFeatures{
+					TemporarilyLiftManagedLocks: true,
+				},
 				Network: features.NetworkFeatures{
 					RelaxedLocking: true,
 				},
+				RecoveryServicesVault: features.SoftDeleteFeatures{
+					RecoverSoftDeleted:       true,
+					PurgeSoftDeleteOnDestroy: false,
+				},
+				ResourceGroup: features.ResourceGroupFeatures{
+					MoveResourcesOnGroupChange: true,
+				},
 				TemplateDeployment: features.TemplateDeploymentFeatures{
 					DeleteNestedItemsDuringDeletion: true,
 				},
@@ -104,17 +177,50 @@ func TestExpandFeatures(t *testing.T) {
 			Name: "Complete Disabled",
 			Input: []interface{}{
 				map[string]interface{}{
+					"api_management": []interface{}{
+						map[string]interface{}{
+							"recover_soft_deleted":         false,
+							"purge_soft_delete_on_destroy": false,
+						},
+					},
+					"app_configuration": []interface{}{
+						map[string]interface{}{
+							"recover_soft_deleted":         false,
+							"purge_soft_delete_on_destroy": false,
+						},
+					},
+					"cognitive_account": []interface{}{
+						map[string]interface{}{
+							"recover_soft_deleted":         false,
+							"purge_soft_delete_on_destroy": false,
+						},
+					},
 					"virtual_machine": []interface{}{
 						map[string]interface{}{
 							"delete_os_disk_on_deletion": false,
 							"graceful_shutdown":          false,
 						},
 					},
+					"management_lock": []interface{}{
+						map[string]interface{}{
+							"temporarily_lift_managed_locks": false,
+						},
+					},
 					"network_locking": []interface{}{
 						map[string]interface{}{
 							"relaxed_locking": false,
 						},
 					},
+					"recovery_services_vault": []interface{}{
+						map[string]interface{}{
+							"recover_soft_deleted": false,
+						},
+					},
+					"resource_group": []interface{}{
+						map[string]interface{}{
+							"move_resources_on_group_change": false,
+						},
+					},
 					"template_deployment": []interface{}{
 						map[string]interface{}{
 							"delete_nested_items_during_deletion": false,
@@ -139,6 +245,18 @@ func TestExpandFeatures(t *testing.T) {
 				},
 			},
 			Expected: features.UserFeatures{
+				ApiManagement: features.SoftDeleteFeatures{
+					RecoverSoftDeleted:       false,
+					PurgeSoftDeleteOnDestroy: false,
+				},
+				AppConfiguration: features.SoftDeleteFeatures{
+					RecoverSoftDeleted:       false,
+					PurgeSoftDeleteOnDestroy: false,
+				},
+				CognitiveAccount: features.SoftDeleteFeatures{
+					RecoverSoftDeleted:       false,
+					PurgeSoftDeleteOnDestroy: false,
+				},
 				KeyVault: features.KeyVaultFeatures{
 					PurgeSoftDeleteOnDestroy:    false,
 					RecoverSoftDeletedKeyVaults: false,
@@ -146,9 +264,19 @@ func TestExpandFeatures(t *testing.T) {
 				LogAnalyticsWorkspace: features.LogAnalyticsWorkspaceFeatures{
 					PermanentlyDeleteOnDestroy: false,
 				},
+				ManagementLock: features.ManagementLockFeatures{
+					TemporarilyLiftManagedLocks: false,
+				},
 				Network: features.NetworkFeatures{
 					RelaxedLocking: false,
 				},
+				RecoveryServicesVault: features.SoftDeleteFeatures{
+					RecoverSoftDeleted:       false,
+					PurgeSoftDeleteOnDestroy: false,
+				},
+				ResourceGroup: features.ResourceGroupFeatures{
+					MoveResourcesOnGroupChange: false,
+				},
 				TemplateDeployment: features.TemplateDeploymentFeatures{
 					DeleteNestedItemsDuringDeletion: false,
 				},
@@ -242,6 +370,71 @@ func TestExpandFeaturesKeyVault(t *testing.T) {
 	}
 }
 
+func TestExpandFeaturesManagementLock(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		EnvVars  map[string]interface{}
+		Expected features.UserFeatures
+	}{
+		{
+			Name: "Empty Block",
+			Input: []interface{}{
+				map[string]interface{}{
+					"management_lock": []interface{}{},
+				},
+			},
+			Expected: features.UserFeatures{
+				ManagementLock: features.ManagementLockFeatures{
+					TemporarilyLiftManagedLocks: false,
+				},
+			},
+		},
+		{
+			Name: "Temporarily Lift Managed Locks Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"management_lock": []interface{}{
+						map[string]interface{}{
+							"temporarily_lift_managed_locks": true,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				ManagementLock: features.ManagementLockFeatures{
+					TemporarilyLiftManagedLocks: true,
+				},
+			},
+		},
+		{
+			Name: "Temporarily Lift Managed Locks Disabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"management_lock": []interface{}{
+						map[string]interface{}{
+							"temporarily_lift_managed_locks": false,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				ManagementLock: features.ManagementLockFeatures{
+					TemporarilyLiftManagedLocks: false,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		result := expandFeatures(testCase.Input)
+		if !reflect.DeepEqual(result.ManagementLock, testCase.Expected.ManagementLock) {
+			t.Fatalf("Expected %+v but got %+v", result.ManagementLock, testCase.Expected.ManagementLock)
+		}
+	}
+}
+
 func TestExpandFeaturesNetwork(t *testing.T) {
 	testData := []struct {
 		Name     string
@@ -307,6 +500,71 @@ func TestExpandFeaturesNetwork(t *testing.T) {
 	}
 }
 
+func TestExpandFeaturesResourceGroup(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		EnvVars  map[string]interface{}
+		Expected features.UserFeatures
+	}{
+		{
+			Name: "Empty Block",
+			Input: []interface{}{
+				map[string]interface{}{
+					"resource_group": []interface{}{},
+				},
+			},
+			Expected: features.UserFeatures{
+				ResourceGroup: features.ResourceGroupFeatures{
+					MoveResourcesOnGroupChange: false,
+				},
+			},
+		},
+		{
+			Name: "Move Resources Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"resource_group": []interface{}{
+						map[string]interface{}{
+							"move_resources_on_group_change": true,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				ResourceGroup: features.ResourceGroupFeatures{
+					MoveResourcesOnGroupChange: true,
+				},
+			},
+		},
+		{
+			Name: "Move Resources Disabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"resource_group": []interface{}{
+						map[string]interface{}{
+							"move_resources_on_group_change": false,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				ResourceGroup: features.ResourceGroupFeatures{
+					MoveResourcesOnGroupChange: false,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		result := expandFeatures(testCase.Input)
+		if !reflect.DeepEqual(result.ResourceGroup, testCase.Expected.ResourceGroup) {
+			t.Fatalf("Expected %+v but got %+v", result.ResourceGroup, testCase.Expected.ResourceGroup)
+		}
+	}
+}
+
 func TestExpandFeaturesTemplateDeployment(t *testing.T) {
 	testData := []struct {
 		Name     string
@@ -507,6 +765,79 @@ func TestExpandFeaturesVirtualMachineScaleSet(t *testing.T) {
 	}
 }
 
+func TestExpandFeaturesSoftDelete(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		Expected features.SoftDeleteFeatures
+	}{
+		{
+			Name: "Block Omitted",
+			Input: []interface{}{
+				map[string]interface{}{},
+			},
+			Expected: features.SoftDeleteFeatures{
+				RecoverSoftDeleted:       false,
+				PurgeSoftDeleteOnDestroy: false,
+			},
+		},
+		{
+			Name: "Empty Block",
+			Input: []interface{}{
+				map[string]interface{}{
+					"api_management": []interface{}{},
+				},
+			},
+			Expected: features.SoftDeleteFeatures{
+				RecoverSoftDeleted:       false,
+				PurgeSoftDeleteOnDestroy: false,
+			},
+		},
+		{
+			Name: "Recover Disabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"api_management": []interface{}{
+						map[string]interface{}{
+							"recover_soft_deleted":         false,
+							"purge_soft_delete_on_destroy": true,
+						},
+					},
+				},
+			},
+			Expected: features.SoftDeleteFeatures{
+				RecoverSoftDeleted:       false,
+				PurgeSoftDeleteOnDestroy: true,
+			},
+		},
+		{
+			Name: "Purge Disabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"api_management": []interface{}{
+						map[string]interface{}{
+							"recover_soft_deleted":         true,
+							"purge_soft_delete_on_destroy": false,
+						},
+					},
+				},
+			},
+			Expected: features.SoftDeleteFeatures{
+				RecoverSoftDeleted:       true,
+				PurgeSoftDeleteOnDestroy: false,
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		result := expandFeatures(testCase.Input)
+		if !reflect.DeepEqual(result.ApiManagement, testCase.Expected) {
+			t.Fatalf("Expected %+v but got %+v", testCase.Expected, result.ApiManagement)
+		}
+	}
+}
+
 func TestExpandFeaturesLogAnalyticsWorkspace(t *testing.T) {
 	testData := []struct {
 		Name     string
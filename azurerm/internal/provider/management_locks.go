@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/managementlocks"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+)
+
+// decorateResourceWithManagementLocks wraps the Update and Delete functions so that any Management Locks
+// preventing these are detected before the request is sent to Azure (and optionally lifted until the
+// operation has completed)
+func decorateResourceWithManagementLocks(name string, resource *schema.Resource) {
+	// Management Locks don't prevent other Management Locks from being removed
+	if name == "azurerm_management_lock" {
+		return
+	}
+
+	type timeoutFunc func(ctx context.Context, d *schema.ResourceData) (context.Context, context.CancelFunc)
+	wrap := func(f func(d *schema.ResourceData, meta interface{}) error, operation managementlocks.Operation, timeout timeoutFunc) func(d *schema.ResourceData, meta interface{}) error {
+		return func(d *schema.ResourceData, meta interface{}) error {
+			client := meta.(*clients.Client)
+
+			ctx, cancel := timeout(client.StopContext, d)
+			restore, err := managementlocks.CheckForLocks(ctx, client, d.Id(), operation)
+			cancel()
+			if err != nil {
+				return err
+			}
+
+			err = f(d, meta)
+
+			ctx, cancel = timeout(client.StopContext, d)
+			defer cancel()
+			if restoreErr := restore(ctx, err == nil); restoreErr != nil {
+				if err != nil {
+					log.Printf("[WARN] %+v", restoreErr)
+					return err
+				}
+				return restoreErr
+			}
+			return err
+		}
+	}
+
+	resource.Delete = wrap(resource.Delete, managementlocks.OperationDelete, timeouts.ForDelete)
+	if resource.Update != nil {
+		resource.Update = wrap(resource.Update, managementlocks.OperationUpdate, timeouts.ForUpdate)
+	}
+}
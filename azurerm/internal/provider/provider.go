@@ -5,16 +5,22 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
 
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/go-azure-helpers/authentication"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/providercache"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/resourceproviders"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tracing"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
@@ -26,7 +32,13 @@ func TestAzureProvider() terraform.ResourceProvider {
 	return azureProvider(true)
 }
 
-func azureProvider(supportLegacyTestSuite bool) terraform.ResourceProvider {
+// TestAzureProviderWithSendDecorators returns the Provider used in the Acceptance Tests, where all
+// requests to Azure are sent through the specified decorators (for example to record/replay them)
+func TestAzureProviderWithSendDecorators(sendDecorators ...autorest.SendDecorator) terraform.ResourceProvider {
+	return azureProvider(true, sendDecorators...)
+}
+
+func azureProvider(supportLegacyTestSuite bool, sendDecorators ...autorest.SendDecorator) terraform.ResourceProvider {
 	// avoids this showing up in test output
 	debugLog := func(f string, v ...interface{}) {
 		if os.Getenv("TF_LOG") == "" {
@@ -61,6 +73,24 @@ func azureProvider(supportLegacyTestSuite bool) terraform.ResourceProvider {
 			dataSources[key] = dataSource
 		}
 
+		if v, ok := service.(sdk.TypedServiceRegistrationWithListDataSources); ok {
+			debugLog("[DEBUG] Registering List Data Sources for %q..", service.Name())
+			for _, ds := range v.ListDataSources() {
+				key := ds.ResourceType()
+				if existing := dataSources[key]; existing != nil {
+					panic(fmt.Sprintf("An existing Data Source exists for %q", key))
+				}
+
+				wrapper := sdk.NewListDataSourceWrapper(ds)
+				dataSource, err := wrapper.DataSource()
+				if err != nil {
+					panic(fmt.Errorf("creating Wrapper for List Data Source %q: %+v", key, err))
+				}
+
+				dataSources[key] = dataSource
+			}
+		}
+
 		debugLog("[DEBUG] Registering Resources for %q..", service.Name())
 		for _, r := range service.Resources() {
 			key := r.ResourceType()
@@ -98,6 +128,16 @@ func azureProvider(supportLegacyTestSuite bool) terraform.ResourceProvider {
 		}
 	}
 
+	for name, resource := range resources {
+		decorateResourceWithTags(resource)
+		decorateResourceWithManagementLocks(name, resource)
+		decorateResourceWithTracing(name, resource)
+	}
+
+	for name, dataSource := range dataSources {
+		decorateResourceWithTracing(name, dataSource)
+	}
+
 	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"subscription_id": {
@@ -144,6 +184,13 @@ func azureProvider(supportLegacyTestSuite bool) terraform.ResourceProvider {
 				Description: "The Hostname which should be used for the Azure Metadata Service.",
 			},
 
+			"environment_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_ENVIRONMENT_FILEPATH", ""),
+				Description: "The path to a JSON file describing the endpoints of a Custom Azure Environment, which is used rather than the Azure Metadata Service.",
+			},
+
 			"metadata_url": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -189,6 +236,27 @@ func azureProvider(supportLegacyTestSuite bool) terraform.ResourceProvider {
 				Description: "The path to a custom endpoint for Managed Service Identity - in most circumstances this should be detected automatically. ",
 			},
 
+			// OIDC specific fields
+			"use_oidc": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_USE_OIDC", false),
+				Description: "Allow OIDC (Workload Identity Federation) to be used for Authentication.",
+			},
+			"oidc_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_OIDC_TOKEN", ""),
+				Description: "The OIDC token for use when authenticating as a Service Principal using OIDC.",
+			},
+			"oidc_token_file_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"ARM_OIDC_TOKEN_FILE_PATH", "AZURE_FEDERATED_TOKEN_FILE"}, ""),
+				Description: "The path to a file containing an OIDC token for use when authenticating as a Service Principal using OIDC, which is re-read when the access token is refreshed.",
+			},
+
 			// Managed Tracking GUID for User-agent
 			"partner_id": {
 				Type:         schema.TypeString,
@@ -212,8 +280,72 @@ func azureProvider(supportLegacyTestSuite bool) terraform.ResourceProvider {
 				Description: "This will disable the Terraform Partner ID which is used if a custom `partner_id` isn't specified.",
 			},
 
+			// Logging
+			"disable_log_redaction": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_DISABLE_LOG_REDACTION", false),
+				Description: "This will disable the redaction of secrets (such as Access Keys, SAS Tokens and Connection Strings) from the requests and responses which are logged when `TF_LOG` is set to `DEBUG`.",
+			},
+
+			"log_redaction_keys": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Description: "A list of additional JSON property names whose values should be redacted from the requests and responses which are logged.",
+			},
+
+			// Retry & Throttling
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_MAX_RETRIES", nil),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum number of times a request to Azure should be retried. Defaults to 3 when the retry policy is configured.",
+			},
+
+			"retry_min_backoff": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_RETRY_MIN_BACKOFF", nil),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The number of seconds to wait before retrying a request, which is doubled for each subsequent retry. Defaults to 30 when the retry policy is configured.",
+			},
+
+			"retry_max_backoff": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_RETRY_MAX_BACKOFF", nil),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum number of seconds to wait between retries. Defaults to 300 when the retry policy is configured.",
+			},
+
+			"honour_rate_limit_headers": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_HONOUR_RATE_LIMIT_HEADERS", false),
+				Description: "Should the AzureRM Provider wait for the duration specified in the `Retry-After` header, and slow down requests when the `x-ms-ratelimit-remaining-subscription-reads` and `x-ms-ratelimit-remaining-subscription-writes` headers indicate that requests are about to be throttled?",
+			},
+
+			"api_concurrency": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ValidateFunc: validateApiConcurrency,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "The maximum number of concurrent requests which should be sent to each Resource Provider (for example `Microsoft.Network`) within a Subscription, where the key `default` applies to all other Resource Providers.",
+			},
+
 			"features": schemaFeatures(supportLegacyTestSuite),
 
+			"default_tags": schemaDefaultTags(),
+
+			"ignore_tags": schemaIgnoreTags(),
+
 			// Advanced feature flags
 			"skip_provider_registration": {
 				Type:        schema.TypeBool,
@@ -243,12 +375,12 @@ func azureProvider(supportLegacyTestSuite bool) terraform.ResourceProvider {
 		}
 	}
 
-	p.ConfigureFunc = providerConfigure(p)
+	p.ConfigureFunc = providerConfigure(p, sendDecorators)
 
 	return p
 }
 
-func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
+func providerConfigure(p *schema.Provider, sendDecorators []autorest.SendDecorator) schema.ConfigureFunc {
 	return func(d *schema.ResourceData) (interface{}, error) {
 		var auxTenants []string
 		if v, ok := d.Get("auxiliary_tenant_ids").([]interface{}); ok && len(v) > 0 {
@@ -293,7 +425,18 @@ func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
 			ClientSecretDocsLink: "https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/guides/service_principal_client_secret",
 		}
 
-		config, err := builder.Build()
+		// a Client Certificate or Client Secret take precedence over OIDC, consistent with the ordering of the other authentication methods
+		var oidcAuth *clients.OIDCAuth
+		if d.Get("use_oidc").(bool) && builder.ClientCertPath == "" && builder.ClientSecret == "" {
+			oidcAuth = &clients.OIDCAuth{
+				ClientID:      builder.ClientID,
+				TenantID:      builder.TenantID,
+				Token:         d.Get("oidc_token").(string),
+				TokenFilePath: d.Get("oidc_token_file_path").(string),
+			}
+		}
+
+		config, err := buildAuthConfig(builder, oidcAuth)
 		if err != nil {
 			return nil, fmt.Errorf("Error building AzureRM Client: %s", err)
 		}
@@ -305,6 +448,22 @@ func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
 			terraformVersion = "0.11+compatible"
 		}
 
+		retries, err := expandRetryOptions(d)
+		if err != nil {
+			return nil, err
+		}
+
+		var logRedaction *common.LogRedaction
+		if !d.Get("disable_log_redaction").(bool) {
+			var logRedactionKeys []string
+			if v, ok := d.Get("log_redaction_keys").([]interface{}); ok && len(v) > 0 {
+				logRedactionKeys = *utils.ExpandStringSlice(v)
+			} else if v := os.Getenv("ARM_LOG_REDACTION_KEYS"); v != "" {
+				logRedactionKeys = strings.Split(v, ";")
+			}
+			logRedaction = common.NewLogRedaction(logRedactionKeys)
+		}
+
 		skipProviderRegistration := d.Get("skip_provider_registration").(bool)
 		clientBuilder := clients.ClientBuilder{
 			AuthConfig:                  config,
@@ -314,21 +473,36 @@ func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
 			DisableCorrelationRequestID: d.Get("disable_correlation_request_id").(bool),
 			DisableTerraformPartnerID:   d.Get("disable_terraform_partner_id").(bool),
 			Features:                    expandFeatures(d.Get("features").([]interface{})),
+			DefaultTags:                 expandDefaultTags(d.Get("default_tags").([]interface{})),
+			IgnoreTags:                  expandIgnoreTags(d.Get("ignore_tags").([]interface{})),
 			StorageUseAzureAD:           d.Get("storage_use_azuread").(bool),
+			Retries:                     retries,
+			ConcurrencyLimits:           expandApiConcurrency(d.Get("api_concurrency").(map[string]interface{})),
+			SendDecorators:              sendDecorators,
+			OIDCAuth:                    oidcAuth,
+			EnvironmentFilePath:         d.Get("environment_file").(string),
+			LogRedaction:                logRedaction,
+			ProviderCacheDirectory:      os.Getenv(providercache.EnvironmentVariable),
 		}
+		if err := tracing.ConfigureFromEnvironment(); err != nil {
+			return nil, fmt.Errorf("configuring tracing: %+v", err)
+		}
+
 		client, err := clients.Build(p.StopContext(), clientBuilder)
 		if err != nil {
 			return nil, err
 		}
 
 		client.StopContext = p.StopContext()
+		tags.Configure(client.DefaultTags, client.IgnoreTags)
 
 		if !skipProviderRegistration {
 			// List all the available providers and their registration state to avoid unnecessary
 			// requests. This also lets us check if the provider credentials are correct.
-			ctx := client.StopContext
+			ctx, span := tracing.StartSpan(client.StopContext, "Provider.RegisterResourceProviders")
 			providerList, err := client.Resource.ProvidersClient.List(ctx, nil, "")
 			if err != nil {
+				span.End(err)
 				return nil, fmt.Errorf("Unable to list provider registration status, it is possible that this is due to invalid "+
 					"credentials or the service principal does not have permission to use the Resource Manager API, Azure "+
 					"error: %s", err)
@@ -338,14 +512,78 @@ func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
 			requiredResourceProviders := resourceproviders.Required()
 
 			if err := resourceproviders.EnsureRegistered(ctx, *client.Resource.ProvidersClient, availableResourceProviders, requiredResourceProviders); err != nil {
+				span.End(err)
 				return nil, fmt.Errorf(resourceProviderRegistrationErrorFmt, err)
 			}
+			span.End(nil)
 		}
 
 		return client, nil
 	}
 }
 
+// buildAuthConfig builds the `authentication.Config` using the Builder, unless OIDC is being used to authenticate (which
+// isn't supported by the Builder) - in which case the Config is built from the Builder's core fields
+func buildAuthConfig(builder *authentication.Builder, oidcAuth *clients.OIDCAuth) (*authentication.Config, error) {
+	if oidcAuth == nil {
+		return builder.Build()
+	}
+
+	log.Printf("[DEBUG] Using OIDC for Authentication")
+	if err := oidcAuth.Validate(); err != nil {
+		return nil, fmt.Errorf("Validating Service Principal / OIDC: %+v", err)
+	}
+	if builder.SubscriptionID == "" {
+		return nil, fmt.Errorf("A Subscription ID must be configured when authenticating as a Service Principal using OIDC.")
+	}
+	if len(builder.AuxiliaryTenantIDs) > 0 {
+		return nil, fmt.Errorf("Auxiliary Tenants aren't supported when authenticating as a Service Principal using OIDC.")
+	}
+
+	return &authentication.Config{
+		ClientID:       builder.ClientID,
+		SubscriptionID: builder.SubscriptionID,
+		TenantID:       builder.TenantID,
+		Environment:    builder.Environment,
+		MetadataHost:   builder.MetadataHost,
+	}, nil
+}
+
+// expandRetryOptions returns the retry policy configured in the Provider block - or nil when none of the
+// retry arguments are specified, in which case autorest's default retry behaviour is used
+func expandRetryOptions(d *schema.ResourceData) (*common.RetryOptions, error) {
+	maxRetries := d.Get("max_retries").(int)
+	minBackoffSeconds := d.Get("retry_min_backoff").(int)
+	maxBackoffSeconds := d.Get("retry_max_backoff").(int)
+	honourRateLimitHeaders := d.Get("honour_rate_limit_headers").(bool)
+	if maxRetries == 0 && minBackoffSeconds == 0 && maxBackoffSeconds == 0 && !honourRateLimitHeaders {
+		return nil, nil
+	}
+
+	if maxRetries == 0 {
+		maxRetries = 3
+	}
+	if minBackoffSeconds == 0 {
+		minBackoffSeconds = 30
+	}
+	if maxBackoffSeconds == 0 {
+		maxBackoffSeconds = 300
+	}
+
+	minBackoff := time.Duration(minBackoffSeconds) * time.Second
+	maxBackoff := time.Duration(maxBackoffSeconds) * time.Second
+	if minBackoff > maxBackoff {
+		return nil, fmt.Errorf("`retry_min_backoff` (%s) must be less than or equal to `retry_max_backoff` (%s)", minBackoff, maxBackoff)
+	}
+
+	return &common.RetryOptions{
+		MaxRetries:             maxRetries,
+		MinBackoff:             minBackoff,
+		MaxBackoff:             maxBackoff,
+		HonourRateLimitHeaders: honourRateLimitHeaders,
+	}, nil
+}
+
 const resourceProviderRegistrationErrorFmt = `Error ensuring Resource Providers are registered.
 
 Terraform automatically attempts to register the Resource Providers it supports to
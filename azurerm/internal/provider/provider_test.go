@@ -2,10 +2,12 @@ package provider
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
 )
 
 func TestProvider(t *testing.T) {
@@ -89,3 +91,80 @@ func TestResourcesSupportCustomTimeouts(t *testing.T) {
 func TestProvider_impl(t *testing.T) {
 	_ = AzureProvider()
 }
+
+func TestExpandRetryOptions(t *testing.T) {
+	for _, v := range []string{"ARM_MAX_RETRIES", "ARM_RETRY_MIN_BACKOFF", "ARM_RETRY_MAX_BACKOFF", "ARM_HONOUR_RATE_LIMIT_HEADERS"} {
+		t.Setenv(v, "")
+	}
+
+	providerSchema := TestAzureProvider().(*schema.Provider).Schema
+	retrySchema := map[string]*schema.Schema{}
+	for _, key := range []string{"max_retries", "retry_min_backoff", "retry_max_backoff", "honour_rate_limit_headers"} {
+		retrySchema[key] = providerSchema[key]
+	}
+
+	testData := []struct {
+		Name        string
+		Input       map[string]interface{}
+		Expected    *common.RetryOptions
+		ExpectError bool
+	}{
+		{
+			Name:     "Not Configured",
+			Input:    map[string]interface{}{},
+			Expected: nil,
+		},
+		{
+			Name: "Honour Rate Limit Headers",
+			Input: map[string]interface{}{
+				"honour_rate_limit_headers": true,
+			},
+			Expected: &common.RetryOptions{
+				MaxRetries:             3,
+				MinBackoff:             30 * time.Second,
+				MaxBackoff:             300 * time.Second,
+				HonourRateLimitHeaders: true,
+			},
+		},
+		{
+			Name: "Max Retries",
+			Input: map[string]interface{}{
+				"max_retries": 5,
+			},
+			Expected: &common.RetryOptions{
+				MaxRetries: 5,
+				MinBackoff: 30 * time.Second,
+				MaxBackoff: 300 * time.Second,
+			},
+		},
+		{
+			Name: "Min Backoff Greater Than Max Backoff",
+			Input: map[string]interface{}{
+				"retry_min_backoff": 60,
+				"retry_max_backoff": 10,
+			},
+			ExpectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test Case: %q", v.Name)
+
+		d := schema.TestResourceDataRaw(t, retrySchema, v.Input)
+		result, err := expandRetryOptions(d)
+		if err != nil {
+			if v.ExpectError {
+				continue
+			}
+
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.ExpectError {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		if !reflect.DeepEqual(result, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, result)
+		}
+	}
+}
@@ -19,6 +19,24 @@ func TestTypedDataSourcesContainValidModelObjects(t *testing.T) {
 	}
 }
 
+func TestTypedListDataSourcesContainValidModelObjects(t *testing.T) {
+	for _, service := range SupportedTypedServices() {
+		v, ok := service.(sdk.TypedServiceRegistrationWithListDataSources)
+		if !ok {
+			continue
+		}
+
+		t.Logf("Service %q..", service.Name())
+		for _, dataSource := range v.ListDataSources() {
+			t.Logf("- List DataSource %q..", dataSource.ResourceType())
+			obj := dataSource.ModelObject()
+			if err := sdk.ValidateModelObject(&obj); err != nil {
+				t.Fatalf("validating model: %+v", err)
+			}
+		}
+	}
+}
+
 func TestTypedResourcesContainValidModelObjects(t *testing.T) {
 	for _, service := range SupportedTypedServices() {
 		t.Logf("Service %q..", service.Name())
@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func schemaDefaultTags() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"tags": {
+					Type:         schema.TypeMap,
+					Optional:     true,
+					ValidateFunc: tags.Validate,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+		Description: "A set of Tags which should be assigned to every Resource which supports Tags.",
+	}
+}
+
+func expandDefaultTags(input []interface{}) map[string]string {
+	output := make(map[string]string)
+
+	if len(input) == 0 || input[0] == nil {
+		return output
+	}
+
+	raw := input[0].(map[string]interface{})
+	for k, v := range raw["tags"].(map[string]interface{}) {
+		// Validate should have ignored this error already
+		value, _ := tags.TagValueToString(v)
+		output[k] = value
+	}
+
+	return output
+}
+
+func schemaIgnoreTags() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"keys": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+				"key_prefixes": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+			},
+		},
+		Description: "A set of Tags which are managed outside of Terraform and should be ignored on all Resources.",
+	}
+}
+
+func expandIgnoreTags(input []interface{}) tags.IgnoreConfig {
+	output := tags.IgnoreConfig{
+		Keys:        make([]string, 0),
+		KeyPrefixes: make([]string, 0),
+	}
+
+	if len(input) == 0 || input[0] == nil {
+		return output
+	}
+
+	raw := input[0].(map[string]interface{})
+	if v, ok := raw["keys"]; ok {
+		output.Keys = *utils.ExpandStringSlice(v.(*schema.Set).List())
+	}
+	if v, ok := raw["key_prefixes"]; ok {
+		output.KeyPrefixes = *utils.ExpandStringSlice(v.(*schema.Set).List())
+	}
+
+	return output
+}
+
+// supportsTags determines whether the specified Resource exposes a user-configurable `tags` field
+func supportsTags(resource *schema.Resource) bool {
+	if _, exists := resource.Schema["tags_all"]; exists {
+		return false
+	}
+
+	v, ok := resource.Schema["tags"]
+	if !ok {
+		return false
+	}
+
+	return v.Type == schema.TypeMap && v.Optional && !v.Computed && v.Deprecated == ""
+}
+
+// decorateResourceWithTags adds the Computed `tags_all` field to the Resource, which `tags.FlattenAndSet`
+// sets to the effective tags for the Resource (including the Default Tags merged in by `tags.Expand`).
+//
+// Since neither of these have access to the Resource Data prior to the tags being sent to Azure, the
+// Update function is wrapped so that any Ignored Tags present on the remote resource are sent back
+// to Azure unchanged - and the plan is customized to show the effective tags within `tags_all`.
+func decorateResourceWithTags(resource *schema.Resource) {
+	if !supportsTags(resource) {
+		return
+	}
+
+	resource.Schema["tags_all"] = tags.SchemaAll()
+
+	if update := resource.Update; update != nil {
+		resource.Update = func(d *schema.ResourceData, meta interface{}) error {
+			existing, _ := d.GetChange("tags_all")
+			ignored := tags.OnlyIgnored(existing.(map[string]interface{}), tags.Ignored())
+			if len(ignored) > 0 {
+				if err := d.Set("tags", withIgnoredTags(d.Get("tags").(map[string]interface{}), ignored)); err != nil {
+					return fmt.Errorf("setting `tags`: %+v", err)
+				}
+			}
+
+			return update(d, meta)
+		}
+	}
+
+	forceNew := resource.Schema["tags"].ForceNew
+	customizeDiff := resource.CustomizeDiff
+	resource.CustomizeDiff = func(d *schema.ResourceDiff, meta interface{}) error {
+		if customizeDiff != nil {
+			if err := customizeDiff(d, meta); err != nil {
+				return err
+			}
+		}
+
+		if !d.NewValueKnown("tags") {
+			return d.SetNewComputed("tags_all")
+		}
+
+		existing := d.Get("tags_all").(map[string]interface{})
+		ignored := tags.OnlyIgnored(existing, tags.Ignored())
+		effective := withIgnoredTags(tags.MergeDefaults(tags.Defaults(), d.Get("tags").(map[string]interface{})), ignored)
+		if reflect.DeepEqual(effective, existing) {
+			return nil
+		}
+
+		if err := d.SetNew("tags_all", effective); err != nil {
+			return err
+		}
+
+		// when the tags can't be updated in-place the Resource has to be recreated to apply a change to the Default Tags
+		if forceNew && d.Id() != "" {
+			return d.ForceNew("tags_all")
+		}
+
+		return nil
+	}
+}
+
+// withIgnoredTags appends the ignored tags present on the remote resource to the specified tags,
+// so that these aren't removed when the tags are sent to Azure
+func withIgnoredTags(input map[string]interface{}, ignored map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(input)+len(ignored))
+	for k, v := range input {
+		output[k] = v
+	}
+
+	for k, v := range ignored {
+		if _, exists := output[k]; !exists {
+			output[k] = v
+		}
+	}
+
+	return output
+}
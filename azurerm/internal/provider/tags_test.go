@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
+)
+
+func TestExpandDefaultTags(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		Expected map[string]string
+	}{
+		{
+			Name:     "Empty Block",
+			Input:    []interface{}{},
+			Expected: map[string]string{},
+		},
+		{
+			Name: "Tags",
+			Input: []interface{}{
+				map[string]interface{}{
+					"tags": map[string]interface{}{
+						"env":   "prod",
+						"owner": "team",
+					},
+				},
+			},
+			Expected: map[string]string{
+				"env":   "prod",
+				"owner": "team",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test Case: %q", v.Name)
+		result := expandDefaultTags(v.Input)
+		if !reflect.DeepEqual(result, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, result)
+		}
+	}
+}
+
+func TestExpandIgnoreTags(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		Expected tags.IgnoreConfig
+	}{
+		{
+			Name:  "Empty Block",
+			Input: []interface{}{},
+			Expected: tags.IgnoreConfig{
+				Keys:        []string{},
+				KeyPrefixes: []string{},
+			},
+		},
+		{
+			Name: "Keys and Prefixes",
+			Input: []interface{}{
+				map[string]interface{}{
+					"keys":         schema.NewSet(schema.HashString, []interface{}{"CreatedOnDate"}),
+					"key_prefixes": schema.NewSet(schema.HashString, []interface{}{"hidden-link:"}),
+				},
+			},
+			Expected: tags.IgnoreConfig{
+				Keys:        []string{"CreatedOnDate"},
+				KeyPrefixes: []string{"hidden-link:"},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test Case: %q", v.Name)
+		result := expandIgnoreTags(v.Input)
+		if !reflect.DeepEqual(result, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, result)
+		}
+	}
+}
+
+func TestDecorateResourceWithTagsForceNew(t *testing.T) {
+	tags.Configure(map[string]string{"env": "prod"}, tags.IgnoreConfig{})
+	t.Cleanup(func() {
+		tags.Configure(nil, tags.IgnoreConfig{})
+	})
+
+	// the tags sent to Azure by the Create function, which the Read function returns
+	var remote map[string]*string
+	resource := &schema.Resource{
+		Create: func(d *schema.ResourceData, meta interface{}) error {
+			remote = tags.Expand(d.Get("tags").(map[string]interface{}))
+			d.SetId("example")
+			return tags.FlattenAndSet(d, remote)
+		},
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			return tags.FlattenAndSet(d, remote)
+		},
+		Delete: func(d *schema.ResourceData, meta interface{}) error {
+			return nil
+		},
+		Schema: map[string]*schema.Schema{
+			"tags": tags.ForceNewSchema(),
+		},
+	}
+	decorateResourceWithTags(resource)
+	if err := resource.InternalValidate(nil, true); err != nil {
+		t.Fatalf("validating the decorated Resource: %+v", err)
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"tags": map[string]interface{}{"hello": "there"},
+	})
+	diff, err := resource.Diff(nil, config, nil)
+	if err != nil {
+		t.Fatalf("planning the creation: %+v", err)
+	}
+	if v := diff.Attributes["tags_all.env"]; v == nil || v.New != "prod" {
+		t.Fatalf("expected `tags_all` to contain the Default Tag but got %+v", diff.Attributes)
+	}
+
+	state, err := resource.Apply(nil, diff, nil)
+	if err != nil {
+		t.Fatalf("creating: %+v", err)
+	}
+	if v := remote["env"]; v == nil || *v != "prod" {
+		t.Fatalf("expected the Default Tag to be sent to Azure but got %+v", remote)
+	}
+	if _, ok := state.Attributes["tags.env"]; ok {
+		t.Fatalf("expected the Default Tag not to be present in `tags` but got %+v", state.Attributes)
+	}
+	if state.Attributes["tags_all.env"] != "prod" {
+		t.Fatalf("expected the Default Tag to be present in `tags_all` but got %+v", state.Attributes)
+	}
+
+	diff, err = resource.Diff(state, config, nil)
+	if err != nil {
+		t.Fatalf("planning with no changes: %+v", err)
+	}
+	if !diff.Empty() {
+		t.Fatalf("expected no changes but got %+v", diff.Attributes)
+	}
+
+	tags.Configure(map[string]string{"env": "dev"}, tags.IgnoreConfig{})
+	diff, err = resource.Diff(state, config, nil)
+	if err != nil {
+		t.Fatalf("planning a change to the Default Tags: %+v", err)
+	}
+	if !diff.RequiresNew() {
+		t.Fatalf("expected a change to the Default Tags to recreate the Resource but got %+v", diff.Attributes)
+	}
+}
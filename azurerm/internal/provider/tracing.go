@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tracing"
+)
+
+// decorateResourceWithTracing wraps the Create, Read, Update and Delete functions so that (when tracing is enabled)
+// a Span named after the Resource Type and operation (for example `azurerm_resource_group.create`) is recorded for
+// each, which the Spans for the API requests made during the operation are recorded within.
+//
+// This must be the last decorator applied to a Resource, so that the Span also covers the other decorators.
+func decorateResourceWithTracing(name string, resource *schema.Resource) {
+	wrap := func(f func(d *schema.ResourceData, meta interface{}) error, operation string) func(d *schema.ResourceData, meta interface{}) error {
+		if f == nil {
+			return nil
+		}
+
+		return func(d *schema.ResourceData, meta interface{}) error {
+			client := meta.(*clients.Client)
+
+			ctx, span := tracing.StartResourceOperation(client.StopContext, name, operation)
+			if span == nil {
+				return f(d, meta)
+			}
+
+			// the Span is passed to the operation (which uses the StopContext as the parent of its context) using
+			// a copy of the Client, since the same Client is used for concurrent operations
+			tracedClient := *client
+			tracedClient.StopContext = ctx
+
+			err := f(d, &tracedClient)
+			span.SetAttribute("azurerm.resource_id", d.Id())
+			span.End(err)
+			return err
+		}
+	}
+
+	resource.Create = wrap(resource.Create, "create")
+	resource.Read = wrap(resource.Read, "read")
+	resource.Update = wrap(resource.Update, "update")
+	resource.Delete = wrap(resource.Delete, "delete")
+}
@@ -0,0 +1,175 @@
+package providercache
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EnvironmentVariable is the Environment Variable used to specify the directory used for the on-disk cache
+const EnvironmentVariable = "ARM_PROVIDER_CACHE_DIR"
+
+// Cache is an optional on-disk cache for metadata which the Provider looks up on every run (such as the available
+// Resource Providers and Locations), which persists across runs of Terraform - and is the only place the Provider
+// stores data on disk (for example the Management Locks managed by Terraform are also registered here). Each Cache is scoped to an Azure
+// Environment and Subscription, and entries expire once they're older than the TTL specified when they're retrieved.
+//
+// A nil Cache is valid, in which case nothing is cached - meaning that callers don't need to check if it's enabled.
+type Cache struct {
+	directory string
+	lock      sync.Mutex
+}
+
+type entry struct {
+	Key      string          `json:"key"`
+	CachedAt time.Time       `json:"cachedAt"`
+	Value    json.RawMessage `json:"value"`
+}
+
+var unsafeCharacters = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// New returns a Cache within `directory` scoped to the specified Environment and Subscription - or nil (meaning
+// that nothing is cached) if `directory` is empty
+func New(directory string, environment string, subscriptionId string) *Cache {
+	if directory == "" {
+		return nil
+	}
+
+	sanitize := func(input string) string {
+		return unsafeCharacters.ReplaceAllString(strings.ToLower(input), "_")
+	}
+
+	return &Cache{
+		directory: filepath.Join(directory, sanitize(environment), sanitize(subscriptionId)),
+	}
+}
+
+// Get unmarshals the entry `key` into `value`, returning whether it was found - entries older than `ttl` are removed
+func (c *Cache) Get(key string, ttl time.Duration, value interface{}) bool {
+	if c == nil {
+		return false
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	contents, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("[DEBUG] Unable to read the cached entry %q: %+v", key, err)
+		}
+		return false
+	}
+
+	var e entry
+	if err := json.Unmarshal(contents, &e); err != nil {
+		log.Printf("[DEBUG] Removing the cached entry %q since it couldn't be deserialized: %+v", key, err)
+		c.remove(key)
+		return false
+	}
+
+	// guard against the (unlikely) event of a hash collision
+	if e.Key != key {
+		return false
+	}
+
+	if time.Since(e.CachedAt) > ttl {
+		log.Printf("[DEBUG] Removing the cached entry %q since it was cached at %s", key, e.CachedAt.Format(time.RFC3339))
+		c.remove(key)
+		return false
+	}
+
+	if err := json.Unmarshal(e.Value, value); err != nil {
+		log.Printf("[DEBUG] Removing the cached entry %q since it couldn't be deserialized: %+v", key, err)
+		c.remove(key)
+		return false
+	}
+
+	return true
+}
+
+// Set caches `value` as the entry `key`, failures are logged rather than returned since caching is best-effort
+func (c *Cache) Set(key string, value interface{}) {
+	if c == nil {
+		return
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		log.Printf("[DEBUG] Unable to serialize the cached entry %q: %+v", key, err)
+		return
+	}
+	contents, err := json.Marshal(entry{
+		Key:      key,
+		CachedAt: time.Now(),
+		Value:    raw,
+	})
+	if err != nil {
+		log.Printf("[DEBUG] Unable to serialize the cached entry %q: %+v", key, err)
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.write(key, contents); err != nil {
+		log.Printf("[DEBUG] Unable to write the cached entry %q: %+v", key, err)
+	}
+}
+
+// Delete removes the entry `key`, for example when it's found to be out of date
+func (c *Cache) Delete(key string) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.remove(key)
+}
+
+func (c *Cache) write(key string, contents []byte) error {
+	if err := os.MkdirAll(c.directory, 0700); err != nil {
+		return fmt.Errorf("creating the directory %q: %+v", c.directory, err)
+	}
+
+	// write to a temporary file first so that a partially written file is never read, including by
+	// other instances of the Provider running at the same time
+	file, err := ioutil.TempFile(c.directory, "entry")
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(contents); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+
+	return os.Rename(file.Name(), c.path(key))
+}
+
+func (c *Cache) remove(key string) {
+	if err := os.Remove(c.path(key)); err != nil && !os.IsNotExist(err) {
+		log.Printf("[DEBUG] Unable to remove the cached entry %q: %+v", key, err)
+	}
+}
+
+// path returns the path to the file for the entry, since keys can contain characters which aren't valid
+// within a file name the file is named using a hash of the key
+func (c *Cache) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(c.directory, fmt.Sprintf("%x.json", hash))
+}
@@ -0,0 +1,97 @@
+package providercache
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCacheDisabled(t *testing.T) {
+	cache := New("", "public", "11111111-1111-1111-1111-111111111111")
+	if cache != nil {
+		t.Fatalf("expected the cache to be nil when no directory is specified")
+	}
+
+	// a nil Cache is valid
+	cache.Set("example", []string{"hello"})
+	var value []string
+	if cache.Get("example", time.Hour, &value) {
+		t.Fatalf("expected a nil cache to return nothing")
+	}
+	cache.Delete("example")
+}
+
+func TestCacheGetSetDelete(t *testing.T) {
+	cache := New(t.TempDir(), "public", "11111111-1111-1111-1111-111111111111")
+
+	var value []string
+	if cache.Get("example", time.Hour, &value) {
+		t.Fatalf("expected no entry before it's been set")
+	}
+
+	expected := []string{"Microsoft.Compute", "Microsoft.Network"}
+	cache.Set("example", expected)
+	if !cache.Get("example", time.Hour, &value) {
+		t.Fatalf("expected the entry to be found")
+	}
+	if !reflect.DeepEqual(value, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, value)
+	}
+
+	cache.Delete("example")
+	if cache.Get("example", time.Hour, &value) {
+		t.Fatalf("expected the entry to have been deleted")
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	cache := New(t.TempDir(), "public", "11111111-1111-1111-1111-111111111111")
+	cache.Set("example", "hello")
+
+	var value string
+	if cache.Get("example", 0, &value) {
+		t.Fatalf("expected the entry to have expired")
+	}
+
+	// and expired entries are removed
+	if cache.Get("example", time.Hour, &value) {
+		t.Fatalf("expected the expired entry to have been removed")
+	}
+}
+
+func TestCacheScopedToEnvironmentAndSubscription(t *testing.T) {
+	directory := t.TempDir()
+	New(directory, "public", "11111111-1111-1111-1111-111111111111").Set("example", "hello")
+
+	var value string
+	for _, cache := range []*Cache{
+		New(directory, "china", "11111111-1111-1111-1111-111111111111"),
+		New(directory, "public", "22222222-2222-2222-2222-222222222222"),
+	} {
+		if cache.Get("example", time.Hour, &value) {
+			t.Fatalf("expected the entry not to be shared between Environments and Subscriptions")
+		}
+	}
+
+	if !New(directory, "PUBLIC", "11111111-1111-1111-1111-111111111111").Get("example", time.Hour, &value) || value != "hello" {
+		t.Fatalf("expected the entry to be found for the same Environment and Subscription")
+	}
+}
+
+func TestCacheInvalidEntry(t *testing.T) {
+	cache := New(t.TempDir(), "public", "11111111-1111-1111-1111-111111111111")
+	cache.Set("example", "hello")
+	if err := ioutil.WriteFile(cache.path("example"), []byte("{not-json"), 0600); err != nil {
+		t.Fatalf("writing invalid entry: %+v", err)
+	}
+
+	var value string
+	if cache.Get("example", time.Hour, &value) {
+		t.Fatalf("expected an invalid entry not to be found")
+	}
+	if matches, _ := filepath.Glob(filepath.Join(cache.directory, "*.json")); len(matches) != 0 {
+		t.Fatalf("expected the invalid entry to have been removed but got %+v", matches)
+	}
+}
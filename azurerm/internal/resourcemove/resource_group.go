@@ -0,0 +1,115 @@
+package resourcemove
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/locks"
+	resourceParse "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/resource/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// SchemaResourceGroupName returns the Schema for the `resource_group_name` field of a Resource which can be moved
+// between Resource Groups - this must be used in conjunction with CustomizeDiff (which forces a new resource to be
+// created unless the `move_resources_on_group_change` feature is enabled) and MoveIfResourceGroupChanged.
+func SchemaResourceGroupName() *schema.Schema {
+	s := azure.SchemaResourceGroupName()
+	s.ForceNew = false
+	return s
+}
+
+// CustomizeDiff forces a new resource to be created when the `resource_group_name` field changes, unless the
+// `move_resources_on_group_change` feature is enabled - in which case the Resource is moved during the Update
+func CustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("resource_group_name") {
+		return nil
+	}
+
+	if client, ok := meta.(*clients.Client); ok && client.Features.ResourceGroup.MoveResourcesOnGroupChange {
+		return nil
+	}
+
+	return d.ForceNew("resource_group_name")
+}
+
+// MoveIfResourceGroupChanged moves the Resource into the Resource Group specified in the `resource_group_name` field
+// when this has changed, updating the ID of the Resource to reflect the new Resource Group
+func MoveIfResourceGroupChanged(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	if !d.HasChange("resource_group_name") {
+		return nil
+	}
+
+	sourceId, err := azure.ParseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	targetResourceGroup := d.Get("resource_group_name").(string)
+
+	// a previous apply may have moved the Resource but failed to update it afterwards
+	if strings.EqualFold(sourceId.ResourceGroup, targetResourceGroup) {
+		return nil
+	}
+
+	targetResourceGroupId := resourceParse.NewResourceGroupID(sourceId.SubscriptionID, targetResourceGroup)
+	targetId, err := withResourceGroup(d.Id(), targetResourceGroup)
+	if err != nil {
+		return err
+	}
+
+	// the Resource could be within a different Subscription to the one configured for the Provider
+	client := *meta.(*clients.Client).Resource.ResourcesClient
+	client.SubscriptionID = sourceId.SubscriptionID
+
+	// both Resource Groups are locked by Azure for the duration of the move, so only one move can happen at once - these
+	// are sorted to ensure concurrent moves in opposite directions acquire the locks in the same order
+	sourceResourceGroupId := resourceParse.NewResourceGroupID(sourceId.SubscriptionID, sourceId.ResourceGroup)
+	resourceGroupIds := []string{strings.ToLower(sourceResourceGroupId.ID()), strings.ToLower(targetResourceGroupId.ID())}
+	sort.Strings(resourceGroupIds)
+	locks.MultipleByName(&resourceGroupIds, "azurerm_resource_group")
+	defer locks.UnlockMultipleByName(&resourceGroupIds, "azurerm_resource_group")
+
+	moveInfo := resources.MoveInfo{
+		ResourcesProperty:   &[]string{d.Id()},
+		TargetResourceGroup: utils.String(targetResourceGroupId.ID()),
+	}
+
+	log.Printf("[DEBUG] Validating the move of %q into %s..", d.Id(), targetResourceGroupId)
+	validateFuture, err := client.ValidateMoveResources(ctx, sourceId.ResourceGroup, moveInfo)
+	if err != nil {
+		return fmt.Errorf("validating the move of %q into %s: %+v", d.Id(), targetResourceGroupId, err)
+	}
+	if err := validateFuture.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("validating the move of %q into %s (this Resource may need to be moved alongside the Resources it depends on, which isn't supported): %+v", d.Id(), targetResourceGroupId, err)
+	}
+
+	log.Printf("[DEBUG] Moving %q into %s..", d.Id(), targetResourceGroupId)
+	moveFuture, err := client.MoveResources(ctx, sourceId.ResourceGroup, moveInfo)
+	if err != nil {
+		return fmt.Errorf("moving %q into %s: %+v", d.Id(), targetResourceGroupId, err)
+	}
+	if err := moveFuture.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for the move of %q into %s: %+v", d.Id(), targetResourceGroupId, err)
+	}
+	log.Printf("[DEBUG] Moved %q to %q", d.Id(), targetId)
+
+	d.SetId(targetId)
+	return nil
+}
+
+// withResourceGroup returns the Resource ID `id` with the Resource Group replaced by `resourceGroup`
+func withResourceGroup(id string, resourceGroup string) (string, error) {
+	segments := strings.Split(id, "/")
+	if len(segments) < 5 || segments[0] != "" || !strings.EqualFold(segments[1], "subscriptions") || !strings.EqualFold(segments[3], "resourceGroups") {
+		return "", fmt.Errorf("expected %q to be a Resource ID within a Resource Group", id)
+	}
+
+	segments[4] = resourceGroup
+	return strings.Join(segments, "/"), nil
+}
@@ -0,0 +1,86 @@
+package resourcemove_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/fakearm"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/resourcemove"
+)
+
+func TestMoveIfResourceGroupChanged(t *testing.T) {
+	server := fakearm.NewServer()
+	sourceGroupId := fmt.Sprintf("/subscriptions/%s/resourceGroups/source", fakearm.SubscriptionId)
+	targetGroupId := fmt.Sprintf("/subscriptions/%s/resourceGroups/target", fakearm.SubscriptionId)
+	server.SetResource(sourceGroupId, map[string]interface{}{"location": "westeurope"})
+	server.SetResource(targetGroupId, map[string]interface{}{"location": "westeurope"})
+
+	sourceId := fmt.Sprintf("%s/providers/Microsoft.Network/virtualNetworks/network1", sourceGroupId)
+	server.SetResource(sourceId, map[string]interface{}{"location": "westeurope"})
+	server.SetResource(sourceId+"/subnets/subnet1", map[string]interface{}{})
+
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+
+	resourceSchema := map[string]*schema.Schema{
+		"name":                {Type: schema.TypeString, Required: true, ForceNew: true},
+		"resource_group_name": resourcemove.SchemaResourceGroupName(),
+	}
+	d := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{
+		"name":                "network1",
+		"resource_group_name": "target",
+	})
+	d.SetId(sourceId)
+
+	if err := resourcemove.MoveIfResourceGroupChanged(client.StopContext, d, client); err != nil {
+		t.Fatalf("moving: %+v", err)
+	}
+
+	expectedId := fmt.Sprintf("%s/providers/Microsoft.Network/virtualNetworks/network1", targetGroupId)
+	if d.Id() != expectedId {
+		t.Fatalf("expected the ID to be %q but got %q", expectedId, d.Id())
+	}
+	if _, exists := server.Resource(sourceId); exists {
+		t.Fatalf("expected %q to have been moved", sourceId)
+	}
+	for _, id := range []string{expectedId, expectedId + "/subnets/subnet1"} {
+		if _, exists := server.Resource(id); !exists {
+			t.Fatalf("expected %q to exist after the move", id)
+		}
+	}
+}
+
+func TestMoveIfResourceGroupChangedTargetMissing(t *testing.T) {
+	server := fakearm.NewServer()
+	sourceGroupId := fmt.Sprintf("/subscriptions/%s/resourceGroups/source", fakearm.SubscriptionId)
+	server.SetResource(sourceGroupId, map[string]interface{}{"location": "westeurope"})
+
+	sourceId := fmt.Sprintf("%s/providers/Microsoft.Network/virtualNetworks/network1", sourceGroupId)
+	server.SetResource(sourceId, map[string]interface{}{"location": "westeurope"})
+
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+
+	resourceSchema := map[string]*schema.Schema{
+		"resource_group_name": resourcemove.SchemaResourceGroupName(),
+	}
+	d := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{
+		"resource_group_name": "target",
+	})
+	d.SetId(sourceId)
+
+	if err := resourcemove.MoveIfResourceGroupChanged(client.StopContext, d, client); err == nil {
+		t.Fatalf("expected an error when the target Resource Group doesn't exist")
+	}
+	if d.Id() != sourceId {
+		t.Fatalf("expected the ID to be unchanged but got %q", d.Id())
+	}
+	if _, exists := server.Resource(sourceId); !exists {
+		t.Fatalf("expected %q not to have been moved", sourceId)
+	}
+}
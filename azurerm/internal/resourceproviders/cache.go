@@ -3,21 +3,81 @@ package resourceproviders
 import (
 	"context"
 	"log"
+	"sync"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2017-03-09/resources/mgmt/resources"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/providercache"
+)
+
+const (
+	providerCacheKey = "resource-providers"
+	providerCacheTTL = 24 * time.Hour
 )
 
 // cachedResourceProviders can be (validly) nil - as such this shouldn't be relied on
 var cachedResourceProviders *[]string
 
+// refreshResourceProviders is set when the Resource Providers were loaded from the on-disk cache (which could be
+// out of date), allowing these to be retrieved from the API once should a Resource Provider not be found
+var (
+	refreshResourceProviders     func() (*[]string, error)
+	refreshResourceProvidersLock = sync.Mutex{}
+)
+
 // CacheSupportedProviders attempts to retrieve the supported Resource Providers from the Resource Manager API
-// and caches them, for used in enhanced validation
-func CacheSupportedProviders(ctx context.Context, client *resources.ProvidersClient) {
-	providers, err := availableResourceProviders(ctx, client)
+// (or the on-disk cache, when enabled) and caches them, for used in enhanced validation
+func CacheSupportedProviders(ctx context.Context, client *resources.ProvidersClient, cache *providercache.Cache) {
+	refresh := func() (*[]string, error) {
+		providers, err := availableResourceProviders(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+
+		cache.Set(providerCacheKey, *providers)
+		return providers, nil
+	}
+
+	var providers []string
+	if cache.Get(providerCacheKey, providerCacheTTL, &providers) {
+		log.Printf("[DEBUG] Loaded %d Resource Providers from the on-disk cache", len(providers))
+		refreshResourceProvidersLock.Lock()
+		refreshResourceProviders = refresh
+		refreshResourceProvidersLock.Unlock()
+
+		cachedResourceProviders = &providers
+		return
+	}
+
+	retrieved, err := refresh()
 	if err != nil {
 		log.Printf("[DEBUG] error retrieving providers: %s. Enhanced validation will be unavailable", err)
 		return
 	}
 
+	cachedResourceProviders = retrieved
+}
+
+// refreshCachedProviders retrieves the supported Resource Providers from the API if these were loaded from the
+// on-disk cache, returning whether these were refreshed
+func refreshCachedProviders() bool {
+	refreshResourceProvidersLock.Lock()
+	defer refreshResourceProvidersLock.Unlock()
+
+	if refreshResourceProviders == nil {
+		return false
+	}
+
+	refresh := refreshResourceProviders
+	refreshResourceProviders = nil
+
+	log.Printf("[DEBUG] Cache Miss - refreshing the Resource Providers loaded from the on-disk cache..")
+	providers, err := refresh()
+	if err != nil {
+		log.Printf("[DEBUG] error refreshing providers: %s", err)
+		return false
+	}
+
 	cachedResourceProviders = providers
+	return true
 }
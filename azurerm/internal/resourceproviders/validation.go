@@ -39,11 +39,11 @@ func enhancedValidation(i interface{}, k string) ([]string, []error) {
 		return nil, nil
 	}
 
-	found := false
-	for _, provider := range *cachedResourceProviders {
-		if provider == v {
-			found = true
-		}
+	found := isCachedProvider(v)
+
+	// the Resource Providers loaded from the on-disk cache could be out of date
+	if !found && refreshCachedProviders() {
+		found = isCachedProvider(v)
 	}
 
 	if !found {
@@ -55,3 +55,13 @@ func enhancedValidation(i interface{}, k string) ([]string, []error) {
 
 	return nil, nil
 }
+
+func isCachedProvider(name string) bool {
+	for _, provider := range *cachedResourceProviders {
+		if provider == name {
+			return true
+		}
+	}
+
+	return false
+}
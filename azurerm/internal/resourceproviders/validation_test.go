@@ -84,3 +84,31 @@ func TestEnhancedValidationEnabled(t *testing.T) {
 		}
 	}
 }
+
+func TestEnhancedValidationRefreshesOnMiss(t *testing.T) {
+	enhancedEnabled = true
+	cachedResourceProviders = &[]string{"Microsoft.Compute"}
+	refreshes := 0
+	refreshResourceProviders = func() (*[]string, error) {
+		refreshes++
+		return &[]string{"Microsoft.Compute", "Microsoft.Example"}, nil
+	}
+	defer func() {
+		enhancedEnabled = features.EnhancedValidationEnabled()
+		cachedResourceProviders = nil
+		refreshResourceProviders = nil
+	}()
+
+	for _, input := range []string{"Microsoft.Compute", "Microsoft.Example", "Microsoft.Example"} {
+		if _, errors := EnhancedValidate(input, "name"); len(errors) > 0 {
+			t.Fatalf("expected %q to be valid but got %+v", input, errors)
+		}
+	}
+	if _, errors := EnhancedValidate("Microsoft.Missing", "name"); len(errors) == 0 {
+		t.Fatalf("expected %q to be invalid", "Microsoft.Missing")
+	}
+
+	if refreshes != 1 {
+		t.Fatalf("expected the Resource Providers to be refreshed once but got %d", refreshes)
+	}
+}
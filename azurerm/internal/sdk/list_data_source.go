@@ -0,0 +1,139 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// A ListDataSource is a Data Source which looks up a list of items, for example all of the
+// Public IP Addresses within a Resource Group.
+//
+// The wrapper for this exposes a uniform set of filters (`filter`, `tags`, `name_regex` and
+// `max_results`) and returns the matching items, sorted by their ID, alongside a list of `ids`,
+// as such implementations only need to retrieve each page of items.
+type ListDataSource interface {
+	// Arguments is a list of additional user-configurable arguments used to retrieve
+	// the items (for example, the Resource Group Name) - which can be retrieved using
+	// the ResourceData within the ResourceMetaData
+	Arguments() map[string]*schema.Schema
+
+	// ModelObject is an instance of the object each item is encoded into - which must
+	// contain `id` and `name` fields and can optionally contain a `tags` field
+	ModelObject() interface{}
+
+	// ResourceType is the exposed name of this Data Source (e.g. `azurerm_example`)
+	ResourceType() string
+
+	// ItemsAttribute is the name of the attribute containing the matching items (e.g. `public_ips`)
+	ItemsAttribute() string
+
+	// ListPages retrieves each page of items, calling yield with the items in each page
+	// (as instances of the ModelObject). The filters can optionally be used to filter
+	// the items server-side, however these are also applied client-side by the wrapper
+	ListPages(ctx context.Context, metadata ResourceMetaData, filters ListFilters, yield ListPageFunc) error
+
+	// ReadTimeout is the default timeout for retrieving the items
+	ReadTimeout() time.Duration
+}
+
+// ListPageFunc is called for each page of items returned from a ListDataSource
+type ListPageFunc func(items []interface{}) error
+
+// ListFilters are the filters specified by the user for a ListDataSource
+type ListFilters struct {
+	// Filters is a list of filters which must all match an item - where an item matches a
+	// filter when the field `Name` matches (case-insensitively) any of the `Values`
+	Filters []ListFilter
+
+	// Tags is a list of Tags which must all be present (with the same value) on an item
+	Tags map[string]string
+
+	// NameRegex is a regular expression which must match the name of an item - or nil if unspecified
+	NameRegex *regexp.Regexp
+
+	// MaxResults is the maximum number of items which should be returned - or 0 if unlimited
+	MaxResults int
+}
+
+// ListFilter is a single `filter` block defined for a ListDataSource
+type ListFilter struct {
+	Name   string
+	Values []string
+}
+
+// Matches returns whether the specified (encoded) item matches all of the filters
+func (f ListFilters) Matches(item map[string]interface{}) (bool, error) {
+	if f.NameRegex != nil {
+		name, _ := item["name"].(string)
+		if !f.NameRegex.MatchString(name) {
+			return false, nil
+		}
+	}
+
+	if len(f.Tags) > 0 {
+		tags, _ := item["tags"].(map[string]interface{})
+		for k, v := range f.Tags {
+			existing, ok := tags[k]
+			if !ok || fmt.Sprintf("%v", existing) != v {
+				return false, nil
+			}
+		}
+	}
+
+	for _, filter := range f.Filters {
+		value, exists := item[filter.Name]
+		if !exists {
+			return false, fmt.Errorf("`filter` %q is not supported - this must be the name of a field within each item", filter.Name)
+		}
+
+		matches, err := filter.matches(value)
+		if err != nil {
+			return false, err
+		}
+		if !matches {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (f ListFilter) matches(value interface{}) (bool, error) {
+	candidates := make([]string, 0)
+	switch v := value.(type) {
+	case nil:
+	case string, bool, int, int64, float64:
+		candidates = append(candidates, fmt.Sprintf("%v", v))
+	case []string:
+		candidates = append(candidates, v...)
+	case []int:
+		for _, item := range v {
+			candidates = append(candidates, fmt.Sprintf("%d", item))
+		}
+	case []float64:
+		for _, item := range v {
+			candidates = append(candidates, fmt.Sprintf("%v", item))
+		}
+	case []bool:
+		for _, item := range v {
+			candidates = append(candidates, fmt.Sprintf("%t", item))
+		}
+	default:
+		return false, fmt.Errorf("`filter` %q is not supported - only fields containing a value or a list of values can be filtered on", f.Name)
+	}
+
+	for _, candidate := range candidates {
+		for _, expected := range f.Values {
+			if strings.EqualFold(candidate, expected) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
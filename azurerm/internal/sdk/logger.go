@@ -2,6 +2,13 @@ package sdk
 
 // Logger is an interface for switching out the Logger implementation
 type Logger interface {
+	// Debug prints out a message prefixed with `[DEBUG]` verbatim
+	Debug(message string)
+
+	// Debugf prints out a message prefixed with `[DEBUG]` formatted
+	// with the specified arguments
+	Debugf(format string, args ...interface{})
+
 	// Info prints out a message prefixed with `[INFO]` verbatim
 	Info(message string)
 
@@ -15,4 +22,15 @@ type Logger interface {
 	// Warnf prints out a message prefixed with `[WARN]` formatted
 	// with the specified arguments
 	Warnf(format string, args ...interface{})
+
+	// Error prints out a message prefixed with `[ERROR]` verbatim
+	Error(message string)
+
+	// Errorf prints out a message prefixed with `[ERROR]` formatted
+	// with the specified arguments
+	Errorf(format string, args ...interface{})
+
+	// WithFields returns a Logger which includes the specified key-value pairs
+	// (e.g. `"resource_type", "azurerm_example"`) in each message
+	WithFields(keysAndValues ...interface{}) Logger
 }
@@ -1,18 +1,44 @@
 package sdk
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"os"
+	"strings"
+	"time"
 )
 
+// LogFormatEnvironmentVariable is the Environment Variable which can be set to `json` to output
+// structured log messages as JSON (prefixed with the log level, so that these are still filtered
+// by Terraform according to `TF_LOG`) rather than as text
+const LogFormatEnvironmentVariable = "ARM_PROVIDER_LOG_FORMAT"
+
 // ConsoleLogger provides a Logger implementation which writes the log messages
 // to StdOut - in Terraform's perspective that's proxied via the Plugin SDK
 type ConsoleLogger struct {
+	// fields is a list of key-value pairs which are included in each message
+	fields []interface{}
+}
+
+// LazyLogValue is a log field value which is evaluated each time a message is written, for
+// example to include the Resource ID which may not be known when the Logger is created
+type LazyLogValue func() interface{}
+
+// Debug prints out a message prefixed with `[DEBUG]` verbatim
+func (l ConsoleLogger) Debug(message string) {
+	l.write("DEBUG", message)
+}
+
+// Debugf prints out a message prefixed with `[DEBUG]` formatted
+// with the specified arguments
+func (l ConsoleLogger) Debugf(format string, args ...interface{}) {
+	l.Debug(fmt.Sprintf(format, args...))
 }
 
 // Info prints out a message prefixed with `[INFO]` verbatim
-func (ConsoleLogger) Info(message string) {
-	log.Print(fmt.Sprintf("[INFO] %s", message))
+func (l ConsoleLogger) Info(message string) {
+	l.write("INFO", message)
 }
 
 // Infof prints out a message prefixed with `[INFO]` formatted
@@ -23,7 +49,7 @@ func (l ConsoleLogger) Infof(format string, args ...interface{}) {
 
 // Warn prints out a message prefixed with `[WARN]` formatted verbatim
 func (l ConsoleLogger) Warn(message string) {
-	log.Print(fmt.Sprintf("[WARN] %s", message))
+	l.write("WARN", message)
 }
 
 // Warnf prints out a message prefixed with `[WARN]` formatted
@@ -31,3 +57,73 @@ func (l ConsoleLogger) Warn(message string) {
 func (l ConsoleLogger) Warnf(format string, args ...interface{}) {
 	l.Warn(fmt.Sprintf(format, args...))
 }
+
+// Error prints out a message prefixed with `[ERROR]` verbatim
+func (l ConsoleLogger) Error(message string) {
+	l.write("ERROR", message)
+}
+
+// Errorf prints out a message prefixed with `[ERROR]` formatted
+// with the specified arguments
+func (l ConsoleLogger) Errorf(format string, args ...interface{}) {
+	l.Error(fmt.Sprintf(format, args...))
+}
+
+// WithFields returns a ConsoleLogger which includes the specified key-value pairs in each message
+// in addition to any fields already defined on this ConsoleLogger
+func (l ConsoleLogger) WithFields(keysAndValues ...interface{}) Logger {
+	fields := make([]interface{}, 0, len(l.fields)+len(keysAndValues))
+	fields = append(fields, l.fields...)
+	fields = append(fields, keysAndValues...)
+
+	// a key without a value would otherwise shift the remaining pairs
+	if len(fields)%2 != 0 {
+		fields = append(fields, nil)
+	}
+
+	return ConsoleLogger{
+		fields: fields,
+	}
+}
+
+func (l ConsoleLogger) write(level, message string) {
+	log.Print(l.format(level, message, strings.EqualFold(os.Getenv(LogFormatEnvironmentVariable), "json")))
+}
+
+func (l ConsoleLogger) format(level, message string, asJson bool) string {
+	if asJson {
+		entry := map[string]interface{}{
+			"@level":     strings.ToLower(level),
+			"@message":   message,
+			"@timestamp": time.Now().UTC().Format(time.RFC3339Nano),
+		}
+		for i := 0; i+1 < len(l.fields); i += 2 {
+			entry[fmt.Sprintf("%v", l.fields[i])] = logValue(l.fields[i+1])
+		}
+
+		out, err := json.Marshal(entry)
+		if err == nil {
+			return fmt.Sprintf("[%s] %s", level, string(out))
+		}
+
+		// fall back to the text output rather than losing the message
+	}
+
+	output := fmt.Sprintf("[%s] %s", level, message)
+	for i := 0; i+1 < len(l.fields); i += 2 {
+		output += fmt.Sprintf(" %v=%q", l.fields[i], fmt.Sprintf("%v", logValue(l.fields[i+1])))
+	}
+	return output
+}
+
+func logValue(input interface{}) interface{} {
+	if v, ok := input.(LazyLogValue); ok {
+		return v()
+	}
+
+	if v, ok := input.(error); ok {
+		return v.Error()
+	}
+
+	return input
+}
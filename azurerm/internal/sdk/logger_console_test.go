@@ -0,0 +1,93 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestConsoleLoggerFormatText(t *testing.T) {
+	id := ""
+	logger := ConsoleLogger{}.WithFields("resource_type", "azurerm_example", "resource_id", LazyLogValue(func() interface{} {
+		return id
+	})).(ConsoleLogger)
+
+	testData := []struct {
+		Name     string
+		Logger   ConsoleLogger
+		Id       string
+		Expected string
+	}{
+		{
+			Name:     "No Fields",
+			Logger:   ConsoleLogger{},
+			Expected: "[INFO] hello world",
+		},
+		{
+			Name:     "Fields",
+			Logger:   logger,
+			Expected: `[INFO] hello world resource_type="azurerm_example" resource_id=""`,
+		},
+		{
+			Name:     "Lazy Fields",
+			Logger:   logger,
+			Id:       "/subscriptions/1234",
+			Expected: `[INFO] hello world resource_type="azurerm_example" resource_id="/subscriptions/1234"`,
+		},
+		{
+			Name:     "Nested Fields",
+			Logger:   logger.WithFields("operation", "create").(ConsoleLogger),
+			Expected: `[INFO] hello world resource_type="azurerm_example" resource_id="" operation="create"`,
+		},
+		{
+			Name:     "Missing Value",
+			Logger:   ConsoleLogger{}.WithFields("operation").(ConsoleLogger),
+			Expected: `[INFO] hello world operation="<nil>"`,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		id = v.Id
+		actual := v.Logger.format("INFO", "hello world", false)
+		if actual != v.Expected {
+			t.Fatalf("Expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestConsoleLoggerFormatJson(t *testing.T) {
+	logger := ConsoleLogger{}.WithFields(
+		"resource_type", "azurerm_example",
+		"operation", "read",
+		"error", fmt.Errorf("bad things"),
+	).(ConsoleLogger)
+
+	actual := logger.format("DEBUG", "hello world", true)
+	if !strings.HasPrefix(actual, "[DEBUG] ") {
+		t.Fatalf("expected the message to be prefixed with the log level but got %q", actual)
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(actual, "[DEBUG] ")), &entry); err != nil {
+		t.Fatalf("parsing %q: %+v", actual, err)
+	}
+
+	expected := map[string]interface{}{
+		"@level":        "debug",
+		"@message":      "hello world",
+		"resource_type": "azurerm_example",
+		"operation":     "read",
+		"error":         "bad things",
+	}
+	for k, v := range expected {
+		if entry[k] != v {
+			t.Fatalf("expected %q to be %q but got %q", k, v, entry[k])
+		}
+	}
+	if _, ok := entry["@timestamp"]; !ok {
+		t.Fatalf("expected the message to contain a timestamp")
+	}
+}
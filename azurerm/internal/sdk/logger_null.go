@@ -6,6 +6,15 @@ package sdk
 type NullLogger struct {
 }
 
+// Debug prints out a message prefixed with `[DEBUG]` verbatim
+func (NullLogger) Debug(_ string) {
+}
+
+// Debugf prints out a message prefixed with `[DEBUG]` formatted
+// with the specified arguments
+func (NullLogger) Debugf(_ string, _ ...interface{}) {
+}
+
 // Info prints out a message prefixed with `[INFO]` verbatim
 func (NullLogger) Info(_ string) {
 }
@@ -23,3 +32,17 @@ func (NullLogger) Warn(_ string) {
 // with the specified arguments
 func (NullLogger) Warnf(_ string, _ ...interface{}) {
 }
+
+// Error prints out a message prefixed with `[ERROR]` verbatim
+func (NullLogger) Error(_ string) {
+}
+
+// Errorf prints out a message prefixed with `[ERROR]` formatted
+// with the specified arguments
+func (NullLogger) Errorf(_ string, _ ...interface{}) {
+}
+
+// WithFields returns this NullLogger, since the output is disregarded
+func (l NullLogger) WithFields(_ ...interface{}) Logger {
+	return l
+}
@@ -0,0 +1,443 @@
+package sdk
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// structTag is the parsed representation of a `tfschema` struct tag, for example
+// `tfschema:"name,required,forcenew"`
+type structTag struct {
+	// Name is the name of the field within the Terraform Schema
+	Name string
+
+	Required  bool
+	Optional  bool
+	Computed  bool
+	ForceNew  bool
+	Sensitive bool
+
+	// Set specifies that a slice should be represented as a TypeSet rather than a TypeList
+	Set bool
+}
+
+// hasBehaviour returns whether the struct tag specifies if the field is Required, Optional and/or Computed
+func (t structTag) hasBehaviour() bool {
+	return t.Required || t.Optional || t.Computed
+}
+
+// parseStructTag parses the `tfschema` struct tag for the specified field, returning
+// false if the field doesn't contain a `tfschema` struct tag
+func parseStructTag(field reflect.StructField) (*structTag, bool, error) {
+	raw, exists := field.Tag.Lookup("tfschema")
+	if !exists {
+		return nil, false, nil
+	}
+
+	segments := strings.Split(raw, ",")
+	tag := structTag{
+		Name: segments[0],
+	}
+	if tag.Name == "" {
+		return nil, true, fmt.Errorf("field %q has an empty `tfschema` label", field.Name)
+	}
+
+	for _, option := range segments[1:] {
+		switch strings.TrimSpace(option) {
+		case "required":
+			tag.Required = true
+		case "optional":
+			tag.Optional = true
+		case "computed":
+			tag.Computed = true
+		case "forcenew":
+			tag.ForceNew = true
+		case "sensitive":
+			tag.Sensitive = true
+		case "set":
+			tag.Set = true
+		default:
+			return nil, true, fmt.Errorf("field %q has an unsupported `tfschema` option %q", field.Name, option)
+		}
+	}
+
+	return &tag, true, nil
+}
+
+// tfschemaName returns the name of the field within the Terraform Schema, ignoring any options
+func tfschemaName(field reflect.StructField) (string, bool) {
+	raw, exists := field.Tag.Lookup("tfschema")
+	if !exists {
+		return "", false
+	}
+
+	return strings.Split(raw, ",")[0], true
+}
+
+// SchemaFromModel generates the Arguments and Attributes for a Resource from the `tfschema`
+// struct tags on the specified model - where each field must specify whether it's `required`,
+// `optional` and/or `computed` (fields which are only `computed` are returned as Attributes).
+//
+// The validators are keyed by the path to the field within the model (e.g. `rule.port`) and,
+// for Lists/Sets of primitive types, apply to each element within the List/Set.
+func SchemaFromModel(model interface{}, validators map[string]schema.SchemaValidateFunc) (arguments map[string]*schema.Schema, attributes map[string]*schema.Schema, err error) {
+	modelType := reflect.TypeOf(model)
+	if modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	if modelType.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("expected the model to be a struct but got %s", modelType.Kind())
+	}
+
+	unused := make(map[string]struct{}, len(validators))
+	for k := range validators {
+		unused[k] = struct{}{}
+	}
+
+	fields, err := schemaForStruct("", modelType, false, validators, unused)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(unused) > 0 {
+		keys := make([]string, 0, len(unused))
+		for k := range unused {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return nil, nil, fmt.Errorf("validators were defined for fields which don't exist in the model: %s", strings.Join(keys, ", "))
+	}
+
+	arguments = make(map[string]*schema.Schema)
+	attributes = make(map[string]*schema.Schema)
+	for k, v := range fields {
+		if v.Computed && !v.Optional && !v.Required {
+			attributes[k] = v
+			continue
+		}
+
+		arguments[k] = v
+	}
+
+	return arguments, attributes, nil
+}
+
+// schemaForStruct generates the Schema for the specified struct - when computedOnly is true each field is
+// marked as Computed (and any Required/Optional/Computed/ForceNew options in the struct tags are ignored)
+func schemaForStruct(prefix string, objType reflect.Type, computedOnly bool, validators map[string]schema.SchemaValidateFunc, unused map[string]struct{}) (map[string]*schema.Schema, error) {
+	out := make(map[string]*schema.Schema)
+	for i := 0; i < objType.NumField(); i++ {
+		field := objType.Field(i)
+		tag, exists, err := parseStructTag(field)
+		if err != nil {
+			return nil, err
+		}
+
+		fieldName := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, field.Name), ".")
+		if !exists {
+			return nil, fmt.Errorf("field %q is missing an `tfschema` label", fieldName)
+		}
+		if !computedOnly && !tag.hasBehaviour() {
+			return nil, fmt.Errorf("field %q must be marked as `required`, `optional` and/or `computed`", fieldName)
+		}
+
+		path := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, tag.Name), ".")
+		if _, alreadyExists := out[tag.Name]; alreadyExists {
+			return nil, fmt.Errorf("%q is defined multiple times in the model", path)
+		}
+
+		s, err := schemaForField(path, field.Type, *tag, computedOnly, validators, unused)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %+v", fieldName, err)
+		}
+		s.Sensitive = tag.Sensitive
+		if computedOnly {
+			s.Computed = true
+			out[tag.Name] = s
+			continue
+		}
+
+		s.Required = tag.Required
+		s.Optional = tag.Optional
+		s.Computed = tag.Computed
+		s.ForceNew = tag.ForceNew
+
+		out[tag.Name] = s
+	}
+
+	return out, nil
+}
+
+func schemaForField(path string, fieldType reflect.Type, tag structTag, computedOnly bool, validators map[string]schema.SchemaValidateFunc, unused map[string]struct{}) (*schema.Schema, error) {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	validateFunc, hasValidator := validators[path]
+	delete(unused, path)
+
+	if valueType, ok := primitiveValueType(fieldType); ok {
+		return &schema.Schema{
+			Type:         valueType,
+			ValidateFunc: validateFunc,
+		}, nil
+	}
+
+	collectionType := schema.TypeList
+	if tag.Set {
+		collectionType = schema.TypeSet
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Struct:
+		nested, err := schemaForStruct(path, fieldType, computedOnly, validators, unused)
+		if err != nil {
+			return nil, err
+		}
+		if hasValidator {
+			return nil, fmt.Errorf("validators are not supported for nested blocks")
+		}
+
+		return &schema.Schema{
+			Type:     collectionType,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: nested,
+			},
+		}, nil
+
+	case reflect.Slice:
+		elemType := fieldType.Elem()
+		if elemType.Kind() == reflect.Ptr {
+			elemType = elemType.Elem()
+		}
+
+		if valueType, ok := primitiveValueType(elemType); ok {
+			return &schema.Schema{
+				Type: collectionType,
+				Elem: &schema.Schema{
+					Type:         valueType,
+					ValidateFunc: validateFunc,
+				},
+			}, nil
+		}
+
+		if elemType.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("unsupported slice type %s", fieldType)
+		}
+		if hasValidator {
+			return nil, fmt.Errorf("validators are not supported for nested blocks")
+		}
+
+		nested, err := schemaForStruct(path, elemType, computedOnly, validators, unused)
+		if err != nil {
+			return nil, err
+		}
+		return &schema.Schema{
+			Type: collectionType,
+			Elem: &schema.Resource{
+				Schema: nested,
+			},
+		}, nil
+
+	case reflect.Map:
+		elemType := fieldType.Elem()
+		if elemType.Kind() == reflect.Ptr {
+			elemType = elemType.Elem()
+		}
+
+		valueType, ok := primitiveValueType(elemType)
+		if !ok || fieldType.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map type %s", fieldType)
+		}
+
+		return &schema.Schema{
+			Type:         schema.TypeMap,
+			ValidateFunc: validateFunc,
+			Elem: &schema.Schema{
+				Type: valueType,
+			},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", fieldType)
+}
+
+// primitiveValueType returns the Terraform Schema type for the specified primitive Go type
+func primitiveValueType(input reflect.Type) (schema.ValueType, bool) {
+	switch input.Kind() {
+	case reflect.String:
+		return schema.TypeString, true
+	case reflect.Bool:
+		return schema.TypeBool, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return schema.TypeInt, true
+	case reflect.Float32, reflect.Float64:
+		return schema.TypeFloat, true
+	}
+
+	return schema.TypeInvalid, false
+}
+
+// computedSchemaFromModel generates a Schema from the `tfschema` struct tags on the specified model
+// where every field is Computed - for example for the items returned from a List Data Source
+func computedSchemaFromModel(model interface{}) (map[string]*schema.Schema, error) {
+	modelType := reflect.TypeOf(model)
+	if modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	if modelType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected the model to be a struct but got %s", modelType.Kind())
+	}
+
+	return schemaForStruct("", modelType, true, nil, map[string]struct{}{})
+}
+
+// ValidateModelAgainstSchema validates that each field within the model exists within the Schema
+// with a compatible type and shape (and vice versa) - and that any `required`, `optional`,
+// `computed`, `forcenew` or `sensitive` options specified in the `tfschema` struct tags match the Schema
+func ValidateModelAgainstSchema(model interface{}, resourceSchema map[string]*schema.Schema) error {
+	modelType := reflect.TypeOf(model)
+	if modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	if modelType.Kind() != reflect.Struct {
+		return fmt.Errorf("expected the model to be a struct but got %s", modelType.Kind())
+	}
+
+	return validateStructAgainstSchema("", modelType, resourceSchema)
+}
+
+func validateStructAgainstSchema(prefix string, objType reflect.Type, resourceSchema map[string]*schema.Schema) error {
+	seen := make(map[string]struct{})
+	for i := 0; i < objType.NumField(); i++ {
+		field := objType.Field(i)
+		tag, exists, err := parseStructTag(field)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+
+		path := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, tag.Name), ".")
+		seen[tag.Name] = struct{}{}
+
+		s, ok := resourceSchema[tag.Name]
+		if !ok {
+			return fmt.Errorf("%q is defined in the model but not in the schema", path)
+		}
+
+		if tag.hasBehaviour() {
+			if tag.Required != s.Required || tag.Optional != s.Optional || tag.Computed != s.Computed {
+				return fmt.Errorf("%q: the Required/Optional/Computed options in the model don't match the schema", path)
+			}
+			if tag.ForceNew != s.ForceNew {
+				return fmt.Errorf("%q: the ForceNew option in the model doesn't match the schema", path)
+			}
+			if tag.Sensitive != s.Sensitive {
+				return fmt.Errorf("%q: the Sensitive option in the model doesn't match the schema", path)
+			}
+		}
+
+		if err := validateFieldAgainstSchema(path, field.Type, *tag, s); err != nil {
+			return err
+		}
+	}
+
+	for k := range resourceSchema {
+		if _, ok := seen[k]; !ok {
+			path := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, k), ".")
+			return fmt.Errorf("%q is defined in the schema but not in the model", path)
+		}
+	}
+
+	return nil
+}
+
+func validateFieldAgainstSchema(path string, fieldType reflect.Type, tag structTag, s *schema.Schema) error {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	if valueType, ok := primitiveValueType(fieldType); ok {
+		if s.Type != valueType {
+			return fmt.Errorf("%q is a %s in the model but a %s in the schema", path, valueType, s.Type)
+		}
+
+		return nil
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Struct, reflect.Slice:
+		if s.Type != schema.TypeList && s.Type != schema.TypeSet {
+			return fmt.Errorf("%q is a %s in the model but a %s in the schema", path, fieldType.Kind(), s.Type)
+		}
+		if tag.Set && s.Type != schema.TypeSet {
+			return fmt.Errorf("%q is a Set in the model but a %s in the schema", path, s.Type)
+		}
+
+		elemType := fieldType
+		if fieldType.Kind() == reflect.Slice {
+			elemType = fieldType.Elem()
+			if elemType.Kind() == reflect.Ptr {
+				elemType = elemType.Elem()
+			}
+		} else if s.MaxItems != 1 {
+			return fmt.Errorf("%q is a single nested block in the model and so must have a MaxItems of 1 in the schema", path)
+		}
+
+		if valueType, ok := primitiveValueType(elemType); ok {
+			elem, ok := s.Elem.(*schema.Schema)
+			if !ok {
+				return fmt.Errorf("%q is a list of %s in the model but the schema doesn't contain a list of primitives", path, valueType)
+			}
+			if elem.Type != valueType {
+				return fmt.Errorf("%q is a list of %s in the model but a list of %s in the schema", path, valueType, elem.Type)
+			}
+
+			return nil
+		}
+
+		if elemType.Kind() != reflect.Struct {
+			return fmt.Errorf("%q has an unsupported type %s", path, fieldType)
+		}
+
+		elem, ok := s.Elem.(*schema.Resource)
+		if !ok {
+			return fmt.Errorf("%q is a nested block in the model but not in the schema", path)
+		}
+		return validateStructAgainstSchema(path, elemType, elem.Schema)
+
+	case reflect.Map:
+		if s.Type != schema.TypeMap {
+			return fmt.Errorf("%q is a map in the model but a %s in the schema", path, s.Type)
+		}
+
+		elemType := fieldType.Elem()
+		if elemType.Kind() == reflect.Ptr {
+			elemType = elemType.Elem()
+		}
+		valueType, ok := primitiveValueType(elemType)
+		if !ok {
+			return fmt.Errorf("%q has an unsupported type %s", path, fieldType)
+		}
+
+		// the Plugin SDK treats a Map without an Elem as a map of strings
+		schemaValueType := schema.TypeString
+		if elem, ok := s.Elem.(*schema.Schema); ok {
+			schemaValueType = elem.Type
+		}
+		if valueType != schemaValueType {
+			return fmt.Errorf("%q is a map of %s in the model but a map of %s in the schema", path, valueType, schemaValueType)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("%q has an unsupported type %s", path, fieldType)
+}
@@ -0,0 +1,410 @@
+package sdk
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+type generatedSchemaRuleModel struct {
+	Name string `tfschema:"name,required"`
+	Port int    `tfschema:"port,optional"`
+}
+
+type generatedSchemaModel struct {
+	Name     string                     `tfschema:"name,required,forcenew"`
+	Password *string                    `tfschema:"password,optional,sensitive"`
+	Zones    []string                   `tfschema:"zones,optional,set"`
+	Limits   map[string]int             `tfschema:"limits,optional"`
+	Rules    []generatedSchemaRuleModel `tfschema:"rule,optional"`
+	Settings *generatedSchemaRuleModel  `tfschema:"settings,optional,computed"`
+	Fqdn     string                     `tfschema:"fqdn,computed"`
+}
+
+type generatedSchemaResource struct{}
+
+func (r generatedSchemaResource) Arguments() map[string]*schema.Schema {
+	return map[string]*schema.Schema{}
+}
+
+func (r generatedSchemaResource) Attributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{}
+}
+
+func (r generatedSchemaResource) ModelObject() interface{} {
+	return generatedSchemaModel{}
+}
+
+func (r generatedSchemaResource) ResourceType() string {
+	return "validator_generated_schema"
+}
+
+func (r generatedSchemaResource) SchemaValidators() map[string]schema.SchemaValidateFunc {
+	return map[string]schema.SchemaValidateFunc{
+		"zones":     validation.StringIsNotEmpty,
+		"rule.port": validation.IsPortNumber,
+	}
+}
+
+func (r generatedSchemaResource) Create() ResourceFunc {
+	return r.noop()
+}
+
+func (r generatedSchemaResource) Read() ResourceFunc {
+	return r.noop()
+}
+
+func (r generatedSchemaResource) Update() ResourceFunc {
+	return r.noop()
+}
+
+func (r generatedSchemaResource) Delete() ResourceFunc {
+	return r.noop()
+}
+
+func (r generatedSchemaResource) IDValidationFunc() schema.SchemaValidateFunc {
+	return nil
+}
+
+func (r generatedSchemaResource) noop() ResourceFunc {
+	return ResourceFunc{
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			return nil
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+func TestParseStructTag(t *testing.T) {
+	testData := []struct {
+		Name        string
+		Input       reflect.StructTag
+		Expected    *structTag
+		ExpectError bool
+	}{
+		{
+			Name:     "Name Only",
+			Input:    `tfschema:"name"`,
+			Expected: &structTag{Name: "name"},
+		},
+		{
+			Name:  "All Options",
+			Input: `tfschema:"name,required,optional,computed,forcenew,sensitive,set"`,
+			Expected: &structTag{
+				Name:      "name",
+				Required:  true,
+				Optional:  true,
+				Computed:  true,
+				ForceNew:  true,
+				Sensitive: true,
+				Set:       true,
+			},
+		},
+		{
+			Name:        "Empty Name",
+			Input:       `tfschema:",required"`,
+			ExpectError: true,
+		},
+		{
+			Name:        "Unknown Option",
+			Input:       `tfschema:"name,deprecated"`,
+			ExpectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual, _, err := parseStructTag(reflect.StructField{Name: "Field", Tag: v.Input})
+		if err != nil {
+			if v.ExpectError {
+				continue
+			}
+
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.ExpectError {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestSchemaFromModel(t *testing.T) {
+	arguments, attributes, err := SchemaFromModel(generatedSchemaModel{}, generatedSchemaResource{}.SchemaValidators())
+	if err != nil {
+		t.Fatalf("generating schema: %+v", err)
+	}
+
+	if len(arguments) != 6 {
+		t.Fatalf("expected 6 arguments but got %d", len(arguments))
+	}
+	if len(attributes) != 1 || attributes["fqdn"] == nil || !attributes["fqdn"].Computed {
+		t.Fatalf("expected `fqdn` to be the only attribute but got %+v", attributes)
+	}
+
+	name := arguments["name"]
+	if name.Type != schema.TypeString || !name.Required || !name.ForceNew {
+		t.Fatalf("expected `name` to be a Required ForceNew String but got %+v", name)
+	}
+
+	password := arguments["password"]
+	if password.Type != schema.TypeString || !password.Optional || !password.Sensitive {
+		t.Fatalf("expected `password` to be an Optional Sensitive String but got %+v", password)
+	}
+
+	zones := arguments["zones"]
+	if zones.Type != schema.TypeSet || zones.Elem.(*schema.Schema).Type != schema.TypeString || zones.Elem.(*schema.Schema).ValidateFunc == nil {
+		t.Fatalf("expected `zones` to be a Set of validated Strings but got %+v", zones)
+	}
+
+	limits := arguments["limits"]
+	if limits.Type != schema.TypeMap || limits.Elem.(*schema.Schema).Type != schema.TypeInt {
+		t.Fatalf("expected `limits` to be a Map of Ints but got %+v", limits)
+	}
+
+	rule := arguments["rule"]
+	if rule.Type != schema.TypeList || rule.MaxItems != 0 {
+		t.Fatalf("expected `rule` to be a List but got %+v", rule)
+	}
+	port := rule.Elem.(*schema.Resource).Schema["port"]
+	if port.Type != schema.TypeInt || !port.Optional || port.ValidateFunc == nil {
+		t.Fatalf("expected `rule.port` to be a validated Optional Int but got %+v", port)
+	}
+
+	settings := arguments["settings"]
+	if settings.Type != schema.TypeList || settings.MaxItems != 1 || !settings.Optional || !settings.Computed {
+		t.Fatalf("expected `settings` to be an Optional Computed List with a single item but got %+v", settings)
+	}
+}
+
+func TestSchemaFromModelInvalid(t *testing.T) {
+	type missingBehaviour struct {
+		Name string `tfschema:"name"`
+	}
+	if _, _, err := SchemaFromModel(missingBehaviour{}, nil); err == nil {
+		t.Fatalf("expected an error for a field without a behaviour but didn't get one")
+	}
+
+	type unsupportedType struct {
+		Channel chan string `tfschema:"channel,optional"`
+	}
+	if _, _, err := SchemaFromModel(unsupportedType{}, nil); err == nil {
+		t.Fatalf("expected an error for an unsupported type but didn't get one")
+	}
+
+	type valid struct {
+		Name string `tfschema:"name,required"`
+	}
+	validators := map[string]schema.SchemaValidateFunc{
+		"does_not_exist": validation.StringIsNotEmpty,
+	}
+	if _, _, err := SchemaFromModel(valid{}, validators); err == nil {
+		t.Fatalf("expected an error for a validator for an unknown field but didn't get one")
+	}
+}
+
+func TestValidateModelAgainstSchema(t *testing.T) {
+	type Nested struct {
+		Key string `tfschema:"key"`
+	}
+	type Model struct {
+		Name   string            `tfschema:"name,required"`
+		Count  *int              `tfschema:"count"`
+		Zones  []string          `tfschema:"zones"`
+		Limits map[string]int    `tfschema:"limits"`
+		Tags   map[string]string `tfschema:"tags"`
+		Nested *Nested           `tfschema:"nested"`
+	}
+
+	validSchema := func() map[string]*schema.Schema {
+		return map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"count": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"zones": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"limits": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+			"nested": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	testData := []struct {
+		Name        string
+		Update      func(input map[string]*schema.Schema)
+		ExpectError bool
+	}{
+		{
+			Name:   "Valid",
+			Update: func(input map[string]*schema.Schema) {},
+		},
+		{
+			Name: "Missing from the Schema",
+			Update: func(input map[string]*schema.Schema) {
+				delete(input, "count")
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "Missing from the Model",
+			Update: func(input map[string]*schema.Schema) {
+				input["extra"] = &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+				}
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "Mismatched Type",
+			Update: func(input map[string]*schema.Schema) {
+				input["count"].Type = schema.TypeString
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "Mismatched List Element",
+			Update: func(input map[string]*schema.Schema) {
+				input["zones"].Elem = &schema.Schema{
+					Type: schema.TypeInt,
+				}
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "Mismatched Map Element",
+			Update: func(input map[string]*schema.Schema) {
+				input["limits"].Elem = &schema.Schema{
+					Type: schema.TypeString,
+				}
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "Single Nested Block without MaxItems",
+			Update: func(input map[string]*schema.Schema) {
+				input["nested"].MaxItems = 0
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "Mismatched Nested Block",
+			Update: func(input map[string]*schema.Schema) {
+				input["nested"].Elem.(*schema.Resource).Schema["key"].Type = schema.TypeBool
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "Mismatched Behaviour",
+			Update: func(input map[string]*schema.Schema) {
+				input["name"].Required = false
+				input["name"].Optional = true
+			},
+			ExpectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		input := validSchema()
+		v.Update(input)
+
+		err := ValidateModelAgainstSchema(Model{}, input)
+		if err != nil {
+			if v.ExpectError {
+				continue
+			}
+
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.ExpectError {
+			t.Fatalf("expected an error but didn't get one")
+		}
+	}
+}
+
+func TestResourceWrapperGeneratedSchema(t *testing.T) {
+	wrapper := NewResourceWrapper(generatedSchemaResource{})
+	resource, err := wrapper.Resource()
+	if err != nil {
+		t.Fatalf("building Resource: %+v", err)
+	}
+
+	if err := resource.InternalValidate(nil, true); err != nil {
+		t.Fatalf("validating Resource: %+v", err)
+	}
+
+	if _, ok := resource.Schema["fqdn"]; !ok {
+		t.Fatalf("expected the generated Schema to contain `fqdn`")
+	}
+}
+
+func TestResourceWrapperValidatesModelAgainstSchema(t *testing.T) {
+	wrapper := NewResourceWrapper(customizeDiffResource{
+		timeout: 5 * time.Minute,
+	})
+	if _, err := wrapper.Resource(); err != nil {
+		t.Fatalf("building Resource: %+v", err)
+	}
+
+	wrapper = NewResourceWrapper(mismatchedModelResource{
+		customizeDiffResource: customizeDiffResource{
+			timeout: 5 * time.Minute,
+		},
+	})
+	if _, err := wrapper.Resource(); err == nil {
+		t.Fatalf("expected an error when the model doesn't match the Schema but didn't get one")
+	}
+}
+
+type mismatchedModelResource struct {
+	customizeDiffResource
+}
+
+func (r mismatchedModelResource) ModelObject() interface{} {
+	return struct {
+		Name  string `tfschema:"name"`
+		Sku   int    `tfschema:"sku"`
+		Zones []int  `tfschema:"zones"`
+	}{}
+}
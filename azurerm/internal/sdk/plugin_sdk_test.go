@@ -855,6 +855,192 @@ func TestAccPluginSDKAndEncoder(t *testing.T) {
 	})
 }
 
+func TestAccPluginSDKAndEncoderDecoderNestedRoundTrip(t *testing.T) {
+	os.Setenv("TF_ACC", "1")
+
+	type RuleType struct {
+		Name string `tfschema:"name"`
+		Port int    `tfschema:"port"`
+	}
+	type SettingsType struct {
+		Enabled bool           `tfschema:"enabled"`
+		Limits  map[string]int `tfschema:"limits"`
+	}
+	type MyType struct {
+		Name           string        `tfschema:"name"`
+		OptionalNumber *int          `tfschema:"optional_number"`
+		ZeroNumber     *int          `tfschema:"zero_number"`
+		Rules          []RuleType    `tfschema:"rule"`
+		Settings       *SettingsType `tfschema:"settings"`
+		Unset          *SettingsType `tfschema:"unset"`
+	}
+
+	zero := 0
+	expected := MyType{
+		Name:       "example",
+		ZeroNumber: &zero,
+		Rules: []RuleType{
+			{
+				Name: "http",
+				Port: 80,
+			},
+		},
+		Settings: &SettingsType{
+			Enabled: true,
+			Limits: map[string]int{
+				"connections": 10,
+			},
+		},
+	}
+
+	var decode = func(d *schema.ResourceData, expected MyType) (*MyType, error) {
+		wrapper := ResourceMetaData{
+			ResourceData:             d,
+			Logger:                   ConsoleLogger{},
+			serializationDebugLogger: ConsoleLogger{},
+		}
+
+		var actual MyType
+		if err := wrapper.Decode(&actual); err != nil {
+			return nil, fmt.Errorf("decoding: %+v", err)
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			return nil, fmt.Errorf("Values did not match - Expected:\n%+v\n\nActual:\n%+v", expected, actual)
+		}
+
+		return &actual, nil
+	}
+
+	// lintignore:AT001
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: map[string]terraform.ResourceProviderFactory{
+			"validator": func() (terraform.ResourceProvider, error) {
+				return &schema.Provider{
+					DataSourcesMap: map[string]*schema.Resource{},
+					ResourcesMap: map[string]*schema.Resource{
+						"validator_round_trip": {
+							Schema: map[string]*schema.Schema{
+								"name": {
+									Type:     schema.TypeString,
+									Required: true,
+								},
+								"optional_number": {
+									Type:     schema.TypeInt,
+									Optional: true,
+								},
+								"zero_number": {
+									Type:     schema.TypeInt,
+									Optional: true,
+								},
+								"rule": {
+									Type:     schema.TypeSet,
+									Optional: true,
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"name": {
+												Type:     schema.TypeString,
+												Required: true,
+											},
+											"port": {
+												Type:     schema.TypeInt,
+												Required: true,
+											},
+										},
+									},
+								},
+								"settings": {
+									Type:     schema.TypeList,
+									Optional: true,
+									MaxItems: 1,
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"enabled": {
+												Type:     schema.TypeBool,
+												Optional: true,
+											},
+											"limits": {
+												Type:     schema.TypeMap,
+												Optional: true,
+												Elem: &schema.Schema{
+													Type: schema.TypeInt,
+												},
+											},
+										},
+									},
+								},
+								"unset": {
+									Type:     schema.TypeList,
+									Optional: true,
+									MaxItems: 1,
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"enabled": {
+												Type:     schema.TypeBool,
+												Optional: true,
+											},
+										},
+									},
+								},
+							},
+							Create: func(d *schema.ResourceData, _ interface{}) error {
+								actual, err := decode(d, expected)
+								if err != nil {
+									return err
+								}
+
+								d.SetId("some-id")
+								wrapper := ResourceMetaData{
+									ResourceData:             d,
+									Logger:                   ConsoleLogger{},
+									serializationDebugLogger: ConsoleLogger{},
+								}
+								return wrapper.Encode(actual)
+							},
+							Read: func(d *schema.ResourceData, _ interface{}) error {
+								// once the value has been persisted into the State the Plugin SDK can no longer
+								// distinguish between an unset value and a zero value for primitive fields
+								refreshed := expected
+								refreshed.OptionalNumber = &zero
+								_, err := decode(d, refreshed)
+								return err
+							},
+							Update: func(d *schema.ResourceData, _ interface{}) error {
+								return nil
+							},
+							Delete: func(_ *schema.ResourceData, _ interface{}) error {
+								return nil
+							},
+						},
+					},
+				}, nil
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "validator_round_trip" "test" {
+  name        = "example"
+  zero_number = 0
+
+  rule {
+    name = "http"
+    port = 80
+  }
+
+  settings {
+    enabled = true
+    limits = {
+      connections = 10
+    }
+  }
+}
+`,
+			},
+		},
+	})
+}
+
 func TestAccPluginSDKReturnsComputedFields(t *testing.T) {
 	os.Setenv("TF_ACC", "1")
 
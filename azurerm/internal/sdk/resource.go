@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -37,6 +38,19 @@ type DataSource interface {
 	Read() ResourceFunc
 }
 
+// DataSourceWithGeneratedSchema is an optional interface
+//
+// Data Sources implementing this interface have their Schema generated from the `tfschema`
+// struct tags on the ModelObject (see SchemaFromModel) - as such the Arguments and Attributes
+// functions should return an empty map.
+type DataSourceWithGeneratedSchema interface {
+	DataSource
+
+	// SchemaValidators returns the ValidateFunc's used for fields within the generated Schema
+	// keyed by the path to the field (e.g. `rule.port`) - this can return nil if none are required
+	SchemaValidators() map[string]schema.SchemaValidateFunc
+}
+
 // A Resource is an object which can be provisioned and managed by Terraform
 // that is, Created, Retrieved, Deleted, Imported (and optionally, Updated, by implementing
 // the 'ResourceWithUpdate' interface)
@@ -62,10 +76,6 @@ type Resource interface {
 	IDValidationFunc() schema.SchemaValidateFunc
 }
 
-// TODO: ResourceWithCustomizeDiff
-// TODO: ResourceWithStateMigration
-// TODO: a generic state migration for updating ID's
-
 type ResourceWithCustomImporter interface {
 	Resource
 
@@ -87,6 +97,54 @@ type ResourceWithUpdate interface {
 	Update() ResourceFunc
 }
 
+// ResourceWithCustomizeDiff is an optional interface
+//
+// Resources implementing this interface can inspect (and make changes to) the Plan
+// prior to it being applied - for example to validate fields against one another
+// or to mark fields as ForceNew/Computed based on the values of other fields.
+type ResourceWithCustomizeDiff interface {
+	Resource
+
+	// CustomizeDiff returns a ResourceFunc which is called during the Plan
+	// NOTE: the ResourceMetaData passed to this function contains a ResourceDiff
+	// rather than a ResourceData - as such Decode returns the planned values
+	CustomizeDiff() ResourceFunc
+}
+
+// ResourceWithStateMigration is an optional interface
+//
+// Resources implementing this interface can migrate the existing State from
+// a previous Schema Version to the current one - for example when the casing
+// of the Resource ID has changed, see ResourceIDStateUpgrade
+type ResourceWithStateMigration interface {
+	Resource
+
+	// StateUpgraders returns the current Schema Version and the StateUpgrade's
+	// for each previous Schema Version of this Resource
+	StateUpgraders() StateUpgradeData
+}
+
+// ResourceWithGeneratedSchema is an optional interface
+//
+// Resources implementing this interface have their Schema generated from the `tfschema`
+// struct tags on the ModelObject (see SchemaFromModel) - as such the Arguments and Attributes
+// functions should return an empty map. For example:
+//
+//	type ExampleModel struct {
+//		Name     string   `tfschema:"name,required,forcenew"`
+//		Port     *int     `tfschema:"port,optional"`
+//		Password string   `tfschema:"password,optional,sensitive"`
+//		Zones    []string `tfschema:"zones,optional,set"`
+//		Fqdn     string   `tfschema:"fqdn,computed"`
+//	}
+type ResourceWithGeneratedSchema interface {
+	Resource
+
+	// SchemaValidators returns the ValidateFunc's used for fields within the generated Schema
+	// keyed by the path to the field (e.g. `rule.port`) - this can return nil if none are required
+	SchemaValidators() map[string]schema.SchemaValidateFunc
+}
+
 // ResourceWithDeprecation is an optional interface
 //
 // Resources implementing this interface will be marked as Deprecated
@@ -126,6 +184,11 @@ type ResourceMetaData struct {
 	// for example, to determine if a field has changes
 	ResourceData *schema.ResourceData
 
+	// ResourceDiff is a reference to the ResourceDiff object from Terraform's Plugin SDK
+	// This is only available within the CustomizeDiff function (at which point ResourceData is nil)
+	// and can be used to make changes to the Plan, for example, marking a field as ForceNew
+	ResourceDiff *schema.ResourceDiff
+
 	// serializationDebugLogger is used for testing purposes
 	serializationDebugLogger Logger
 }
@@ -136,6 +199,26 @@ func (rmd ResourceMetaData) MarkAsGone() error {
 	return nil
 }
 
+// MarkAsForceNew marks the specified field as requiring the resource to be recreated
+// NOTE: this can only be used within the CustomizeDiff function
+func (rmd ResourceMetaData) MarkAsForceNew(key string) error {
+	if rmd.ResourceDiff == nil {
+		return fmt.Errorf("marking %q as ForceNew: this is only supported within CustomizeDiff", key)
+	}
+
+	return rmd.ResourceDiff.ForceNew(key)
+}
+
+// MarkAsComputed marks the specified Computed field as having a new value which is known after apply
+// NOTE: this can only be used within the CustomizeDiff function
+func (rmd ResourceMetaData) MarkAsComputed(key string) error {
+	if rmd.ResourceDiff == nil {
+		return fmt.Errorf("marking %q as Computed: this is only supported within CustomizeDiff", key)
+	}
+
+	return rmd.ResourceDiff.SetNewComputed(key)
+}
+
 // ResourceRequiresImport returns an error saying that this resource must be imported with instructions
 // on how to do this (namely, using `terraform import`
 func (rmd ResourceMetaData) ResourceRequiresImport(resourceName string, idFormatter resourceid.Formatter) error {
@@ -9,16 +9,29 @@ import (
 
 // Decode will decode the Terraform Schema into the specified object
 // NOTE: this object must be passed by value - and must contain `tfschema`
-// struct tags for all fields
+// struct tags for all fields. When called from within CustomizeDiff this
+// decodes the planned values.
+//
+// Nested blocks can be decoded into a slice of structs (or pointers to structs),
+// or into a struct/pointer to a struct where the block contains a single item.
+// Top-level fields defined as pointers (e.g. `*int`) are left as nil when the
+// value hasn't been set, allowing unset values to be distinguished from zero values.
+// Since this uses `GetOkExists` this is only reliable when the value comes from the
+// configuration (e.g. in Create or CustomizeDiff), rather than from the State.
 //
 // Example Usage:
 //
 // type Person struct {
 //	 Name string `tfschema:"name"
+//	 Age  *int   `tfschema:"age"
 // }
 // var person Person
 // if err := metadata.Decode(&person); err != nil { .. }
 func (rmd ResourceMetaData) Decode(input interface{}) error {
+	if rmd.ResourceDiff != nil {
+		return decodeReflectedType(input, rmd.ResourceDiff, rmd.serializationDebugLogger)
+	}
+
 	return decodeReflectedType(input, rmd.ResourceData, rmd.serializationDebugLogger)
 }
 
@@ -35,21 +48,21 @@ func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debug
 	}
 
 	objType := reflect.TypeOf(input).Elem()
+	objVal := reflect.ValueOf(input).Elem()
 	for i := 0; i < objType.NumField(); i++ {
 		field := objType.Field(i)
 		debugLogger.Infof("Field", field)
 
-		if val, exists := field.Tag.Lookup("tfschema"); exists {
+		if val, exists := tfschemaName(field); exists {
 			tfschemaValue, valExists := stateRetriever.GetOkExists(val)
 			if !valExists {
 				continue
 			}
 
 			debugLogger.Infof("TFSchemaValue: ", tfschemaValue)
-			debugLogger.Infof("Input Type: ", reflect.ValueOf(input).Elem().Field(i).Type())
+			debugLogger.Infof("Input Type: ", field.Type)
 
-			fieldName := reflect.ValueOf(input).Elem().Field(i).String()
-			if err := setValue(input, tfschemaValue, i, fieldName, debugLogger); err != nil {
+			if err := setValue(objVal.Field(i), tfschemaValue, field.Name, debugLogger); err != nil {
 				return err
 			}
 		}
@@ -57,148 +70,179 @@ func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debug
 	return nil
 }
 
-func setValue(input, tfschemaValue interface{}, index int, fieldName string, debugLogger Logger) (errOut error) {
-	debugLogger.Infof("setting list value for %q..", fieldName)
+func setValue(target reflect.Value, tfschemaValue interface{}, fieldName string, debugLogger Logger) (errOut error) {
+	debugLogger.Infof("setting value for %q..", fieldName)
 	defer func() {
 		if r := recover(); r != nil {
 			debugLogger.Warnf("error setting value for %q: %+v", fieldName, r)
 			out, ok := r.(error)
 			if !ok {
-				return
+				out = fmt.Errorf("%+v", r)
 			}
 
-			errOut = out
+			errOut = fmt.Errorf("setting value for %q: %+v", fieldName, out)
 		}
 	}()
 
-	if v, ok := tfschemaValue.(string); ok {
-		debugLogger.Infof("[String] Decode %+v", v)
-		debugLogger.Infof("Input %+v", reflect.ValueOf(input))
-		debugLogger.Infof("Input Elem %+v", reflect.ValueOf(input).Elem())
-		reflect.ValueOf(input).Elem().Field(index).SetString(v)
-		return nil
-	}
-
-	if v, ok := tfschemaValue.(int); ok {
-		debugLogger.Infof("[INT] Decode %+v", v)
-		reflect.ValueOf(input).Elem().Field(index).SetInt(int64(v))
+	if tfschemaValue == nil {
 		return nil
 	}
 
-	if v, ok := tfschemaValue.(int32); ok {
-		debugLogger.Infof("[INT] Decode %+v", v)
-		reflect.ValueOf(input).Elem().Field(index).SetInt(int64(v))
-		return nil
-	}
+	switch target.Kind() {
+	case reflect.Ptr:
+		// an empty nested block is left as nil, rather than a pointer to an empty struct
+		if target.Type().Elem().Kind() == reflect.Struct {
+			if _, isMap := tfschemaValue.(map[string]interface{}); !isMap && len(listItems(tfschemaValue)) == 0 {
+				return nil
+			}
+		}
 
-	if v, ok := tfschemaValue.(int64); ok {
-		debugLogger.Infof("[INT] Decode %+v", v)
-		reflect.ValueOf(input).Elem().Field(index).SetInt(v)
-		return nil
-	}
+		elem := reflect.New(target.Type().Elem())
+		if err := setValue(elem.Elem(), tfschemaValue, fieldName, debugLogger); err != nil {
+			return err
+		}
+		target.Set(elem)
 
-	if v, ok := tfschemaValue.(float64); ok {
-		debugLogger.Infof("[Float] Decode %+v", v)
-		reflect.ValueOf(input).Elem().Field(index).SetFloat(v)
-		return nil
-	}
+	case reflect.String:
+		v, ok := tfschemaValue.(string)
+		if !ok {
+			return fmt.Errorf("expected a string for %q but got %T", fieldName, tfschemaValue)
+		}
+		debugLogger.Infof("[String] Decode %+v", v)
+		target.SetString(v)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch v := tfschemaValue.(type) {
+		case int:
+			target.SetInt(int64(v))
+		case int32:
+			target.SetInt(int64(v))
+		case int64:
+			target.SetInt(v)
+		case float64:
+			target.SetInt(int64(v))
+		default:
+			return fmt.Errorf("expected an int for %q but got %T", fieldName, tfschemaValue)
+		}
+		debugLogger.Infof("[INT] Decode %+v", tfschemaValue)
+
+	case reflect.Float32, reflect.Float64:
+		switch v := tfschemaValue.(type) {
+		case float64:
+			target.SetFloat(v)
+		case float32:
+			target.SetFloat(float64(v))
+		case int:
+			target.SetFloat(float64(v))
+		case int64:
+			target.SetFloat(float64(v))
+		default:
+			return fmt.Errorf("expected a float for %q but got %T", fieldName, tfschemaValue)
+		}
+		debugLogger.Infof("[Float] Decode %+v", tfschemaValue)
 
-	// Doesn't work for empty bools?
-	if v, ok := tfschemaValue.(bool); ok {
+	case reflect.Bool:
+		v, ok := tfschemaValue.(bool)
+		if !ok {
+			return fmt.Errorf("expected a bool for %q but got %T", fieldName, tfschemaValue)
+		}
 		debugLogger.Infof("[BOOL] Decode %+v", v)
+		target.SetBool(v)
 
-		reflect.ValueOf(input).Elem().Field(index).SetBool(v)
-		return nil
-	}
-
-	if v, ok := tfschemaValue.(*schema.Set); ok {
-		return setListValue(input, index, fieldName, v.List(), debugLogger)
-	}
+	case reflect.Map:
+		mapConfig, ok := tfschemaValue.(map[string]interface{})
+		if !ok {
+			return nil
+		}
 
-	if mapConfig, ok := tfschemaValue.(map[string]interface{}); ok {
-		mapOutput := reflect.MakeMap(reflect.ValueOf(input).Elem().Field(index).Type())
+		mapOutput := reflect.MakeMapWithSize(target.Type(), len(mapConfig))
 		for key, val := range mapConfig {
-			mapOutput.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(val))
+			elem := reflect.New(target.Type().Elem()).Elem()
+			if err := setValue(elem, val, fmt.Sprintf("%s.%s", fieldName, key), debugLogger); err != nil {
+				return err
+			}
+			mapOutput.SetMapIndex(reflect.ValueOf(key), elem)
+		}
+		target.Set(mapOutput)
+
+	case reflect.Slice:
+		return setListValue(target, tfschemaValue, fieldName, debugLogger)
+
+	case reflect.Struct:
+		// a single nested block is represented in the Plugin SDK as a list containing one item
+		block, ok := tfschemaValue.(map[string]interface{})
+		if !ok {
+			items := listItems(tfschemaValue)
+			if len(items) == 0 {
+				return nil
+			}
+			if block, ok = items[0].(map[string]interface{}); !ok {
+				return nil
+			}
 		}
 
-		reflect.ValueOf(input).Elem().Field(index).Set(mapOutput)
-		return nil
-	}
+		return setStructValue(target, block, fieldName, debugLogger)
 
-	if v, ok := tfschemaValue.([]interface{}); ok {
-		return setListValue(input, index, fieldName, v, debugLogger)
+	default:
+		return fmt.Errorf("unsupported type %+v for %q", target.Type(), fieldName)
 	}
 
 	return nil
 }
 
-func setListValue(input interface{}, index int, fieldName string, v []interface{}, debugLogger Logger) error {
-	switch fieldType := reflect.ValueOf(input).Elem().Field(index).Type(); fieldType {
-	case reflect.TypeOf([]string{}):
-		stringSlice := reflect.MakeSlice(reflect.TypeOf([]string{}), len(v), len(v))
-		for i, stringVal := range v {
-			stringSlice.Index(i).SetString(stringVal.(string))
-		}
-		reflect.ValueOf(input).Elem().Field(index).Set(stringSlice)
+func setListValue(target reflect.Value, tfschemaValue interface{}, fieldName string, debugLogger Logger) error {
+	switch tfschemaValue.(type) {
+	case []interface{}, *schema.Set:
+	default:
+		return nil
+	}
 
-	case reflect.TypeOf([]int{}):
-		iSlice := reflect.MakeSlice(reflect.TypeOf([]int{}), len(v), len(v))
-		for i, iVal := range v {
-			iSlice.Index(i).SetInt(int64(iVal.(int)))
-		}
-		reflect.ValueOf(input).Elem().Field(index).Set(iSlice)
+	items := listItems(tfschemaValue)
+	elemType := target.Type().Elem()
+	isBlock := elemType.Kind() == reflect.Struct || (elemType.Kind() == reflect.Ptr && elemType.Elem().Kind() == reflect.Struct)
 
-	case reflect.TypeOf([]float64{}):
-		fSlice := reflect.MakeSlice(reflect.TypeOf([]float64{}), len(v), len(v))
-		for i, fVal := range v {
-			fSlice.Index(i).SetFloat(fVal.(float64))
+	valueToSet := reflect.MakeSlice(target.Type(), 0, len(items))
+	debugLogger.Infof("List Type", valueToSet.Type())
+	for i, item := range items {
+		// the Plugin SDK returns nil for a nested block containing no values
+		if item == nil && isBlock {
+			continue
 		}
-		reflect.ValueOf(input).Elem().Field(index).Set(fSlice)
 
-	case reflect.TypeOf([]bool{}):
-		bSlice := reflect.MakeSlice(reflect.TypeOf([]bool{}), len(v), len(v))
-		for i, bVal := range v {
-			bSlice.Index(i).SetBool(bVal.(bool))
+		elem := reflect.New(elemType).Elem()
+		if err := setValue(elem, item, fmt.Sprintf("%s.%d", fieldName, i), debugLogger); err != nil {
+			return err
 		}
-		reflect.ValueOf(input).Elem().Field(index).Set(bSlice)
+		valueToSet = reflect.Append(valueToSet, elem)
+	}
 
-	default:
-		valueToSet := reflect.MakeSlice(reflect.ValueOf(input).Elem().Field(index).Type(), 0, 0)
-		debugLogger.Infof("List Type", valueToSet.Type())
-
-		for _, mapVal := range v {
-			if test, ok := mapVal.(map[string]interface{}); ok && test != nil {
-				elem := reflect.New(fieldType.Elem())
-				debugLogger.Infof("element ", elem)
-				for j := 0; j < elem.Type().Elem().NumField(); j++ {
-					nestedField := elem.Type().Elem().Field(j)
-					debugLogger.Infof("nestedField ", nestedField)
-
-					if val, exists := nestedField.Tag.Lookup("tfschema"); exists {
-						nestedTFSchemaValue := test[val]
-						if err := setValue(elem.Interface(), nestedTFSchemaValue, j, fieldName, debugLogger); err != nil {
-							return err
-						}
-					}
-				}
-
-				if !elem.CanSet() {
-					elem = elem.Elem()
-				}
-
-				if valueToSet.Kind() == reflect.Ptr {
-					valueToSet.Elem().Set(reflect.Append(valueToSet.Elem(), elem))
-				} else {
-					valueToSet = reflect.Append(valueToSet, elem)
-				}
-
-				debugLogger.Infof("value to set type after changes", valueToSet.Type())
+	target.Set(valueToSet)
+	return nil
+}
+
+func setStructValue(target reflect.Value, block map[string]interface{}, fieldName string, debugLogger Logger) error {
+	for j := 0; j < target.NumField(); j++ {
+		nestedField := target.Type().Field(j)
+		debugLogger.Infof("nestedField ", nestedField)
+
+		if val, exists := tfschemaName(nestedField); exists {
+			nestedFieldName := fmt.Sprintf("%s.%s", fieldName, nestedField.Name)
+			if err := setValue(target.Field(j), block[val], nestedFieldName, debugLogger); err != nil {
+				return err
 			}
 		}
+	}
+
+	return nil
+}
 
-		valueToSet = reflect.Indirect(valueToSet)
-		fieldToSet := reflect.ValueOf(input).Elem().Field(index)
-		fieldToSet.Set(reflect.Indirect(valueToSet))
+// listItems returns the items within either a List or a Set
+func listItems(input interface{}) []interface{} {
+	switch v := input.(type) {
+	case []interface{}:
+		return v
+	case *schema.Set:
+		return v.List()
 	}
 
 	return nil
@@ -3,6 +3,8 @@ package sdk
 import (
 	"reflect"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
 type decodeTestData struct {
@@ -696,6 +698,221 @@ func TestResourceDecode_NestedThreeLevelsDeepMultipleItems(t *testing.T) {
 	}.test(t)
 }
 
+func TestResourceDecode_TopLevelPointers(t *testing.T) {
+	type SimpleType struct {
+		String  *string  `tfschema:"string"`
+		Number  *int     `tfschema:"number"`
+		Price   *float64 `tfschema:"price"`
+		Enabled *bool    `tfschema:"enabled"`
+	}
+
+	t.Log("Unset")
+	decodeTestData{
+		State:    map[string]interface{}{},
+		Input:    &SimpleType{},
+		Expected: &SimpleType{},
+	}.test(t)
+
+	t.Log("Zero Values")
+	emptyString := ""
+	zero := 0
+	zeroFloat := float64(0)
+	disabled := false
+	decodeTestData{
+		State: map[string]interface{}{
+			"string":  "",
+			"number":  0,
+			"price":   float64(0),
+			"enabled": false,
+		},
+		Input: &SimpleType{},
+		Expected: &SimpleType{
+			String:  &emptyString,
+			Number:  &zero,
+			Price:   &zeroFloat,
+			Enabled: &disabled,
+		},
+	}.test(t)
+}
+
+func TestResourceDecode_MapsOfPrimitives(t *testing.T) {
+	type SimpleType struct {
+		MapOfBools   map[string]bool    `tfschema:"map_of_bools"`
+		MapOfFloats  map[string]float64 `tfschema:"map_of_floats"`
+		MapOfNumbers map[string]int     `tfschema:"map_of_numbers"`
+		MapOfStrings map[string]string  `tfschema:"map_of_strings"`
+	}
+	decodeTestData{
+		State: map[string]interface{}{
+			"map_of_bools": map[string]interface{}{
+				"enabled": true,
+			},
+			"map_of_floats": map[string]interface{}{
+				"pi":    3.14159,
+				"whole": 3,
+			},
+			"map_of_numbers": map[string]interface{}{
+				"int":   1,
+				"int64": int64(2),
+			},
+			"map_of_strings": map[string]interface{}{
+				"hello": "world",
+			},
+		},
+		Input: &SimpleType{},
+		Expected: &SimpleType{
+			MapOfBools: map[string]bool{
+				"enabled": true,
+			},
+			MapOfFloats: map[string]float64{
+				"pi":    3.14159,
+				"whole": 3,
+			},
+			MapOfNumbers: map[string]int{
+				"int":   1,
+				"int64": 2,
+			},
+			MapOfStrings: map[string]string{
+				"hello": "world",
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceDecode_MapOfInvalidType(t *testing.T) {
+	type SimpleType struct {
+		MapOfNumbers map[string]int `tfschema:"map_of_numbers"`
+	}
+	decodeTestData{
+		State: map[string]interface{}{
+			"map_of_numbers": map[string]interface{}{
+				"hello": "world",
+			},
+		},
+		Input:       &SimpleType{},
+		ExpectError: true,
+	}.test(t)
+}
+
+func TestResourceDecode_NestedSingleBlock(t *testing.T) {
+	type Inner struct {
+		Value  string `tfschema:"value"`
+		Number *int   `tfschema:"number"`
+	}
+	type Type struct {
+		Block    Inner  `tfschema:"block"`
+		Optional *Inner `tfschema:"optional"`
+	}
+
+	t.Log("Empty")
+	decodeTestData{
+		State: map[string]interface{}{
+			"block":    []interface{}{},
+			"optional": []interface{}{},
+		},
+		Input:    &Type{},
+		Expected: &Type{},
+	}.test(t)
+
+	t.Log("Populated")
+	number := 42
+	decodeTestData{
+		State: map[string]interface{}{
+			"block": []interface{}{
+				map[string]interface{}{
+					"value":  "first",
+					"number": 42,
+				},
+			},
+			"optional": []interface{}{
+				map[string]interface{}{
+					"value": "second",
+				},
+			},
+		},
+		Input: &Type{},
+		Expected: &Type{
+			Block: Inner{
+				Value:  "first",
+				Number: &number,
+			},
+			Optional: &Inner{
+				Value: "second",
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceDecode_NestedPointers(t *testing.T) {
+	type Inner struct {
+		Value string `tfschema:"value"`
+	}
+	type Type struct {
+		List []*Inner `tfschema:"list"`
+	}
+	decodeTestData{
+		State: map[string]interface{}{
+			"list": []interface{}{
+				map[string]interface{}{
+					"value": "first",
+				},
+				nil,
+				map[string]interface{}{
+					"value": "second",
+				},
+			},
+		},
+		Input: &Type{},
+		Expected: &Type{
+			List: []*Inner{
+				{
+					Value: "first",
+				},
+				{
+					Value: "second",
+				},
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceDecode_NestedSetOfBlocks(t *testing.T) {
+	type Inner struct {
+		Value string            `tfschema:"value"`
+		Tags  map[string]string `tfschema:"tags"`
+	}
+	type Type struct {
+		Set []Inner `tfschema:"set"`
+	}
+
+	set := schema.NewSet(func(input interface{}) int {
+		return schema.HashString(input.(map[string]interface{})["value"])
+	}, []interface{}{
+		map[string]interface{}{
+			"value": "first",
+			"tags": map[string]interface{}{
+				"hello": "world",
+			},
+		},
+	})
+	decodeTestData{
+		State: map[string]interface{}{
+			"set": set,
+		},
+		Input: &Type{},
+		Expected: &Type{
+			Set: []Inner{
+				{
+					Value: "first",
+					Tags: map[string]string{
+						"hello": "world",
+					},
+				},
+			},
+		},
+	}.test(t)
+}
+
 func (testData decodeTestData) test(t *testing.T) {
 	debugLogger := ConsoleLogger{}
 	state := testData.stateWrapper()
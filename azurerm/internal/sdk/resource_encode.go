@@ -8,6 +8,10 @@ import (
 // Encode will encode the specified object into the Terraform State
 // NOTE: this requires that the object passed in is a pointer and
 // all fields contain `tfschema` struct tags
+//
+// Nested blocks can be defined as a slice of structs (or pointers to structs),
+// or as a struct/pointer to a struct - which is encoded as a list containing
+// a single item. Pointers which are nil are encoded as an unset value.
 func (rmd ResourceMetaData) Encode(input interface{}) error {
 	if reflect.TypeOf(input).Kind() != reflect.Ptr {
 		return fmt.Errorf("need a pointer")
@@ -37,10 +41,10 @@ func recurse(objType reflect.Type, objVal reflect.Value, fieldName string, debug
 			debugLogger.Warnf("error setting value for %q: %+v", fieldName, r)
 			out, ok := r.(error)
 			if !ok {
-				return
+				out = fmt.Errorf("%+v", r)
 			}
 
-			errOut = out
+			errOut = fmt.Errorf("serializing %q: %+v", fieldName, out)
 		}
 	}()
 
@@ -48,94 +52,134 @@ func recurse(objType reflect.Type, objVal reflect.Value, fieldName string, debug
 	for i := 0; i < objType.NumField(); i++ {
 		field := objType.Field(i)
 		fieldVal := objVal.Field(i)
-		if tfschemaTag, exists := field.Tag.Lookup("tfschema"); exists {
-			switch field.Type.Kind() {
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				iv := fieldVal.Int()
-				debugLogger.Infof("Setting %q to %d", tfschemaTag, iv)
-				output[tfschemaTag] = iv
-
-			case reflect.Float32, reflect.Float64:
-				fv := fieldVal.Float()
-				debugLogger.Infof("Setting %q to %f", tfschemaTag, fv)
-				output[tfschemaTag] = fv
-
-			case reflect.String:
-				sv := fieldVal.String()
-				debugLogger.Infof("Setting %q to %q", tfschemaTag, sv)
-				output[tfschemaTag] = sv
-
-			case reflect.Bool:
-				bv := fieldVal.Bool()
-				debugLogger.Infof("Setting %q to %t", tfschemaTag, bv)
-				output[tfschemaTag] = bv
-
-			case reflect.Map:
-				iter := fieldVal.MapRange()
-				attr := make(map[string]interface{})
-				for iter.Next() {
-					attr[iter.Key().String()] = iter.Value().Interface()
+		if tfschemaTag, exists := tfschemaName(field); exists {
+			value, err := encodeValue(fieldVal, tfschemaTag, debugLogger)
+			if err != nil {
+				return output, err
+			}
+
+			output[tfschemaTag] = value
+		}
+	}
+
+	return output, nil
+}
+
+func encodeValue(fieldVal reflect.Value, tfschemaTag string, debugLogger Logger) (interface{}, error) {
+	switch fieldVal.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		iv := fieldVal.Int()
+		debugLogger.Infof("Setting %q to %d", tfschemaTag, iv)
+		return iv, nil
+
+	case reflect.Float32, reflect.Float64:
+		fv := fieldVal.Float()
+		debugLogger.Infof("Setting %q to %f", tfschemaTag, fv)
+		return fv, nil
+
+	case reflect.String:
+		sv := fieldVal.String()
+		debugLogger.Infof("Setting %q to %q", tfschemaTag, sv)
+		return sv, nil
+
+	case reflect.Bool:
+		bv := fieldVal.Bool()
+		debugLogger.Infof("Setting %q to %t", tfschemaTag, bv)
+		return bv, nil
+
+	case reflect.Ptr:
+		if fieldVal.IsNil() {
+			debugLogger.Infof("Setting %q to nil", tfschemaTag)
+			return nil, nil
+		}
+
+		return encodeValue(fieldVal.Elem(), tfschemaTag, debugLogger)
+
+	case reflect.Struct:
+		// a single nested block is represented in the Plugin SDK as a list containing one item
+		serialized, err := recurse(fieldVal.Type(), fieldVal, tfschemaTag, debugLogger)
+		if err != nil {
+			return nil, fmt.Errorf("serializing nested object %q: %+v", fieldVal.Type(), err)
+		}
+		return []interface{}{serialized}, nil
+
+	case reflect.Map:
+		iter := fieldVal.MapRange()
+		attr := make(map[string]interface{})
+		for iter.Next() {
+			v := iter.Value()
+			if v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					continue
 				}
-				output[tfschemaTag] = attr
-
-			case reflect.Slice:
-				sv := fieldVal.Slice(0, fieldVal.Len())
-				attr := make([]interface{}, sv.Len())
-				switch sv.Type() {
-				case reflect.TypeOf([]string{}):
-					debugLogger.Infof("Setting %q to []string", tfschemaTag)
-					if sv.Len() > 0 {
-						output[tfschemaTag] = sv.Interface()
-					} else {
-						output[tfschemaTag] = make([]string, 0)
-					}
-
-				case reflect.TypeOf([]int{}):
-					debugLogger.Infof("Setting %q to []int", tfschemaTag)
-					if sv.Len() > 0 {
-						output[tfschemaTag] = sv.Interface()
-					} else {
-						output[tfschemaTag] = make([]int, 0)
-					}
-
-				case reflect.TypeOf([]float64{}):
-					debugLogger.Infof("Setting %q to []float64", tfschemaTag)
-					if sv.Len() > 0 {
-						output[tfschemaTag] = sv.Interface()
-					} else {
-						output[tfschemaTag] = make([]float64, 0)
-					}
-
-				case reflect.TypeOf([]bool{}):
-					debugLogger.Infof("Setting %q to []bool", tfschemaTag)
-					if sv.Len() > 0 {
-						output[tfschemaTag] = sv.Interface()
-					} else {
-						output[tfschemaTag] = make([]bool, 0)
-					}
-
-				default:
-					for i := 0; i < sv.Len(); i++ {
-						debugLogger.Infof("[SLICE] Index %d is %q", i, sv.Index(i).Interface())
-						debugLogger.Infof("[SLICE] Type %+v", sv.Type())
-						nestedType := sv.Index(i).Type()
-						nestedValue := sv.Index(i)
-
-						fieldName := field.Name
-						serialized, err := recurse(nestedType, nestedValue, fieldName, debugLogger)
-						if err != nil {
-							return nil, fmt.Errorf("serializing nested object %q: %+v", sv.Type(), exists)
-						}
-						attr[i] = serialized
-					}
-					debugLogger.Infof("[SLICE] Setting %q to %+v", tfschemaTag, attr)
-					output[tfschemaTag] = attr
+				v = v.Elem()
+			}
+			attr[iter.Key().String()] = v.Interface()
+		}
+		return attr, nil
+
+	case reflect.Slice:
+		sv := fieldVal.Slice(0, fieldVal.Len())
+		switch sv.Type() {
+		case reflect.TypeOf([]string{}):
+			debugLogger.Infof("Setting %q to []string", tfschemaTag)
+			if sv.Len() > 0 {
+				return sv.Interface(), nil
+			}
+			return make([]string, 0), nil
+
+		case reflect.TypeOf([]int{}):
+			debugLogger.Infof("Setting %q to []int", tfschemaTag)
+			if sv.Len() > 0 {
+				return sv.Interface(), nil
+			}
+			return make([]int, 0), nil
+
+		case reflect.TypeOf([]float64{}):
+			debugLogger.Infof("Setting %q to []float64", tfschemaTag)
+			if sv.Len() > 0 {
+				return sv.Interface(), nil
+			}
+			return make([]float64, 0), nil
+
+		case reflect.TypeOf([]bool{}):
+			debugLogger.Infof("Setting %q to []bool", tfschemaTag)
+			if sv.Len() > 0 {
+				return sv.Interface(), nil
+			}
+			return make([]bool, 0), nil
+		}
+
+		attr := make([]interface{}, 0, sv.Len())
+		for i := 0; i < sv.Len(); i++ {
+			debugLogger.Infof("[SLICE] Index %d is %q", i, sv.Index(i).Interface())
+			debugLogger.Infof("[SLICE] Type %+v", sv.Type())
+			nestedValue := sv.Index(i)
+			if nestedValue.Kind() == reflect.Ptr {
+				if nestedValue.IsNil() {
+					continue
+				}
+				nestedValue = nestedValue.Elem()
+			}
+
+			if nestedValue.Kind() != reflect.Struct {
+				value, err := encodeValue(nestedValue, tfschemaTag, debugLogger)
+				if err != nil {
+					return nil, err
 				}
-			default:
-				return output, fmt.Errorf("unknown type %+v for key %q", field.Type.Kind(), tfschemaTag)
+				attr = append(attr, value)
+				continue
 			}
+
+			serialized, err := recurse(nestedValue.Type(), nestedValue, tfschemaTag, debugLogger)
+			if err != nil {
+				return nil, fmt.Errorf("serializing nested object %q: %+v", sv.Type(), err)
+			}
+			attr = append(attr, serialized)
 		}
+		debugLogger.Infof("[SLICE] Setting %q to %+v", tfschemaTag, attr)
+		return attr, nil
 	}
 
-	return output, nil
+	return nil, fmt.Errorf("unknown type %+v for key %q", fieldVal.Kind(), tfschemaTag)
 }
@@ -645,6 +645,182 @@ func TestResourceEncode_NestedThreeLevelsDeepMultipleItems(t *testing.T) {
 	}.test(t)
 }
 
+func TestResourceEncode_TopLevelPointers(t *testing.T) {
+	type SimpleType struct {
+		String  *string  `tfschema:"string"`
+		Number  *int     `tfschema:"number"`
+		Price   *float64 `tfschema:"price"`
+		Enabled *bool    `tfschema:"enabled"`
+	}
+
+	t.Log("Unset")
+	encodeTestData{
+		Input: &SimpleType{},
+		Expected: map[string]interface{}{
+			"string":  nil,
+			"number":  nil,
+			"price":   nil,
+			"enabled": nil,
+		},
+	}.test(t)
+
+	t.Log("Zero Values")
+	emptyString := ""
+	zero := 0
+	zeroFloat := float64(0)
+	disabled := false
+	encodeTestData{
+		Input: &SimpleType{
+			String:  &emptyString,
+			Number:  &zero,
+			Price:   &zeroFloat,
+			Enabled: &disabled,
+		},
+		Expected: map[string]interface{}{
+			"string":  "",
+			"number":  int64(0),
+			"price":   float64(0),
+			"enabled": false,
+		},
+	}.test(t)
+}
+
+func TestResourceEncode_MapsOfPrimitives(t *testing.T) {
+	type SimpleType struct {
+		MapOfBools   map[string]bool    `tfschema:"map_of_bools"`
+		MapOfFloats  map[string]float64 `tfschema:"map_of_floats"`
+		MapOfNumbers map[string]int     `tfschema:"map_of_numbers"`
+		MapOfStrings map[string]*string `tfschema:"map_of_strings"`
+	}
+	value := "world"
+	encodeTestData{
+		Input: &SimpleType{
+			MapOfBools: map[string]bool{
+				"enabled": true,
+			},
+			MapOfFloats: map[string]float64{
+				"pi": 3.14159,
+			},
+			MapOfNumbers: map[string]int{
+				"lucky": 21,
+			},
+			MapOfStrings: map[string]*string{
+				"hello": &value,
+				"unset": nil,
+			},
+		},
+		Expected: map[string]interface{}{
+			"map_of_bools": map[string]interface{}{
+				"enabled": true,
+			},
+			"map_of_floats": map[string]interface{}{
+				"pi": 3.14159,
+			},
+			"map_of_numbers": map[string]interface{}{
+				"lucky": 21,
+			},
+			"map_of_strings": map[string]interface{}{
+				"hello": "world",
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceEncode_NestedSingleBlock(t *testing.T) {
+	type Inner struct {
+		Value  string `tfschema:"value"`
+		Number *int   `tfschema:"number"`
+	}
+	type Type struct {
+		Block    Inner  `tfschema:"block"`
+		Optional *Inner `tfschema:"optional"`
+	}
+
+	t.Log("Empty")
+	encodeTestData{
+		Input: &Type{},
+		Expected: map[string]interface{}{
+			"block": []interface{}{
+				map[string]interface{}{
+					"value":  "",
+					"number": nil,
+				},
+			},
+			"optional": nil,
+		},
+	}.test(t)
+
+	t.Log("Populated")
+	number := 42
+	encodeTestData{
+		Input: &Type{
+			Block: Inner{
+				Value:  "first",
+				Number: &number,
+			},
+			Optional: &Inner{
+				Value: "second",
+			},
+		},
+		Expected: map[string]interface{}{
+			"block": []interface{}{
+				map[string]interface{}{
+					"value":  "first",
+					"number": int64(42),
+				},
+			},
+			"optional": []interface{}{
+				map[string]interface{}{
+					"value":  "second",
+					"number": nil,
+				},
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceEncode_NestedPointers(t *testing.T) {
+	type Inner struct {
+		Value string `tfschema:"value"`
+	}
+	type Type struct {
+		List []*Inner `tfschema:"list"`
+	}
+	encodeTestData{
+		Input: &Type{
+			List: []*Inner{
+				{
+					Value: "first",
+				},
+				nil,
+				{
+					Value: "second",
+				},
+			},
+		},
+		Expected: map[string]interface{}{
+			"list": []interface{}{
+				map[string]interface{}{
+					"value": "first",
+				},
+				map[string]interface{}{
+					"value": "second",
+				},
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceEncode_UnsupportedType(t *testing.T) {
+	type Type struct {
+		Channel chan string `tfschema:"channel"`
+	}
+	encodeTestData{
+		Input:       &Type{},
+		ExpectError: true,
+	}.test(t)
+}
+
 func (testData encodeTestData) test(t *testing.T) {
 	objType := reflect.TypeOf(testData.Input).Elem()
 	objVal := reflect.ValueOf(testData.Input).Elem()
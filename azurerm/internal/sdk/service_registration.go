@@ -22,6 +22,15 @@ type TypedServiceRegistration interface {
 	WebsiteCategories() []string
 }
 
+// TypedServiceRegistrationWithListDataSources is an optional interface for a TypedServiceRegistration
+// which also supports List Data Sources (e.g. Data Sources which return multiple items)
+type TypedServiceRegistrationWithListDataSources interface {
+	TypedServiceRegistration
+
+	// ListDataSources returns a list of List Data Sources supported by this Service
+	ListDataSources() []ListDataSource
+}
+
 // UntypedServiceRegistration is the interface used for untyped/raw Plugin SDK resources
 // in the future this'll be superseded by the TypedServiceRegistration which allows for
 // stronger Typed resources to be used.
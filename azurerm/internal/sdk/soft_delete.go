@@ -0,0 +1,95 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
+)
+
+// SoftDeletedResource is implemented for Resources which are Soft Deleted (rather than being permanently
+// deleted) when they're deleted, allowing the Soft Deleted instance of the Resource to be found, recovered
+// and purged - the behaviour of which is controlled by a `features.SoftDeleteFeatures` for each Resource.
+type SoftDeletedResource interface {
+	// FindSoftDeleted returns whether a Soft Deleted instance of this Resource exists
+	FindSoftDeleted(ctx context.Context) (bool, error)
+
+	// RecoverSoftDeleted recovers the Soft Deleted instance of this Resource, waiting for this to complete
+	RecoverSoftDeleted(ctx context.Context) error
+
+	// PurgeSoftDeleted permanently deletes the Soft Deleted instance of this Resource, waiting for this to complete
+	PurgeSoftDeleted(ctx context.Context) error
+}
+
+// RecoverSoftDeletedOnCreate should be called prior to creating a Resource which supports Soft Delete (once it's
+// been confirmed that the Resource doesn't already exist) - when a Soft Deleted instance of this Resource exists
+// this is either recovered (returning true) when the `recover_soft_deleted` feature is enabled for this Resource,
+// or otherwise an error is returned, since the Resource can't be created until this is either recovered or purged.
+// When recovering is disabled a failure to check for a Soft Deleted instance is logged rather than returned, since
+// any conflict will be surfaced by Azure when creating the Resource.
+//
+// The `featureField` is the path to the field within the `features` block which enables recovering this Resource
+// (for example `api_management.recover_soft_deleted`) and the `description` is used in log and error messages, for
+// example `API Management Service "example" (Resource Group "group1")`
+func RecoverSoftDeletedOnCreate(ctx context.Context, description string, featureField string, options features.SoftDeleteFeatures, resource SoftDeletedResource) (bool, error) {
+	log.Printf("[DEBUG] Checking for a Soft Deleted %s..", description)
+	found, err := resource.FindSoftDeleted(ctx)
+	if err != nil {
+		if !options.RecoverSoftDeleted {
+			log.Printf("[DEBUG] Unable to check for a Soft Deleted %s (skipping since recovering these is disabled): %+v", description, err)
+			return false, nil
+		}
+		return false, fmt.Errorf("checking for a Soft Deleted %s: %+v", description, err)
+	}
+	if !found {
+		return false, nil
+	}
+
+	if !options.RecoverSoftDeleted {
+		return false, fmt.Errorf(`a Soft Deleted %s exists which needs to be recovered or purged before it can be created.
+
+To recover this Terraform can do so automatically by setting the field '%s' within the
+'features' block of the Provider to 'true' - alternatively this can be purged (permanently deleted)
+using the Azure Portal or Azure CLI`, description, featureField)
+	}
+
+	log.Printf("[DEBUG] Recovering the Soft Deleted %s..", description)
+	if err := resource.RecoverSoftDeleted(ctx); err != nil {
+		return false, fmt.Errorf("recovering the Soft Deleted %s: %+v", description, err)
+	}
+	log.Printf("[DEBUG] Recovered the Soft Deleted %s.", description)
+
+	return true, nil
+}
+
+// PurgeSoftDeletedOnDestroy should be called once a Resource which supports Soft Delete has been deleted, which
+// purges (that is, permanently deletes) the Soft Deleted instance of the Resource when the `purge_soft_delete_on_destroy`
+// feature is enabled for this Resource.
+//
+// Since the Soft Deleted instance of the Resource is available once the deletion has completed, this is expected to be
+// called once any Long Running Operation for the deletion has completed - not all instances of a Resource are necessarily
+// Soft Deleted (for example depending on the SKU or API Version used), as such it's not an error if this isn't found.
+func PurgeSoftDeletedOnDestroy(ctx context.Context, description string, options features.SoftDeleteFeatures, resource SoftDeletedResource) error {
+	if !options.PurgeSoftDeleteOnDestroy {
+		log.Printf("[DEBUG] Skipping purging the Soft Deleted %s as opted-out..", description)
+		return nil
+	}
+
+	found, err := resource.FindSoftDeleted(ctx)
+	if err != nil {
+		return fmt.Errorf("checking for a Soft Deleted %s: %+v", description, err)
+	}
+	if !found {
+		log.Printf("[DEBUG] No Soft Deleted %s was found - skipping purging", description)
+		return nil
+	}
+
+	log.Printf("[DEBUG] Purging the Soft Deleted %s..", description)
+	if err := resource.PurgeSoftDeleted(ctx); err != nil {
+		return fmt.Errorf("purging the Soft Deleted %s: %+v", description, err)
+	}
+	log.Printf("[DEBUG] Purged the Soft Deleted %s.", description)
+
+	return nil
+}
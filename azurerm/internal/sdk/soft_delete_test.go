@@ -0,0 +1,151 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
+)
+
+type testSoftDeletedResource struct {
+	softDeleted bool
+	recovered   bool
+	purged      bool
+	findErr     error
+}
+
+func (r *testSoftDeletedResource) FindSoftDeleted(_ context.Context) (bool, error) {
+	if r.findErr != nil {
+		return false, r.findErr
+	}
+	return r.softDeleted, nil
+}
+
+func (r *testSoftDeletedResource) RecoverSoftDeleted(_ context.Context) error {
+	r.softDeleted = false
+	r.recovered = true
+	return nil
+}
+
+func (r *testSoftDeletedResource) PurgeSoftDeleted(_ context.Context) error {
+	r.softDeleted = false
+	r.purged = true
+	return nil
+}
+
+func TestRecoverSoftDeletedOnCreate(t *testing.T) {
+	testData := []struct {
+		Name              string
+		SoftDeleted       bool
+		LookupFails       bool
+		RecoverEnabled    bool
+		ExpectedRecovered bool
+		ExpectError       bool
+	}{
+		{
+			Name:              "Not Soft Deleted",
+			SoftDeleted:       false,
+			RecoverEnabled:    true,
+			ExpectedRecovered: false,
+		},
+		{
+			Name:              "Soft Deleted and Recover Enabled",
+			SoftDeleted:       true,
+			RecoverEnabled:    true,
+			ExpectedRecovered: true,
+		},
+		{
+			Name:           "Soft Deleted and Recover Disabled",
+			SoftDeleted:    true,
+			RecoverEnabled: false,
+			ExpectError:    true,
+		},
+		{
+			Name:              "Lookup Failed and Recover Disabled",
+			LookupFails:       true,
+			RecoverEnabled:    false,
+			ExpectedRecovered: false,
+		},
+		{
+			Name:           "Lookup Failed and Recover Enabled",
+			LookupFails:    true,
+			RecoverEnabled: true,
+			ExpectError:    true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		resource := &testSoftDeletedResource{
+			softDeleted: v.SoftDeleted,
+		}
+		if v.LookupFails {
+			resource.findErr = fmt.Errorf("unexpected status 500")
+		}
+		options := features.SoftDeleteFeatures{
+			RecoverSoftDeleted: v.RecoverEnabled,
+		}
+		recovered, err := RecoverSoftDeletedOnCreate(context.TODO(), "Example Resource", "example.recover_soft_deleted", options, resource)
+		if v.ExpectError {
+			if err == nil {
+				t.Fatalf("expected an error but didn't get one")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if recovered != v.ExpectedRecovered || resource.recovered != v.ExpectedRecovered {
+			t.Fatalf("expected recovered to be %t but got %t (resource %t)", v.ExpectedRecovered, recovered, resource.recovered)
+		}
+	}
+}
+
+func TestPurgeSoftDeletedOnDestroy(t *testing.T) {
+	testData := []struct {
+		Name           string
+		SoftDeleted    bool
+		PurgeEnabled   bool
+		ExpectedPurged bool
+	}{
+		{
+			Name:           "Not Soft Deleted",
+			SoftDeleted:    false,
+			PurgeEnabled:   true,
+			ExpectedPurged: false,
+		},
+		{
+			Name:           "Soft Deleted and Purge Enabled",
+			SoftDeleted:    true,
+			PurgeEnabled:   true,
+			ExpectedPurged: true,
+		},
+		{
+			Name:           "Soft Deleted and Purge Disabled",
+			SoftDeleted:    true,
+			PurgeEnabled:   false,
+			ExpectedPurged: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		resource := &testSoftDeletedResource{
+			softDeleted: v.SoftDeleted,
+		}
+		options := features.SoftDeleteFeatures{
+			PurgeSoftDeleteOnDestroy: v.PurgeEnabled,
+		}
+		if err := PurgeSoftDeletedOnDestroy(context.TODO(), "Example Resource", options, resource); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if resource.purged != v.ExpectedPurged {
+			t.Fatalf("expected purged to be %t but got %t", v.ExpectedPurged, resource.purged)
+		}
+	}
+}
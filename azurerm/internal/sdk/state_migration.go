@@ -0,0 +1,139 @@
+package sdk
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/resourceid"
+)
+
+// StateUpgradeData defines the current Schema Version for a Resource and the State Upgraders
+// which should be used to upgrade the State from each previous Schema Version
+type StateUpgradeData struct {
+	// SchemaVersion is the current version of the Schema for this Resource
+	SchemaVersion int
+
+	// Upgraders is a map of Schema Version to the StateUpgrade which migrates the State
+	// from that version to the next one (e.g. the StateUpgrade for version 0 migrates to version 1)
+	Upgraders map[int]StateUpgrade
+}
+
+// StateUpgrade defines a single migration of the State from one Schema Version to the next
+type StateUpgrade interface {
+	// Schema is the Schema for the Resource at the Schema Version being upgraded from
+	Schema() map[string]*schema.Schema
+
+	// UpgradeFunc returns the function which performs the migration of the State
+	UpgradeFunc() schema.StateUpgradeFunc
+}
+
+// ResourceIDParser parses the specified Resource ID (which may have been stored in a different
+// casing) into a Formatter, which can be used to obtain the Resource ID in its canonical form
+//
+// Example Usage:
+//
+//	var parser = func(input string) (resourceid.Formatter, error) {
+//		return parse.ResourceGroupIDInsensitively(input)
+//	}
+type ResourceIDParser func(input string) (resourceid.Formatter, error)
+
+// ResourceIDStateUpgradeFunc returns a StateUpgradeFunc which re-parses the Resource ID stored in
+// the `id` field using the specified parser, and rewrites it using the canonical casing
+func ResourceIDStateUpgradeFunc(parser ResourceIDParser) schema.StateUpgradeFunc {
+	return ResourceIDFieldsStateUpgradeFunc(map[string]ResourceIDParser{
+		"id": parser,
+	})
+}
+
+// ResourceIDFieldsStateUpgradeFunc returns a StateUpgradeFunc which re-parses the Resource ID stored
+// in each of the specified fields using the associated parser, and rewrites these using the canonical
+// casing. The `id` field must be present within the State, however any other fields which are not
+// present or are empty within the State are skipped.
+func ResourceIDFieldsStateUpgradeFunc(parsers map[string]ResourceIDParser) schema.StateUpgradeFunc {
+	return func(rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+		for field, parser := range parsers {
+			oldId, ok := rawState[field].(string)
+			if !ok || oldId == "" {
+				if field == "id" {
+					return nil, fmt.Errorf("the `id` field was not found within the State")
+				}
+
+				continue
+			}
+
+			id, err := parser(oldId)
+			if err != nil {
+				return nil, fmt.Errorf("parsing %q for %q: %+v", oldId, field, err)
+			}
+			newId := id.ID()
+
+			log.Printf("[DEBUG] Updating %q from %q to %q", field, oldId, newId)
+			rawState[field] = newId
+		}
+
+		return rawState, nil
+	}
+}
+
+// ResourceIDStateUpgrade is a StateUpgrade which rewrites the Resource ID for a Resource
+// into its canonical casing, for use where a Resource has previously stored an ID using
+// an incorrect casing (for example, as returned from the Azure API)
+type ResourceIDStateUpgrade struct {
+	parser ResourceIDParser
+	schema map[string]*schema.Schema
+}
+
+// NewResourceIDStateUpgrade returns a ResourceIDStateUpgrade which uses the specified parser
+// to rewrite the Resource ID - where resourceSchema is the Schema at the version being upgraded from
+func NewResourceIDStateUpgrade(resourceSchema map[string]*schema.Schema, parser ResourceIDParser) ResourceIDStateUpgrade {
+	return ResourceIDStateUpgrade{
+		parser: parser,
+		schema: resourceSchema,
+	}
+}
+
+// Schema is the Schema for the Resource at the Schema Version being upgraded from
+func (u ResourceIDStateUpgrade) Schema() map[string]*schema.Schema {
+	return u.schema
+}
+
+// UpgradeFunc returns the function which rewrites the Resource ID
+func (u ResourceIDStateUpgrade) UpgradeFunc() schema.StateUpgradeFunc {
+	return ResourceIDStateUpgradeFunc(u.parser)
+}
+
+// buildStateUpgraders converts the StateUpgradeData into the StateUpgraders used by the Plugin SDK,
+// ensuring that an upgrader is defined for every previous Schema Version
+func buildStateUpgraders(data StateUpgradeData) ([]schema.StateUpgrader, error) {
+	versions := make([]int, 0, len(data.Upgraders))
+	for version := range data.Upgraders {
+		if version < 0 || version >= data.SchemaVersion {
+			return nil, fmt.Errorf("a State Upgrader is defined for version %d but the current Schema Version is %d", version, data.SchemaVersion)
+		}
+
+		versions = append(versions, version)
+	}
+	sort.Ints(versions)
+
+	if len(versions) != data.SchemaVersion {
+		return nil, fmt.Errorf("expected %d State Upgraders for Schema Version %d but got %d", data.SchemaVersion, data.SchemaVersion, len(versions))
+	}
+
+	upgraders := make([]schema.StateUpgrader, 0, len(versions))
+	for _, version := range versions {
+		upgrade := data.Upgraders[version]
+		resource := &schema.Resource{
+			Schema: upgrade.Schema(),
+		}
+
+		upgraders = append(upgraders, schema.StateUpgrader{
+			Type:    resource.CoreConfigSchema().ImpliedType(),
+			Upgrade: upgrade.UpgradeFunc(),
+			Version: version,
+		})
+	}
+
+	return upgraders, nil
+}
@@ -0,0 +1,212 @@
+package sdk
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/resourceid"
+)
+
+type testResourceGroupId struct {
+	SubscriptionId string
+	Name           string
+}
+
+func (id testResourceGroupId) ID() string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", id.SubscriptionId, id.Name)
+}
+
+func testResourceGroupIDInsensitively(input string) (resourceid.Formatter, error) {
+	segments := strings.Split(strings.TrimPrefix(input, "/"), "/")
+	if len(segments) != 4 || !strings.EqualFold(segments[0], "subscriptions") || !strings.EqualFold(segments[2], "resourceGroups") {
+		return nil, fmt.Errorf("%q is not a Resource Group ID", input)
+	}
+
+	return testResourceGroupId{
+		SubscriptionId: segments[1],
+		Name:           segments[3],
+	}, nil
+}
+
+func TestResourceIDStateUpgradeFunc(t *testing.T) {
+	testData := []struct {
+		Name        string
+		Input       map[string]interface{}
+		Expected    map[string]interface{}
+		ExpectError bool
+	}{
+		{
+			Name: "Canonical Casing",
+			Input: map[string]interface{}{
+				"id":   "/subscriptions/1234/resourceGroups/group1",
+				"name": "group1",
+			},
+			Expected: map[string]interface{}{
+				"id":   "/subscriptions/1234/resourceGroups/group1",
+				"name": "group1",
+			},
+		},
+		{
+			Name: "Incorrect Casing",
+			Input: map[string]interface{}{
+				"id":   "/Subscriptions/1234/resourcegroups/group1",
+				"name": "group1",
+			},
+			Expected: map[string]interface{}{
+				"id":   "/subscriptions/1234/resourceGroups/group1",
+				"name": "group1",
+			},
+		},
+		{
+			Name: "Invalid ID",
+			Input: map[string]interface{}{
+				"id": "/subscriptions/1234",
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "Empty ID",
+			Input: map[string]interface{}{
+				"id": "",
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "Missing ID",
+			Input: map[string]interface{}{
+				"name": "group1",
+			},
+			ExpectError: true,
+		},
+	}
+
+	upgrade := ResourceIDStateUpgradeFunc(testResourceGroupIDInsensitively)
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual, err := upgrade(v.Input, nil)
+		if err != nil {
+			if v.ExpectError {
+				continue
+			}
+
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.ExpectError {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestResourceIDFieldsStateUpgradeFunc(t *testing.T) {
+	upgrade := ResourceIDFieldsStateUpgradeFunc(map[string]ResourceIDParser{
+		"id":                testResourceGroupIDInsensitively,
+		"resource_group_id": testResourceGroupIDInsensitively,
+		"optional_id":       testResourceGroupIDInsensitively,
+	})
+
+	actual, err := upgrade(map[string]interface{}{
+		"id":                "/subscriptions/1234/resourcegroups/group1",
+		"resource_group_id": "/SUBSCRIPTIONS/1234/RESOURCEGROUPS/group2",
+		"optional_id":       "",
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	expected := map[string]interface{}{
+		"id":                "/subscriptions/1234/resourceGroups/group1",
+		"resource_group_id": "/subscriptions/1234/resourceGroups/group2",
+		"optional_id":       "",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestBuildStateUpgraders(t *testing.T) {
+	upgrade := NewResourceIDStateUpgrade(map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}, testResourceGroupIDInsensitively)
+
+	testData := []struct {
+		Name        string
+		Input       StateUpgradeData
+		Expected    []int
+		ExpectError bool
+	}{
+		{
+			Name: "No Upgraders",
+			Input: StateUpgradeData{
+				SchemaVersion: 0,
+			},
+			Expected: []int{},
+		},
+		{
+			Name: "Sequential Upgraders",
+			Input: StateUpgradeData{
+				SchemaVersion: 2,
+				Upgraders: map[int]StateUpgrade{
+					1: upgrade,
+					0: upgrade,
+				},
+			},
+			Expected: []int{0, 1},
+		},
+		{
+			Name: "Missing Upgrader",
+			Input: StateUpgradeData{
+				SchemaVersion: 2,
+				Upgraders: map[int]StateUpgrade{
+					1: upgrade,
+				},
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "Upgrader for the Current Version",
+			Input: StateUpgradeData{
+				SchemaVersion: 1,
+				Upgraders: map[int]StateUpgrade{
+					0: upgrade,
+					1: upgrade,
+				},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		upgraders, err := buildStateUpgraders(v.Input)
+		if err != nil {
+			if v.ExpectError {
+				continue
+			}
+
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.ExpectError {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		actual := make([]int, 0)
+		for _, upgrader := range upgraders {
+			actual = append(actual, upgrader.Version)
+		}
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected versions %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package sdk
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -25,14 +24,15 @@ func NewDataSourceWrapper(dataSource DataSource) DataSourceWrapper {
 
 // DataSource returns the Terraform Plugin SDK type for this DataSource implementation
 func (rw *DataSourceWrapper) DataSource() (*schema.Resource, error) {
-	resourceSchema, err := combineSchema(rw.dataSource.Arguments(), rw.dataSource.Attributes())
-	if err != nil {
-		return nil, fmt.Errorf("building Schema: %+v", err)
+	var validators map[string]schema.SchemaValidateFunc
+	v, generate := rw.dataSource.(DataSourceWithGeneratedSchema)
+	if generate {
+		validators = v.SchemaValidators()
 	}
 
-	modelObj := rw.dataSource.ModelObject()
-	if err := ValidateModelObject(&modelObj); err != nil {
-		return nil, fmt.Errorf("validating model for %q: %+v", rw.dataSource.ResourceType(), err)
+	resourceSchema, err := buildSchema(rw.dataSource, generate, validators)
+	if err != nil {
+		return nil, err
 	}
 
 	var d = func(duration time.Duration) *time.Duration {
@@ -42,7 +42,7 @@ func (rw *DataSourceWrapper) DataSource() (*schema.Resource, error) {
 	resource := schema.Resource{
 		Schema: *resourceSchema,
 		Read: func(d *schema.ResourceData, meta interface{}) error {
-			ctx, metaData := runArgs(d, meta, rw.logger)
+			ctx, metaData := runArgs(d, meta, rw.logger, rw.dataSource.ResourceType(), operationRead)
 			wrappedCtx, cancel := timeouts.ForRead(ctx, d)
 			defer cancel()
 			return rw.dataSource.Read().Func(wrappedCtx, metaData)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
 )
 
 // combineSchema combines the arguments (user-configurable) and attributes (read-only) schema fields
@@ -45,16 +46,88 @@ func combineSchema(arguments map[string]*schema.Schema, attributes map[string]*s
 	return &out, nil
 }
 
-func runArgs(d *schema.ResourceData, meta interface{}, logger Logger) (context.Context, ResourceMetaData) {
+// buildSchema returns the Schema for the specified Data Source/Resource - which is either generated from
+// the ModelObject (when generate is true) or built from the Arguments and Attributes, in which case
+// these are validated against the ModelObject to ensure the types and shape of each field match
+func buildSchema(base resourceBase, generate bool, validators map[string]schema.SchemaValidateFunc) (*map[string]*schema.Schema, error) {
+	modelObj := base.ModelObject()
+	if err := ValidateModelObject(&modelObj); err != nil {
+		return nil, fmt.Errorf("validating model for %q: %+v", base.ResourceType(), err)
+	}
+
+	arguments := base.Arguments()
+	attributes := base.Attributes()
+	if generate {
+		if len(arguments) > 0 || len(attributes) > 0 {
+			return nil, fmt.Errorf("%q generates the Schema from the model so the Arguments and Attributes must be empty", base.ResourceType())
+		}
+
+		var err error
+		arguments, attributes, err = SchemaFromModel(modelObj, validators)
+		if err != nil {
+			return nil, fmt.Errorf("generating Schema from the model for %q: %+v", base.ResourceType(), err)
+		}
+	}
+
+	resourceSchema, err := combineSchema(arguments, attributes)
+	if err != nil {
+		return nil, fmt.Errorf("building Schema: %+v", err)
+	}
+
+	if err := ValidateModelAgainstSchema(modelObj, *resourceSchema); err != nil {
+		return nil, fmt.Errorf("validating model against the Schema for %q: %+v", base.ResourceType(), err)
+	}
+
+	return resourceSchema, nil
+}
+
+const (
+	operationCreate        = "create"
+	operationRead          = "read"
+	operationUpdate        = "update"
+	operationDelete        = "delete"
+	operationImport        = "import"
+	operationCustomizeDiff = "customize_diff"
+)
+
+// operationLogger returns a Logger which includes the fields required to identify the
+// log messages from this operation - namely the Resource Type, the Resource ID (which is
+// evaluated when each message is written, since this is unknown prior to creation),
+// the operation and the Correlation Request ID sent to Azure
+func operationLogger(logger Logger, resourceType, operation string, id func() string) Logger {
+	return logger.WithFields(
+		"resource_type", resourceType,
+		"resource_id", LazyLogValue(func() interface{} {
+			return id()
+		}),
+		"operation", operation,
+		"correlation_request_id", common.CorrelationRequestID(),
+	)
+}
+
+func runArgs(d *schema.ResourceData, meta interface{}, logger Logger, resourceType, operation string) (context.Context, ResourceMetaData) {
 	// NOTE: this is wrapped as a result of this function, so this is "fine" being unwrapped
 	stopContext := meta.(*clients.Client).StopContext
 	client := meta.(*clients.Client)
 	metaData := ResourceMetaData{
 		Client:                   client,
-		Logger:                   logger,
+		Logger:                   operationLogger(logger, resourceType, operation, d.Id),
 		ResourceData:             d,
 		serializationDebugLogger: NullLogger{},
 	}
 
 	return stopContext, metaData
 }
+
+func runDiffArgs(d *schema.ResourceDiff, meta interface{}, logger Logger, resourceType string) (context.Context, ResourceMetaData) {
+	stopContext := meta.(*clients.Client).StopContext
+	client := meta.(*clients.Client)
+	metaData := ResourceMetaData{
+		Client:                   client,
+		Logger:                   operationLogger(logger, resourceType, operationCustomizeDiff, d.Id),
+		ResourceDiff:             d,
+		serializationDebugLogger: NullLogger{},
+	}
+
+	return stopContext, metaData
+}
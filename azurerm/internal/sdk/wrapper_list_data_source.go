@@ -0,0 +1,265 @@
+package sdk
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+)
+
+// ListDataSourceWrapper is a wrapper for converting a ListDataSource implementation
+// into the object used by the Terraform Plugin SDK
+type ListDataSourceWrapper struct {
+	dataSource ListDataSource
+	logger     Logger
+}
+
+// NewListDataSourceWrapper returns a ListDataSourceWrapper for this List Data Source implementation
+func NewListDataSourceWrapper(dataSource ListDataSource) ListDataSourceWrapper {
+	return ListDataSourceWrapper{
+		dataSource: dataSource,
+		logger:     ConsoleLogger{},
+	}
+}
+
+// DataSource returns the Terraform Plugin SDK type for this ListDataSource implementation
+func (rw *ListDataSourceWrapper) DataSource() (*schema.Resource, error) {
+	resourceSchema, err := rw.schema()
+	if err != nil {
+		return nil, fmt.Errorf("building Schema for %q: %+v", rw.dataSource.ResourceType(), err)
+	}
+
+	var d = func(duration time.Duration) *time.Duration {
+		return &duration
+	}
+
+	resource := schema.Resource{
+		Schema: resourceSchema,
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			ctx, metaData := runArgs(d, meta, rw.logger, rw.dataSource.ResourceType(), operationRead)
+			wrappedCtx, cancel := timeouts.ForRead(ctx, d)
+			defer cancel()
+
+			filters, err := expandListFilters(d)
+			if err != nil {
+				return err
+			}
+
+			items := make([]map[string]interface{}, 0)
+			err = rw.dataSource.ListPages(wrappedCtx, metaData, filters, func(page []interface{}) error {
+				for _, item := range page {
+					encoded, err := encodeListItem(item)
+					if err != nil {
+						return err
+					}
+
+					matches, err := filters.Matches(encoded)
+					if err != nil {
+						return err
+					}
+					if matches {
+						items = append(items, encoded)
+					}
+				}
+
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("listing items for %q: %+v", rw.dataSource.ResourceType(), err)
+			}
+
+			return flattenListItems(d, rw.dataSource.ItemsAttribute(), items, filters.MaxResults)
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Read: d(rw.dataSource.ReadTimeout()),
+		},
+	}
+
+	return &resource, nil
+}
+
+func (rw *ListDataSourceWrapper) schema() (map[string]*schema.Schema, error) {
+	modelObj := rw.dataSource.ModelObject()
+	if err := ValidateModelObject(&modelObj); err != nil {
+		return nil, fmt.Errorf("validating model: %+v", err)
+	}
+
+	itemSchema, err := computedSchemaFromModel(modelObj)
+	if err != nil {
+		return nil, fmt.Errorf("generating Schema from the model: %+v", err)
+	}
+	for _, key := range []string{"id", "name"} {
+		if v, ok := itemSchema[key]; !ok || v.Type != schema.TypeString {
+			return nil, fmt.Errorf("the model must contain a %q field of type string", key)
+		}
+	}
+	if v, ok := itemSchema["tags"]; ok && v.Type != schema.TypeMap {
+		return nil, fmt.Errorf("the %q field within the model must be a map", "tags")
+	}
+
+	out := map[string]*schema.Schema{
+		"filter": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+					"values": {
+						Type:     schema.TypeList,
+						Required: true,
+						MinItems: 1,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+
+		"tags": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+
+		"name_regex": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsValidRegExp,
+		},
+
+		"max_results": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+
+		"ids": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+
+	itemsAttribute := rw.dataSource.ItemsAttribute()
+	if _, exists := out[itemsAttribute]; exists || itemsAttribute == "" {
+		return nil, fmt.Errorf("%q cannot be used as the name of the Items Attribute", itemsAttribute)
+	}
+	out[itemsAttribute] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: itemSchema,
+		},
+	}
+
+	for k, v := range rw.dataSource.Arguments() {
+		if _, exists := out[k]; exists {
+			return nil, fmt.Errorf("%q is already defined by the List Data Source wrapper", k)
+		}
+		if v.Computed && !(v.Optional || v.Required) {
+			return nil, fmt.Errorf("%q is a Computed-only field - which isn't supported for a List Data Source", k)
+		}
+
+		out[k] = v
+	}
+
+	return out, nil
+}
+
+func expandListFilters(d *schema.ResourceData) (ListFilters, error) {
+	filters := ListFilters{
+		Filters:    make([]ListFilter, 0),
+		Tags:       make(map[string]string),
+		MaxResults: d.Get("max_results").(int),
+	}
+
+	for _, raw := range d.Get("filter").(*schema.Set).List() {
+		v := raw.(map[string]interface{})
+		values := make([]string, 0)
+		for _, value := range v["values"].([]interface{}) {
+			values = append(values, value.(string))
+		}
+
+		filters.Filters = append(filters.Filters, ListFilter{
+			Name:   v["name"].(string),
+			Values: values,
+		})
+	}
+
+	for k, v := range d.Get("tags").(map[string]interface{}) {
+		filters.Tags[k] = v.(string)
+	}
+
+	if v := d.Get("name_regex").(string); v != "" {
+		expr, err := regexp.Compile(v)
+		if err != nil {
+			return filters, fmt.Errorf("compiling `name_regex` %q: %+v", v, err)
+		}
+		filters.NameRegex = expr
+	}
+
+	return filters, nil
+}
+
+func encodeListItem(item interface{}) (map[string]interface{}, error) {
+	val := reflect.ValueOf(item)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected each item to be a struct but got %T", item)
+	}
+
+	return recurse(val.Type(), val, val.Type().Name(), NullLogger{})
+}
+
+// flattenListItems sorts the items by their ID (so that the results are stable across runs),
+// applies the maximum number of results and then sets these into the State
+func flattenListItems(d *schema.ResourceData, itemsAttribute string, items []map[string]interface{}, maxResults int) error {
+	sort.SliceStable(items, func(i, j int) bool {
+		first, _ := items[i]["id"].(string)
+		second, _ := items[j]["id"].(string)
+		return strings.ToLower(first) < strings.ToLower(second)
+	})
+
+	if maxResults > 0 && len(items) > maxResults {
+		items = items[0:maxResults]
+	}
+
+	ids := make([]string, 0, len(items))
+	output := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		id, _ := item["id"].(string)
+		ids = append(ids, id)
+		output = append(output, item)
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(ids, ","))))
+
+	if err := d.Set("ids", ids); err != nil {
+		return fmt.Errorf("setting `ids`: %+v", err)
+	}
+
+	if err := d.Set(itemsAttribute, output); err != nil {
+		return fmt.Errorf("setting `%s`: %+v", itemsAttribute, err)
+	}
+
+	return nil
+}
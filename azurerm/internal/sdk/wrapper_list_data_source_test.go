@@ -0,0 +1,355 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+)
+
+type listDataSourceModel struct {
+	Id       string            `tfschema:"id"`
+	Name     string            `tfschema:"name"`
+	Location string            `tfschema:"location"`
+	Zones    []string          `tfschema:"zones"`
+	Tags     map[string]string `tfschema:"tags"`
+}
+
+type listDataSource struct{}
+
+func (ds listDataSource) Arguments() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"resource_group_name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}
+}
+
+func (ds listDataSource) ModelObject() interface{} {
+	return listDataSourceModel{}
+}
+
+func (ds listDataSource) ResourceType() string {
+	return "validator_list"
+}
+
+func (ds listDataSource) ItemsAttribute() string {
+	return "items"
+}
+
+func (ds listDataSource) ReadTimeout() time.Duration {
+	return 5 * time.Minute
+}
+
+func (ds listDataSource) ListPages(ctx context.Context, metadata ResourceMetaData, filters ListFilters, yield ListPageFunc) error {
+	resourceGroup := metadata.ResourceData.Get("resource_group_name").(string)
+	id := func(name string) string {
+		return fmt.Sprintf("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/%s/providers/Microsoft.Example/items/%s", resourceGroup, name)
+	}
+
+	pages := [][]interface{}{
+		{
+			listDataSourceModel{
+				Id:       id("zulu"),
+				Name:     "zulu",
+				Location: "westeurope",
+				Zones:    []string{"1"},
+				Tags: map[string]string{
+					"env": "prod",
+				},
+			},
+			&listDataSourceModel{
+				Id:       id("alpha"),
+				Name:     "alpha",
+				Location: "westeurope",
+				Zones:    []string{"2"},
+				Tags: map[string]string{
+					"env": "dev",
+				},
+			},
+		},
+		{
+			listDataSourceModel{
+				Id:       id("bravo"),
+				Name:     "bravo",
+				Location: "eastus",
+				Zones:    []string{"1", "2"},
+				Tags: map[string]string{
+					"env": "prod",
+				},
+			},
+		},
+	}
+
+	for _, page := range pages {
+		if err := yield(page); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestListFiltersMatches(t *testing.T) {
+	item := map[string]interface{}{
+		"id":       "/items/example",
+		"name":     "example",
+		"location": "westeurope",
+		"enabled":  true,
+		"zones":    []string{"1", "2"},
+		"tags": map[string]interface{}{
+			"env": "prod",
+		},
+		"nested": []interface{}{},
+	}
+
+	testData := []struct {
+		Name        string
+		Filters     ListFilters
+		Expected    bool
+		ExpectError bool
+	}{
+		{
+			Name:     "No Filters",
+			Filters:  ListFilters{},
+			Expected: true,
+		},
+		{
+			Name: "Matching Filter",
+			Filters: ListFilters{
+				Filters: []ListFilter{
+					{
+						Name:   "location",
+						Values: []string{"eastus", "WestEurope"},
+					},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Name: "Non-Matching Filter",
+			Filters: ListFilters{
+				Filters: []ListFilter{
+					{
+						Name:   "location",
+						Values: []string{"eastus"},
+					},
+				},
+			},
+			Expected: false,
+		},
+		{
+			Name: "Matching Bool and List Filters",
+			Filters: ListFilters{
+				Filters: []ListFilter{
+					{
+						Name:   "enabled",
+						Values: []string{"true"},
+					},
+					{
+						Name:   "zones",
+						Values: []string{"2"},
+					},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Name: "Unknown Filter",
+			Filters: ListFilters{
+				Filters: []ListFilter{
+					{
+						Name:   "does_not_exist",
+						Values: []string{"value"},
+					},
+				},
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "Unsupported Filter",
+			Filters: ListFilters{
+				Filters: []ListFilter{
+					{
+						Name:   "nested",
+						Values: []string{"value"},
+					},
+				},
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "Matching Tags",
+			Filters: ListFilters{
+				Tags: map[string]string{
+					"env": "prod",
+				},
+			},
+			Expected: true,
+		},
+		{
+			Name: "Non-Matching Tags",
+			Filters: ListFilters{
+				Tags: map[string]string{
+					"env": "dev",
+				},
+			},
+			Expected: false,
+		},
+		{
+			Name: "Matching Name Regex",
+			Filters: ListFilters{
+				NameRegex: regexp.MustCompile("^ex"),
+			},
+			Expected: true,
+		},
+		{
+			Name: "Non-Matching Name Regex",
+			Filters: ListFilters{
+				NameRegex: regexp.MustCompile("^other"),
+			},
+			Expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual, err := v.Filters.Matches(item)
+		if err != nil {
+			if v.ExpectError {
+				continue
+			}
+
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.ExpectError {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		if actual != v.Expected {
+			t.Fatalf("Expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
+
+func TestListDataSourceWrapperSchema(t *testing.T) {
+	wrapper := NewListDataSourceWrapper(listDataSource{})
+	dataSource, err := wrapper.DataSource()
+	if err != nil {
+		t.Fatalf("building Data Source: %+v", err)
+	}
+
+	if err := dataSource.InternalValidate(nil, false); err != nil {
+		t.Fatalf("validating Data Source: %+v", err)
+	}
+
+	for _, key := range []string{"resource_group_name", "filter", "tags", "name_regex", "max_results", "ids", "items"} {
+		if _, ok := dataSource.Schema[key]; !ok {
+			t.Fatalf("expected the Schema to contain %q", key)
+		}
+	}
+}
+
+func TestAccListDataSourceWrapper(t *testing.T) {
+	os.Setenv("TF_ACC", "1")
+
+	wrapper := NewListDataSourceWrapper(listDataSource{})
+	dataSource, err := wrapper.DataSource()
+	if err != nil {
+		t.Fatalf("building Data Source: %+v", err)
+	}
+
+	dataSourceName := "data.validator_list.test"
+	// lintignore:AT001
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: map[string]terraform.ResourceProviderFactory{
+			"validator": func() (terraform.ResourceProvider, error) {
+				return &schema.Provider{
+					DataSourcesMap: map[string]*schema.Resource{
+						"validator_list": dataSource,
+					},
+					ResourcesMap: map[string]*schema.Resource{},
+					ConfigureFunc: func(_ *schema.ResourceData) (interface{}, error) {
+						return &clients.Client{
+							StopContext: context.Background(),
+						}, nil
+					},
+				}, nil
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "validator_list" "test" {
+  resource_group_name = "example"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "3"),
+					resource.TestCheckResourceAttr(dataSourceName, "items.#", "3"),
+					resource.TestCheckResourceAttr(dataSourceName, "items.0.name", "alpha"),
+					resource.TestCheckResourceAttr(dataSourceName, "items.1.name", "bravo"),
+					resource.TestCheckResourceAttr(dataSourceName, "items.2.name", "zulu"),
+					resource.TestCheckResourceAttr(dataSourceName, "items.2.tags.env", "prod"),
+				),
+			},
+			{
+				Config: `
+data "validator_list" "test" {
+  resource_group_name = "example"
+
+  filter {
+    name   = "zones"
+    values = ["1"]
+  }
+
+  tags = {
+    env = "prod"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "items.0.name", "bravo"),
+					resource.TestCheckResourceAttr(dataSourceName, "items.1.name", "zulu"),
+				),
+			},
+			{
+				Config: `
+data "validator_list" "test" {
+  resource_group_name = "example"
+  name_regex          = "^(alpha|zulu)$"
+  max_results         = 1
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "items.0.name", "alpha"),
+					resource.TestCheckResourceAttr(dataSourceName, "items.0.location", "westeurope"),
+				),
+			},
+			{
+				Config: `
+data "validator_list" "test" {
+  resource_group_name = "example"
+
+  filter {
+    name   = "does_not_exist"
+    values = ["value"]
+  }
+}
+`,
+				ExpectError: regexp.MustCompile("`filter` \"does_not_exist\" is not supported"),
+			},
+		},
+	})
+}
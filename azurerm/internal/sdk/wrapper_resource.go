@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -26,14 +27,15 @@ func NewResourceWrapper(resource Resource) ResourceWrapper {
 
 // Resource returns the Terraform Plugin SDK type for this Resource implementation
 func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
-	resourceSchema, err := combineSchema(rw.resource.Arguments(), rw.resource.Attributes())
-	if err != nil {
-		return nil, fmt.Errorf("building Schema: %+v", err)
+	var validators map[string]schema.SchemaValidateFunc
+	v, generate := rw.resource.(ResourceWithGeneratedSchema)
+	if generate {
+		validators = v.SchemaValidators()
 	}
 
-	modelObj := rw.resource.ModelObject()
-	if err := ValidateModelObject(&modelObj); err != nil {
-		return nil, fmt.Errorf("validating model for %q: %+v", rw.resource.ResourceType(), err)
+	resourceSchema, err := buildSchema(rw.resource, generate, validators)
+	if err != nil {
+		return nil, err
 	}
 
 	var d = func(duration time.Duration) *time.Duration {
@@ -44,7 +46,7 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 		Schema: *resourceSchema,
 
 		Create: func(d *schema.ResourceData, meta interface{}) error {
-			ctx, metaData := runArgs(d, meta, rw.logger)
+			ctx, metaData := runArgs(d, meta, rw.logger, rw.resource.ResourceType(), operationCreate)
 			wrappedCtx, cancel := timeouts.ForCreate(ctx, d)
 			defer cancel()
 			err := rw.resource.Create().Func(wrappedCtx, metaData)
@@ -59,13 +61,13 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 
 		// looks like these could be reused, easiest if they're not
 		Read: func(d *schema.ResourceData, meta interface{}) error {
-			ctx, metaData := runArgs(d, meta, rw.logger)
+			ctx, metaData := runArgs(d, meta, rw.logger, rw.resource.ResourceType(), operationRead)
 			wrappedCtx, cancel := timeouts.ForRead(ctx, d)
 			defer cancel()
 			return rw.resource.Read().Func(wrappedCtx, metaData)
 		},
 		Delete: func(d *schema.ResourceData, meta interface{}) error {
-			ctx, metaData := runArgs(d, meta, rw.logger)
+			ctx, metaData := runArgs(d, meta, rw.logger, rw.resource.ResourceType(), operationDelete)
 			wrappedCtx, cancel := timeouts.ForDelete(ctx, d)
 			defer cancel()
 			return rw.resource.Delete().Func(wrappedCtx, metaData)
@@ -95,7 +97,7 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 			return nil
 		}, func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 			if v, ok := rw.resource.(ResourceWithCustomImporter); ok {
-				ctx, metaData := runArgs(d, meta, rw.logger)
+				ctx, metaData := runArgs(d, meta, rw.logger, rw.resource.ResourceType(), operationImport)
 				wrappedCtx, cancel := timeouts.ForRead(ctx, d)
 				defer cancel()
 
@@ -115,7 +117,7 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 	// implementations can opt to interface
 	if v, ok := rw.resource.(ResourceWithUpdate); ok {
 		resource.Update = func(d *schema.ResourceData, meta interface{}) error {
-			ctx, metaData := runArgs(d, meta, rw.logger)
+			ctx, metaData := runArgs(d, meta, rw.logger, rw.resource.ResourceType(), operationUpdate)
 			wrappedCtx, cancel := timeouts.ForUpdate(ctx, d)
 			defer cancel()
 
@@ -140,8 +142,30 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 		resource.DeprecationMessage = message
 	}
 
-	// TODO: CustomizeDiff
-	// TODO: State Migrations
+	if v, ok := rw.resource.(ResourceWithCustomizeDiff); ok {
+		if v.CustomizeDiff().Timeout == 0 {
+			return nil, fmt.Errorf("Resource %q must return a non-zero Timeout for CustomizeDiff if implementing ResourceWithCustomizeDiff", rw.resource.ResourceType())
+		}
+
+		resource.CustomizeDiff = func(d *schema.ResourceDiff, meta interface{}) error {
+			ctx, metaData := runDiffArgs(d, meta, rw.logger, rw.resource.ResourceType())
+			customizeDiff := v.CustomizeDiff()
+			wrappedCtx, cancel := context.WithTimeout(ctx, customizeDiff.Timeout)
+			defer cancel()
+			return customizeDiff.Func(wrappedCtx, metaData)
+		}
+	}
+
+	if v, ok := rw.resource.(ResourceWithStateMigration); ok {
+		data := v.StateUpgraders()
+		upgraders, err := buildStateUpgraders(data)
+		if err != nil {
+			return nil, fmt.Errorf("building State Upgraders for %q: %+v", rw.resource.ResourceType(), err)
+		}
+
+		resource.SchemaVersion = data.SchemaVersion
+		resource.StateUpgraders = upgraders
+	}
 
 	return &resource, nil
 }
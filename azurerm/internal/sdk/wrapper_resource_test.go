@@ -0,0 +1,208 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+)
+
+type customizeDiffModel struct {
+	Name  string   `tfschema:"name"`
+	Sku   string   `tfschema:"sku"`
+	Zones []string `tfschema:"zones"`
+}
+
+type customizeDiffResource struct {
+	timeout time.Duration
+}
+
+func (r customizeDiffResource) Arguments() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"sku": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"zones": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+func (r customizeDiffResource) Attributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{}
+}
+
+func (r customizeDiffResource) ModelObject() interface{} {
+	return customizeDiffModel{}
+}
+
+func (r customizeDiffResource) ResourceType() string {
+	return "validator_customize_diff"
+}
+
+func (r customizeDiffResource) Create() ResourceFunc {
+	return ResourceFunc{
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			var model customizeDiffModel
+			if err := metadata.Decode(&model); err != nil {
+				return err
+			}
+
+			metadata.ResourceData.SetId(model.Name)
+			return nil
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+func (r customizeDiffResource) Read() ResourceFunc {
+	return ResourceFunc{
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			return nil
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+func (r customizeDiffResource) Update() ResourceFunc {
+	return ResourceFunc{
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			return nil
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+func (r customizeDiffResource) Delete() ResourceFunc {
+	return ResourceFunc{
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			return nil
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+func (r customizeDiffResource) IDValidationFunc() schema.SchemaValidateFunc {
+	return nil
+}
+
+func (r customizeDiffResource) CustomizeDiff() ResourceFunc {
+	return ResourceFunc{
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			var model customizeDiffModel
+			if err := metadata.Decode(&model); err != nil {
+				return err
+			}
+
+			if model.Sku == "Basic" && len(model.Zones) > 0 {
+				return fmt.Errorf("`zones` are not supported when using the `Basic` sku")
+			}
+
+			if metadata.ResourceDiff.HasChange("sku") {
+				old, _ := metadata.ResourceDiff.GetChange("sku")
+				if old.(string) == "Premium" {
+					return metadata.MarkAsForceNew("sku")
+				}
+			}
+
+			return nil
+		},
+		Timeout: r.timeout,
+	}
+}
+
+func TestResourceWrapperCustomizeDiffRequiresTimeout(t *testing.T) {
+	wrapper := NewResourceWrapper(customizeDiffResource{})
+	if _, err := wrapper.Resource(); err == nil {
+		t.Fatalf("expected an error when CustomizeDiff has no Timeout but didn't get one")
+	}
+}
+
+func TestAccResourceWrapperCustomizeDiff(t *testing.T) {
+	os.Setenv("TF_ACC", "1")
+
+	wrapper := NewResourceWrapper(customizeDiffResource{
+		timeout: 5 * time.Minute,
+	})
+	wrapped, err := wrapper.Resource()
+	if err != nil {
+		t.Fatalf("building Resource: %+v", err)
+	}
+
+	resourceName := "validator_customize_diff.test"
+	var firstId string
+	// lintignore:AT001
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: map[string]terraform.ResourceProviderFactory{
+			"validator": func() (terraform.ResourceProvider, error) {
+				return &schema.Provider{
+					DataSourcesMap: map[string]*schema.Resource{},
+					ResourcesMap: map[string]*schema.Resource{
+						"validator_customize_diff": wrapped,
+					},
+					ConfigureFunc: func(_ *schema.ResourceData) (interface{}, error) {
+						return &clients.Client{
+							StopContext: context.Background(),
+						}, nil
+					},
+				}, nil
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "validator_customize_diff" "test" {
+  name  = "first"
+  sku   = "Basic"
+  zones = ["1"]
+}
+`,
+				ExpectError: regexp.MustCompile("`zones` are not supported when using the `Basic` sku"),
+			},
+			{
+				Config: `
+resource "validator_customize_diff" "test" {
+  name = "first"
+  sku  = "Premium"
+}
+`,
+				Check: func(s *terraform.State) error {
+					firstId = s.RootModule().Resources[resourceName].Primary.ID
+					return nil
+				},
+			},
+			{
+				Config: `
+resource "validator_customize_diff" "test" {
+  name = "second"
+  sku  = "Standard"
+}
+`,
+				Check: func(s *terraform.State) error {
+					// changing the name would otherwise be an in-place update - so this only
+					// changes when CustomizeDiff has marked the `sku` as ForceNew
+					if id := s.RootModule().Resources[resourceName].Primary.ID; id == firstId {
+						return fmt.Errorf("expected the resource to be recreated but the ID was still %q", id)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
@@ -13,11 +13,17 @@ func ValidateModelObject(input interface{}) error {
 		return fmt.Errorf("need a pointer")
 	}
 
-	// TODO: could we also validate that each `tfschema` tag exists in the schema?
-
-	objType := reflect.TypeOf(input).Elem()
+	// the wrappers pass a pointer to the `interface{}` returned from ModelObject
+	// so we need to look through to the underlying type
 	objVal := reflect.ValueOf(input).Elem()
-	return validateModelObjectRecursively("", objType, objVal)
+	for objVal.Kind() == reflect.Interface || objVal.Kind() == reflect.Ptr {
+		objVal = objVal.Elem()
+	}
+	if objVal.Kind() != reflect.Struct {
+		return fmt.Errorf("expected the model to be a struct but got %s", objVal.Kind())
+	}
+
+	return validateModelObjectRecursively("", objVal.Type(), objVal)
 }
 
 func validateModelObjectRecursively(prefix string, objType reflect.Type, objVal reflect.Value) (errOut error) {
@@ -39,6 +45,9 @@ func validateModelObjectRecursively(prefix string, objType reflect.Type, objVal
 		if field.Type.Kind() == reflect.Slice {
 			sv := fieldVal.Slice(0, fieldVal.Len())
 			innerType := sv.Type().Elem()
+			if innerType.Kind() == reflect.Ptr {
+				innerType = innerType.Elem()
+			}
 			innerVal := reflect.Indirect(reflect.New(innerType))
 			fieldName := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, field.Name), ".")
 			if err := validateModelObjectRecursively(fieldName, innerType, innerVal); err != nil {
@@ -46,8 +55,24 @@ func validateModelObjectRecursively(prefix string, objType reflect.Type, objVal
 			}
 		}
 
-		if _, exists := field.Tag.Lookup("tfschema"); !exists {
+		// single nested blocks can be defined as either a struct or a pointer to a struct
+		if innerType := field.Type; innerType.Kind() == reflect.Struct || (innerType.Kind() == reflect.Ptr && innerType.Elem().Kind() == reflect.Struct) {
+			if innerType.Kind() == reflect.Ptr {
+				innerType = innerType.Elem()
+			}
+			innerVal := reflect.Indirect(reflect.New(innerType))
 			fieldName := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, field.Name), ".")
+			if err := validateModelObjectRecursively(fieldName, innerType, innerVal); err != nil {
+				return err
+			}
+		}
+
+		fieldName := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, field.Name), ".")
+		_, exists, err := parseStructTag(field)
+		if err != nil {
+			return fmt.Errorf("field %q: %+v", fieldName, err)
+		}
+		if !exists {
 			return fmt.Errorf("field %q is missing an `tfschema` label", fieldName)
 		}
 	}
@@ -56,3 +56,32 @@ func TestValidateNestedObjectInvalid(t *testing.T) {
 		t.Fatalf("expected an error but didn't get one")
 	}
 }
+
+func TestValidateNestedPointerObjectValid(t *testing.T) {
+	type Pet struct {
+		Name string `tfschema:"name"`
+	}
+	type Person struct {
+		Name         string `tfschema:"name"`
+		Age          *int   `tfschema:"age"`
+		Pets         []*Pet `tfschema:"pets"`
+		FavouriteCat *Pet   `tfschema:"favourite_cat"`
+	}
+	if err := ValidateModelObject(&Person{}); err != nil {
+		t.Fatalf("error: %+v", err)
+	}
+}
+
+func TestValidateNestedPointerObjectInvalid(t *testing.T) {
+	type Pet struct {
+		Name string `tfschema:"name"`
+		Age  int
+	}
+	type Person struct {
+		Name         string `tfschema:"name"`
+		FavouriteCat *Pet   `tfschema:"favourite_cat"`
+	}
+	if err := ValidateModelObject(&Person{}); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+}
@@ -17,6 +17,8 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/operations"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/apimanagement/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/apimanagement/schemaz"
 	apimValidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/apimanagement/validate"
@@ -557,6 +559,8 @@ func resourceApiManagementService() *schema.Resource {
 			},
 
 			"tags": tags.Schema(),
+
+			operations.PendingCreationKey: operations.Schema(),
 		},
 
 		// we can only change `virtual_network_type` from None to Internal Or External, Else the subnet can not be destroyed cause “InUseSubnetCannotBeDeleted” for 3 hours
@@ -572,6 +576,8 @@ func resourceApiManagementService() *schema.Resource {
 			customdiff.ForceNewIfChange("virtual_network_configuration", func(old, new, meta interface{}) bool {
 				return !(len(old.([]interface{})) == 0 && len(new.([]interface{})) > 0)
 			}),
+
+			operations.CustomizeDiff,
 		),
 	}
 }
@@ -587,6 +593,7 @@ func resourceApiManagementServiceCreateUpdate(d *schema.ResourceData, meta inter
 
 	name := d.Get("name").(string)
 	resourceGroup := d.Get("resource_group_name").(string)
+	id := parse.NewApiManagementID(meta.(*clients.Client).Account.SubscriptionId, resourceGroup, name)
 
 	if d.IsNewResource() {
 		existing, err := client.Get(ctx, resourceGroup, name)
@@ -599,6 +606,30 @@ func resourceApiManagementServiceCreateUpdate(d *schema.ResourceData, meta inter
 		if existing.ID != nil && *existing.ID != "" {
 			return tf.ImportAsExistsError("azurerm_api_management", *existing.ID)
 		}
+
+		softDeleted := apiManagementSoftDeletedService{
+			client:         meta.(*clients.Client).Resource.GenericResourcesClient,
+			id:             id,
+			location:       azure.NormalizeLocation(d.Get("location").(string)),
+			publisherName:  d.Get("publisher_name").(string),
+			publisherEmail: d.Get("publisher_email").(string),
+			sku:            sku,
+		}
+		description := fmt.Sprintf("API Management Service %q (Resource Group %q)", name, resourceGroup)
+		if _, err := sdk.RecoverSoftDeletedOnCreate(ctx, description, "api_management.recover_soft_deleted", meta.(*clients.Client).Features.ApiManagement, softDeleted); err != nil {
+			return err
+		}
+	}
+
+	// creating an API Management Service can take longer than the timeout, in which case the creation is resumed
+	// prior to the Service being updated - and the settings applied once it's been created are applied in full
+	resumedCreation := false
+	if !d.IsNewResource() {
+		resumed, err := operations.ResumeCreation(ctx, client.Client, d)
+		if err != nil {
+			return fmt.Errorf("resuming the creation of API Management Service %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+		resumedCreation = resumed
 	}
 
 	location := azure.NormalizeLocation(d.Get("location").(string))
@@ -668,8 +699,17 @@ func resourceApiManagementServiceCreateUpdate(d *schema.ResourceData, meta inter
 		return fmt.Errorf("creating/updating API Management Service %q (Resource Group %q): %+v", name, resourceGroup, err)
 	}
 
-	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
-		return fmt.Errorf("waiting for creation/update of API Management Service %q (Resource Group %q): %+v", name, resourceGroup, err)
+	if d.IsNewResource() {
+		pending, err := operations.WaitForCreation(ctx, client.Client, d, id.ID(), future.FutureAPI)
+		if err != nil {
+			return fmt.Errorf("waiting for creation of API Management Service %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+		if pending {
+			// the remaining settings are applied once the creation has been resumed during the next apply
+			return nil
+		}
+	} else if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for update of API Management Service %q (Resource Group %q): %+v", name, resourceGroup, err)
 	}
 
 	read, err := client.Get(ctx, resourceGroup, name)
@@ -714,7 +754,7 @@ func resourceApiManagementServiceCreateUpdate(d *schema.ResourceData, meta inter
 		return err
 	}
 
-	if d.HasChange("policy") {
+	if d.HasChange("policy") || resumedCreation {
 		// remove the existing policy
 		if resp, err := policyClient.Delete(ctx, resourceGroup, name, ""); err != nil {
 			if !utils.ResponseWasNotFound(resp) {
@@ -734,7 +774,7 @@ func resourceApiManagementServiceCreateUpdate(d *schema.ResourceData, meta inter
 	if sku.Name == apimanagement.SkuTypeConsumption && len(tenantAccessRaw) > 0 {
 		return fmt.Errorf("`tenant_access` is not supported for sku tier `Consumption`")
 	}
-	if sku.Name != apimanagement.SkuTypeConsumption && d.HasChange("tenant_access") {
+	if sku.Name != apimanagement.SkuTypeConsumption && (d.HasChange("tenant_access") || resumedCreation) {
 		tenantAccessInformationParametersRaw := d.Get("tenant_access").([]interface{})
 		tenantAccessInformationParameters := expandApiManagementTenantAccessSettings(tenantAccessInformationParametersRaw)
 		tenantAccessClient := meta.(*clients.Client).ApiManagement.TenantAccessClient
@@ -903,7 +943,13 @@ func resourceApiManagementServiceDelete(d *schema.ResourceData, meta interface{}
 		}
 	}
 
-	return nil
+	softDeleted := apiManagementSoftDeletedService{
+		client:   meta.(*clients.Client).Resource.GenericResourcesClient,
+		id:       *id,
+		location: azure.NormalizeLocation(d.Get("location").(string)),
+	}
+	description := fmt.Sprintf("API Management Service %q (Resource Group %q)", name, resourceGroup)
+	return sdk.PurgeSoftDeletedOnDestroy(ctx, description, meta.(*clients.Client).Features.ApiManagement, softDeleted)
 }
 
 func apiManagementRefreshFunc(ctx context.Context, client *apimanagement.ServiceClient, serviceName, resourceGroup string) resource.StateRefreshFunc {
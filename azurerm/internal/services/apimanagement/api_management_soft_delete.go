@@ -0,0 +1,81 @@
+package apimanagement
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/apimanagement/mgmt/2019-12-01/apimanagement"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/apimanagement/parse"
+	resourceClient "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/resource/client"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// the Deleted Services API isn't available in the version of the API Management SDK we're using
+// so this is called using the raw API
+const apiManagementSoftDeleteApiVersion = "2020-06-01-preview"
+
+var _ sdk.SoftDeletedResource = apiManagementSoftDeletedService{}
+
+// apiManagementSoftDeletedService finds, recovers and purges a Soft Deleted API Management Service
+type apiManagementSoftDeletedService struct {
+	client   *resourceClient.GenericResourcesClient
+	id       parse.ApiManagementId
+	location string
+
+	// the following are required to recover the Service
+	publisherName  string
+	publisherEmail string
+	sku            *apimanagement.ServiceSkuProperties
+}
+
+func (s apiManagementSoftDeletedService) deletedServiceId() string {
+	return fmt.Sprintf("/subscriptions/%s/providers/Microsoft.ApiManagement/locations/%s/deletedservices/%s", s.id.SubscriptionId, s.location, s.id.ServiceName)
+}
+
+func (s apiManagementSoftDeletedService) FindSoftDeleted(ctx context.Context) (bool, error) {
+	_, resp, err := s.client.Get(ctx, s.deletedServiceId(), apiManagementSoftDeleteApiVersion)
+	if err != nil {
+		// If Terraform lacks permission to read at the Subscription we'll get 403, not 404
+		if utils.ResponseWasNotFound(resp) || utils.ResponseWasForbidden(resp) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s apiManagementSoftDeletedService) RecoverSoftDeleted(ctx context.Context) error {
+	sku := map[string]interface{}{}
+	if s.sku != nil {
+		sku["name"] = string(s.sku.Name)
+		if s.sku.Capacity != nil {
+			sku["capacity"] = *s.sku.Capacity
+		}
+	}
+
+	future, err := s.client.CreateOrUpdate(ctx, s.id.ID(), apiManagementSoftDeleteApiVersion, map[string]interface{}{
+		"location": s.location,
+		"sku":      sku,
+		"properties": map[string]interface{}{
+			"publisherEmail": s.publisherEmail,
+			"publisherName":  s.publisherName,
+			"restore":        true,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, s.client.Client)
+}
+
+func (s apiManagementSoftDeletedService) PurgeSoftDeleted(ctx context.Context) error {
+	future, err := s.client.Delete(ctx, s.deletedServiceId(), apiManagementSoftDeleteApiVersion)
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, s.client.Client)
+}
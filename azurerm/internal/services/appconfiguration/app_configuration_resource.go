@@ -13,6 +13,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/appconfiguration/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/appconfiguration/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
@@ -205,7 +206,8 @@ func resourceAppConfigurationCreate(d *schema.ResourceData, meta interface{}) er
 
 	name := d.Get("name").(string)
 	resourceGroup := d.Get("resource_group_name").(string)
-	resourceId := parse.NewConfigurationStoreID(subscriptionId, resourceGroup, name).ID()
+	id := parse.NewConfigurationStoreID(subscriptionId, resourceGroup, name)
+	resourceId := id.ID()
 	existing, err := client.Get(ctx, resourceGroup, name)
 	if err != nil {
 		if !utils.ResponseWasNotFound(existing.Response) {
@@ -216,6 +218,17 @@ func resourceAppConfigurationCreate(d *schema.ResourceData, meta interface{}) er
 		return tf.ImportAsExistsError("azurerm_app_configuration", resourceId)
 	}
 
+	softDeleted := appConfigurationSoftDeletedStore{
+		client:   meta.(*clients.Client).Resource.GenericResourcesClient,
+		id:       id,
+		location: azure.NormalizeLocation(d.Get("location").(string)),
+		skuName:  d.Get("sku").(string),
+	}
+	description := fmt.Sprintf("App Configuration %q (Resource Group %q)", name, resourceGroup)
+	if _, err := sdk.RecoverSoftDeletedOnCreate(ctx, description, "app_configuration.recover_soft_deleted", meta.(*clients.Client).Features.AppConfiguration, softDeleted); err != nil {
+		return err
+	}
+
 	parameters := appconfiguration.ConfigurationStore{
 		Location: utils.String(azure.NormalizeLocation(d.Get("location").(string))),
 		Sku: &appconfiguration.Sku{
@@ -362,7 +375,13 @@ func resourceAppConfigurationDelete(d *schema.ResourceData, meta interface{}) er
 		return fmt.Errorf("Error deleting App Configuration %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
 	}
 
-	return nil
+	softDeleted := appConfigurationSoftDeletedStore{
+		client:   meta.(*clients.Client).Resource.GenericResourcesClient,
+		id:       *id,
+		location: azure.NormalizeLocation(d.Get("location").(string)),
+	}
+	description := fmt.Sprintf("App Configuration %q (Resource Group %q)", id.Name, id.ResourceGroup)
+	return sdk.PurgeSoftDeletedOnDestroy(ctx, description, meta.(*clients.Client).Features.AppConfiguration, softDeleted)
 }
 
 type flattenedAccessKeys struct {
@@ -0,0 +1,70 @@
+package appconfiguration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/appconfiguration/parse"
+	resourceClient "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/resource/client"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// the Deleted Configuration Stores API isn't available in the version of the App Configuration SDK we're using
+// so this is called using the raw API
+const appConfigurationSoftDeleteApiVersion = "2021-03-01-preview"
+
+var _ sdk.SoftDeletedResource = appConfigurationSoftDeletedStore{}
+
+// appConfigurationSoftDeletedStore finds, recovers and purges a Soft Deleted App Configuration
+type appConfigurationSoftDeletedStore struct {
+	client   *resourceClient.GenericResourcesClient
+	id       parse.ConfigurationStoreId
+	location string
+
+	// the following are required to recover the Configuration Store
+	skuName string
+}
+
+func (s appConfigurationSoftDeletedStore) deletedConfigurationStoreId() string {
+	return fmt.Sprintf("/subscriptions/%s/providers/Microsoft.AppConfiguration/locations/%s/deletedConfigurationStores/%s", s.id.SubscriptionId, s.location, s.id.Name)
+}
+
+func (s appConfigurationSoftDeletedStore) FindSoftDeleted(ctx context.Context) (bool, error) {
+	_, resp, err := s.client.Get(ctx, s.deletedConfigurationStoreId(), appConfigurationSoftDeleteApiVersion)
+	if err != nil {
+		// If Terraform lacks permission to read at the Subscription we'll get 403, not 404
+		if utils.ResponseWasNotFound(resp) || utils.ResponseWasForbidden(resp) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s appConfigurationSoftDeletedStore) RecoverSoftDeleted(ctx context.Context) error {
+	future, err := s.client.CreateOrUpdate(ctx, s.id.ID(), appConfigurationSoftDeleteApiVersion, map[string]interface{}{
+		"location": s.location,
+		"sku": map[string]interface{}{
+			"name": s.skuName,
+		},
+		"properties": map[string]interface{}{
+			"createMode": "Recover",
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, s.client.Client)
+}
+
+func (s appConfigurationSoftDeletedStore) PurgeSoftDeleted(ctx context.Context) error {
+	future, err := s.client.Action(ctx, s.deletedConfigurationStoreId(), "purge", appConfigurationSoftDeleteApiVersion, nil)
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, s.client.Client)
+}
@@ -0,0 +1,36 @@
+package authorization
+
+import "testing"
+
+func TestRoleAssignmentIdSubscriptionId(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected string
+	}{
+		{
+			Input:    "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Authorization/roleAssignments/11111111-1111-1111-1111-111111111111",
+			Expected: "00000000-0000-0000-0000-000000000000",
+		},
+		{
+			Input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1/providers/Microsoft.Authorization/roleAssignments/11111111-1111-1111-1111-111111111111",
+			Expected: "00000000-0000-0000-0000-000000000000",
+		},
+		{
+			Input:    "/providers/Microsoft.Management/managementGroups/group1/providers/Microsoft.Authorization/roleAssignments/11111111-1111-1111-1111-111111111111",
+			Expected: "",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		id, err := parseRoleAssignmentId(v.Input)
+		if err != nil {
+			t.Fatalf("parsing %q: %+v", v.Input, err)
+		}
+
+		if actual := id.subscriptionId(); actual != v.Expected {
+			t.Fatalf("expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
@@ -95,19 +95,30 @@ func resourceArmRoleAssignment() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+
+			"subscription_id": azure.SchemaSubscriptionIdOverride(),
 		},
 	}
 }
 
 func resourceArmRoleAssignmentCreate(d *schema.ResourceData, meta interface{}) error {
-	roleAssignmentsClient := meta.(*clients.Client).Authorization.RoleAssignmentsClient
-	roleDefinitionsClient := meta.(*clients.Client).Authorization.RoleDefinitionsClient
-	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
-	defer cancel()
-
 	name := d.Get("name").(string)
 	scope := d.Get("scope").(string)
 
+	// when unspecified the Subscription is taken from the Scope, falling back to the Provider's Subscription
+	subscriptionId := d.Get("subscription_id").(string)
+	if subscriptionId == "" {
+		subscriptionId = roleAssignmentId{scope: strings.TrimPrefix(scope, "/")}.subscriptionId()
+	}
+	subscriptionClient, err := meta.(*clients.Client).ForSubscription(subscriptionId)
+	if err != nil {
+		return err
+	}
+	roleAssignmentsClient := subscriptionClient.Authorization.RoleAssignmentsClient
+	roleDefinitionsClient := subscriptionClient.Authorization.RoleDefinitionsClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
 	var roleDefinitionId string
 	if v, ok := d.GetOk("role_definition_id"); ok {
 		roleDefinitionId = v.(string)
@@ -160,7 +171,7 @@ func resourceArmRoleAssignmentCreate(d *schema.ResourceData, meta interface{}) e
 		properties.RoleAssignmentProperties.PrincipalType = authorization.ServicePrincipal
 	}
 
-	if err := resource.Retry(d.Timeout(schema.TimeoutCreate), retryRoleAssignmentsClient(d, scope, name, properties, meta)); err != nil {
+	if err := resource.Retry(d.Timeout(schema.TimeoutCreate), retryRoleAssignmentsClient(d, scope, name, properties, subscriptionClient)); err != nil {
 		return err
 	}
 
@@ -177,8 +188,18 @@ func resourceArmRoleAssignmentCreate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceArmRoleAssignmentRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Authorization.RoleAssignmentsClient
-	roleDefinitionsClient := meta.(*clients.Client).Authorization.RoleDefinitionsClient
+	id, err := parseRoleAssignmentId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// the Scope determines where the Role Assignment lives, so the Subscription isn't part of the ID
+	subscriptionClient, err := meta.(*clients.Client).ForSubscription(d.Get("subscription_id").(string))
+	if err != nil {
+		return err
+	}
+	client := subscriptionClient.Authorization.RoleAssignmentsClient
+	roleDefinitionsClient := subscriptionClient.Authorization.RoleDefinitionsClient
 	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -195,6 +216,12 @@ func resourceArmRoleAssignmentRead(d *schema.ResourceData, meta interface{}) err
 
 	d.Set("name", resp.Name)
 
+	// Role Assignments scoped to a Management Group aren't within a Subscription, in which case
+	// the `subscription_id` is only used to authenticate and the configured value is kept
+	if subscriptionId := id.subscriptionId(); subscriptionId != "" {
+		d.Set("subscription_id", subscriptionId)
+	}
+
 	if props := resp.RoleAssignmentPropertiesWithScope; props != nil {
 		d.Set("scope", props.Scope)
 		d.Set("role_definition_id", props.RoleDefinitionID)
@@ -218,7 +245,6 @@ func resourceArmRoleAssignmentRead(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceArmRoleAssignmentDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Authorization.RoleAssignmentsClient
 	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -227,6 +253,12 @@ func resourceArmRoleAssignmentDelete(d *schema.ResourceData, meta interface{}) e
 		return err
 	}
 
+	subscriptionClient, err := meta.(*clients.Client).ForSubscription(d.Get("subscription_id").(string))
+	if err != nil {
+		return err
+	}
+	client := subscriptionClient.Authorization.RoleAssignmentsClient
+
 	resp, err := client.Delete(ctx, id.scope, id.name)
 	if err != nil {
 		if !utils.ResponseWasNotFound(resp.Response) {
@@ -237,10 +269,10 @@ func resourceArmRoleAssignmentDelete(d *schema.ResourceData, meta interface{}) e
 	return nil
 }
 
-func retryRoleAssignmentsClient(d *schema.ResourceData, scope string, name string, properties authorization.RoleAssignmentCreateParameters, meta interface{}) func() *resource.RetryError {
+func retryRoleAssignmentsClient(d *schema.ResourceData, scope string, name string, properties authorization.RoleAssignmentCreateParameters, meta *clients.Client) func() *resource.RetryError {
 	return func() *resource.RetryError {
-		roleAssignmentsClient := meta.(*clients.Client).Authorization.RoleAssignmentsClient
-		ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+		roleAssignmentsClient := meta.Authorization.RoleAssignmentsClient
+		ctx, cancel := timeouts.ForCreate(meta.StopContext, d)
 		defer cancel()
 
 		resp, err := roleAssignmentsClient.Create(ctx, scope, name, properties)
@@ -299,6 +331,17 @@ func parseRoleAssignmentId(input string) (*roleAssignmentId, error) {
 	return &id, nil
 }
 
+// subscriptionId returns the ID of the Subscription which the Scope of this Role Assignment is within,
+// or an empty string when the Scope isn't within a Subscription (for example a Management Group)
+func (id roleAssignmentId) subscriptionId() string {
+	segments := strings.Split(id.scope, "/")
+	if len(segments) < 2 || !strings.EqualFold(segments[0], "subscriptions") {
+		return ""
+	}
+
+	return segments[1]
+}
+
 func roleAssignmentCreateStateRefreshFunc(ctx context.Context, client *authorization.RoleAssignmentsClient, roleID string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		resp, err := client.GetByID(ctx, roleID)
@@ -14,6 +14,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/cognitive/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/cognitive/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
@@ -149,6 +150,18 @@ func resourceCognitiveAccountCreate(d *schema.ResourceData, meta interface{}) er
 		if existing.ID != nil && *existing.ID != "" {
 			return tf.ImportAsExistsError("azurerm_cognitive_account", *existing.ID)
 		}
+
+		softDeleted := cognitiveSoftDeletedAccount{
+			client:   meta.(*clients.Client).Resource.GenericResourcesClient,
+			id:       parse.NewAccountID(meta.(*clients.Client).Account.SubscriptionId, resourceGroup, name),
+			location: azure.NormalizeLocation(d.Get("location").(string)),
+			kind:     kind,
+			skuName:  d.Get("sku_name").(string),
+		}
+		description := fmt.Sprintf("Cognitive Services Account %q (Resource Group %q)", name, resourceGroup)
+		if _, err := sdk.RecoverSoftDeletedOnCreate(ctx, description, "cognitive_account.recover_soft_deleted", meta.(*clients.Client).Features.CognitiveAccount, softDeleted); err != nil {
+			return err
+		}
 	}
 
 	sku, err := expandAccountSkuName(d.Get("sku_name").(string))
@@ -310,7 +323,13 @@ func resourceCognitiveAccountDelete(d *schema.ResourceData, meta interface{}) er
 		}
 	}
 
-	return nil
+	softDeleted := cognitiveSoftDeletedAccount{
+		client:   meta.(*clients.Client).Resource.GenericResourcesClient,
+		id:       *id,
+		location: azure.NormalizeLocation(d.Get("location").(string)),
+	}
+	description := fmt.Sprintf("Cognitive Services Account %q (Resource Group %q)", id.Name, id.ResourceGroup)
+	return sdk.PurgeSoftDeletedOnDestroy(ctx, description, meta.(*clients.Client).Features.CognitiveAccount, softDeleted)
 }
 
 func expandAccountSkuName(skuName string) (*cognitiveservices.Sku, error) {
@@ -0,0 +1,72 @@
+package cognitive
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/cognitive/parse"
+	resourceClient "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/resource/client"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// the Deleted Accounts API isn't available in the version of the Cognitive Services SDK we're using
+// so this is called using the raw API
+const cognitiveAccountSoftDeleteApiVersion = "2021-04-30"
+
+var _ sdk.SoftDeletedResource = cognitiveSoftDeletedAccount{}
+
+// cognitiveSoftDeletedAccount finds, recovers and purges a Soft Deleted Cognitive Services Account
+type cognitiveSoftDeletedAccount struct {
+	client   *resourceClient.GenericResourcesClient
+	id       parse.AccountId
+	location string
+
+	// the following are required to recover the Account
+	kind    string
+	skuName string
+}
+
+func (a cognitiveSoftDeletedAccount) deletedAccountId() string {
+	return fmt.Sprintf("/subscriptions/%s/providers/Microsoft.CognitiveServices/locations/%s/resourceGroups/%s/deletedAccounts/%s", a.id.SubscriptionId, a.location, a.id.ResourceGroup, a.id.Name)
+}
+
+func (a cognitiveSoftDeletedAccount) FindSoftDeleted(ctx context.Context) (bool, error) {
+	_, resp, err := a.client.Get(ctx, a.deletedAccountId(), cognitiveAccountSoftDeleteApiVersion)
+	if err != nil {
+		// If Terraform lacks permission to read at the Subscription we'll get 403, not 404
+		if utils.ResponseWasNotFound(resp) || utils.ResponseWasForbidden(resp) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (a cognitiveSoftDeletedAccount) RecoverSoftDeleted(ctx context.Context) error {
+	future, err := a.client.CreateOrUpdate(ctx, a.id.ID(), cognitiveAccountSoftDeleteApiVersion, map[string]interface{}{
+		"location": a.location,
+		"kind":     a.kind,
+		"sku": map[string]interface{}{
+			"name": a.skuName,
+		},
+		"properties": map[string]interface{}{
+			"restore": true,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, a.client.Client)
+}
+
+func (a cognitiveSoftDeletedAccount) PurgeSoftDeleted(ctx context.Context) error {
+	future, err := a.client.Delete(ctx, a.deletedAccountId(), cognitiveAccountSoftDeleteApiVersion)
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, a.client.Client)
+}
@@ -121,7 +121,7 @@ func dataSourceImagesRead(d *schema.ResourceData, meta interface{}) error {
 	defer cancel()
 
 	resourceGroup := d.Get("resource_group_name").(string)
-	filterTags := tags.Expand(d.Get("tags_filter").(map[string]interface{}))
+	filterTags := tags.ExpandWithoutDefaults(d.Get("tags_filter").(map[string]interface{}))
 
 	resp, err := client.ListByResourceGroupComplete(ctx, resourceGroup)
 	if err != nil {
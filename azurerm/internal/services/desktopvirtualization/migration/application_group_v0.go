@@ -1,10 +1,10 @@
 package migration
 
 import (
-	"log"
-
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/resourceid"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/desktopvirtualization/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
 )
@@ -48,24 +48,13 @@ func ApplicationGroupUpgradeV0Schema() *schema.Resource {
 	}
 }
 
-func ApplicationGroupUpgradeV0ToV1(rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
-	oldId := rawState["id"].(string)
-	id, err := parse.ApplicationGroupIDInsensitively(oldId)
-	if err != nil {
-		return nil, err
-	}
-	newId := id.ID()
-	log.Printf("[DEBUG] Updating ID from %q to %q", oldId, newId)
-	rawState["id"] = newId
-
-	oldHostPoolId := rawState["host_pool_id"].(string)
-	hostPoolId, err := parse.HostPoolIDInsensitively(oldHostPoolId)
-	if err != nil {
-		return nil, err
-	}
-	newHostPoolId := hostPoolId.ID()
-	log.Printf("[DEBUG] Updating Host Pool ID from %q to %q", oldHostPoolId, newHostPoolId)
-	rawState["host_pool_id"] = newHostPoolId
-
-	return rawState, nil
+func ApplicationGroupUpgradeV0ToV1(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	return sdk.ResourceIDFieldsStateUpgradeFunc(map[string]sdk.ResourceIDParser{
+		"id": func(input string) (resourceid.Formatter, error) {
+			return parse.ApplicationGroupIDInsensitively(input)
+		},
+		"host_pool_id": func(input string) (resourceid.Formatter, error) {
+			return parse.HostPoolIDInsensitively(input)
+		},
+	})(rawState, meta)
 }
@@ -0,0 +1,66 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestApplicationGroupUpgradeV0ToV1(t *testing.T) {
+	testData := []struct {
+		name               string
+		input              map[string]interface{}
+		expected           *string
+		expectedHostPoolId *string
+	}{
+		{
+			name: "missing id",
+			input: map[string]interface{}{
+				"id":           "",
+				"host_pool_id": "/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.DesktopVirtualization/hostPools/pool1",
+			},
+			expected: nil,
+		},
+		{
+			name: "old ids",
+			input: map[string]interface{}{
+				"id":           "/subscriptions/12345678-1234-5678-1234-123456789012/resourcegroups/group1/providers/Microsoft.DesktopVirtualization/applicationgroups/group1",
+				"host_pool_id": "/subscriptions/12345678-1234-5678-1234-123456789012/resourcegroups/group1/providers/Microsoft.DesktopVirtualization/hostpools/pool1",
+			},
+			expected:           utils.String("/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.DesktopVirtualization/applicationGroups/group1"),
+			expectedHostPoolId: utils.String("/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.DesktopVirtualization/hostPools/pool1"),
+		},
+		{
+			name: "new ids",
+			input: map[string]interface{}{
+				"id":           "/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.DesktopVirtualization/applicationGroups/group1",
+				"host_pool_id": "/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.DesktopVirtualization/hostPools/pool1",
+			},
+			expected:           utils.String("/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.DesktopVirtualization/applicationGroups/group1"),
+			expectedHostPoolId: utils.String("/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.DesktopVirtualization/hostPools/pool1"),
+		},
+	}
+	for _, test := range testData {
+		t.Logf("Testing %q..", test.name)
+		result, err := ApplicationGroupUpgradeV0ToV1(test.input, nil)
+		if err != nil && test.expected == nil {
+			continue
+		} else {
+			if err == nil && test.expected == nil {
+				t.Fatalf("Expected an error but didn't get one")
+			} else if err != nil && test.expected != nil {
+				t.Fatalf("Expected no error but got: %+v", err)
+			}
+		}
+
+		actualId := result["id"].(string)
+		if *test.expected != actualId {
+			t.Fatalf("expected %q but got %q!", *test.expected, actualId)
+		}
+
+		actualHostPoolId := result["host_pool_id"].(string)
+		if *test.expectedHostPoolId != actualHostPoolId {
+			t.Fatalf("expected %q but got %q!", *test.expectedHostPoolId, actualHostPoolId)
+		}
+	}
+}
@@ -1,10 +1,10 @@
 package migration
 
 import (
-	"log"
-
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/resourceid"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/desktopvirtualization/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
 )
@@ -99,17 +99,8 @@ func HostPoolUpgradeV0Schema() *schema.Resource {
 	}
 }
 
-func HostPoolUpgradeV0ToV1(rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
-	oldId := rawState["id"].(string)
-
-	id, err := parse.HostPoolIDInsensitively(oldId)
-	if err != nil {
-		return nil, err
-	}
-	newId := id.ID()
-
-	log.Printf("[DEBUG] Updating ID from %q to %q", oldId, newId)
-	rawState["id"] = newId
-
-	return rawState, nil
+func HostPoolUpgradeV0ToV1(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	return sdk.ResourceIDStateUpgradeFunc(func(input string) (resourceid.Formatter, error) {
+		return parse.HostPoolIDInsensitively(input)
+	})(rawState, meta)
 }
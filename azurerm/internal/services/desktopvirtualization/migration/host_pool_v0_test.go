@@ -0,0 +1,55 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestHostPoolUpgradeV0ToV1(t *testing.T) {
+	testData := []struct {
+		name     string
+		input    map[string]interface{}
+		expected *string
+	}{
+		{
+			name: "missing id",
+			input: map[string]interface{}{
+				"id": "",
+			},
+			expected: nil,
+		},
+		{
+			name: "old id",
+			input: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-5678-1234-123456789012/resourcegroups/group1/providers/Microsoft.DesktopVirtualization/hostpools/pool1",
+			},
+			expected: utils.String("/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.DesktopVirtualization/hostPools/pool1"),
+		},
+		{
+			name: "new id",
+			input: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.DesktopVirtualization/hostPools/pool1",
+			},
+			expected: utils.String("/subscriptions/12345678-1234-5678-1234-123456789012/resourceGroups/group1/providers/Microsoft.DesktopVirtualization/hostPools/pool1"),
+		},
+	}
+	for _, test := range testData {
+		t.Logf("Testing %q..", test.name)
+		result, err := HostPoolUpgradeV0ToV1(test.input, nil)
+		if err != nil && test.expected == nil {
+			continue
+		} else {
+			if err == nil && test.expected == nil {
+				t.Fatalf("Expected an error but didn't get one")
+			} else if err != nil && test.expected != nil {
+				t.Fatalf("Expected no error but got: %+v", err)
+			}
+		}
+
+		actualId := result["id"].(string)
+		if *test.expected != actualId {
+			t.Fatalf("expected %q but got %q!", *test.expected, actualId)
+		}
+	}
+}
@@ -191,7 +191,7 @@ func resourceDnsZoneCreateUpdate(d *schema.ResourceData, meta interface{}) error
 		rsParameters := dns.RecordSet{
 			RecordSetProperties: &dns.RecordSetProperties{
 				TTL:       utils.Int64(int64(soaRecord["ttl"].(int))),
-				Metadata:  tags.Expand(soaRecord["tags"].(map[string]interface{})),
+				Metadata:  tags.ExpandWithoutDefaults(soaRecord["tags"].(map[string]interface{})),
 				SoaRecord: expandArmDNSZoneSOARecord(soaRecord),
 			},
 		}
@@ -309,7 +309,7 @@ func flattenArmDNSZoneSOARecord(input *dns.RecordSet) []interface{} {
 
 	metaData := make(map[string]interface{})
 	if input.Metadata != nil {
-		metaData = tags.Flatten(input.Metadata)
+		metaData = tags.FlattenIncludingIgnored(input.Metadata)
 	}
 
 	fqdn := ""
@@ -4,11 +4,14 @@ import (
 	keyvaultmgmt "github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
 	"github.com/Azure/azure-sdk-for-go/services/keyvault/mgmt/2019-09-01/keyvault"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/providercache"
 )
 
 type Client struct {
 	ManagementClient *keyvaultmgmt.BaseClient
 	VaultsClient     *keyvault.VaultsClient
+
+	providerCache *providercache.Cache
 }
 
 func NewClient(o *common.ClientOptions) *Client {
@@ -21,5 +24,6 @@ func NewClient(o *common.ClientOptions) *Client {
 	return &Client{
 		ManagementClient: &managementClient,
 		VaultsClient:     &vaultsClient,
+		providerCache:    o.ProviderCache,
 	}
 }
@@ -6,12 +6,17 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/keyvault/parse"
 	resource "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/resource/client"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+// the Key Vaults are also cached on-disk (when enabled) since finding a Key Vault from its Base URI requires
+// listing the Resources within the Subscription
+const keyVaultCacheTTL = 24 * time.Hour
+
 var keyVaultsCache = map[string]keyVaultDetails{}
 var keysmith = &sync.RWMutex{}
 var lock = map[string]*sync.RWMutex{}
@@ -22,6 +27,11 @@ type keyVaultDetails struct {
 	resourceGroup    string
 }
 
+type cachedKeyVault struct {
+	KeyVaultId       string `json:"keyVaultId"`
+	DataPlaneBaseUri string `json:"dataPlaneBaseUri"`
+}
+
 func (c *Client) AddToCache(keyVaultId parse.VaultId, dataPlaneUri string) {
 	cacheKey := c.cacheKeyForKeyVault(keyVaultId.Name)
 	keysmith.Lock()
@@ -31,6 +41,42 @@ func (c *Client) AddToCache(keyVaultId parse.VaultId, dataPlaneUri string) {
 		resourceGroup:    keyVaultId.ResourceGroup,
 	}
 	keysmith.Unlock()
+
+	c.providerCache.Set(c.providerCacheKeyForKeyVault(cacheKey), cachedKeyVault{
+		KeyVaultId:       keyVaultId.ID(),
+		DataPlaneBaseUri: dataPlaneUri,
+	})
+}
+
+// cachedDetails returns the cached details for the Key Vault from either the in-memory or on-disk cache
+func (c *Client) cachedDetails(cacheKey string) (*keyVaultDetails, bool) {
+	keysmith.Lock()
+	v, ok := keyVaultsCache[cacheKey]
+	keysmith.Unlock()
+	if ok {
+		return &v, true
+	}
+
+	var cached cachedKeyVault
+	if !c.providerCache.Get(c.providerCacheKeyForKeyVault(cacheKey), keyVaultCacheTTL, &cached) {
+		return nil, false
+	}
+	id, err := parse.VaultID(cached.KeyVaultId)
+	if err != nil {
+		c.providerCache.Delete(c.providerCacheKeyForKeyVault(cacheKey))
+		return nil, false
+	}
+
+	details := keyVaultDetails{
+		keyVaultId:       cached.KeyVaultId,
+		dataPlaneBaseUri: cached.DataPlaneBaseUri,
+		resourceGroup:    id.ResourceGroup,
+	}
+	keysmith.Lock()
+	keyVaultsCache[cacheKey] = details
+	keysmith.Unlock()
+
+	return &details, true
 }
 
 func (c *Client) BaseUriForKeyVault(ctx context.Context, keyVaultId parse.VaultId) (*string, error) {
@@ -43,6 +89,11 @@ func (c *Client) BaseUriForKeyVault(ctx context.Context, keyVaultId parse.VaultI
 	lock[cacheKey].Lock()
 	defer lock[cacheKey].Unlock()
 
+	// a Key Vault with the same name in another Resource Group (or Subscription) is a cache miss
+	if v, ok := c.cachedDetails(cacheKey); ok && strings.EqualFold(v.keyVaultId, keyVaultId.ID()) {
+		return utils.String(v.dataPlaneBaseUri), nil
+	}
+
 	resp, err := c.VaultsClient.Get(ctx, keyVaultId.ResourceGroup, keyVaultId.Name)
 	if err != nil {
 		if utils.ResponseWasNotFound(resp.Response) {
@@ -55,6 +106,8 @@ func (c *Client) BaseUriForKeyVault(ctx context.Context, keyVaultId parse.VaultI
 		return nil, fmt.Errorf("`properties` was nil for %s", keyVaultId)
 	}
 
+	c.AddToCache(keyVaultId, *resp.Properties.VaultURI)
+
 	return resp.Properties.VaultURI, nil
 }
 
@@ -68,7 +121,11 @@ func (c *Client) Exists(ctx context.Context, keyVaultId parse.VaultId) (bool, er
 	lock[cacheKey].Lock()
 	defer lock[cacheKey].Unlock()
 
-	if _, ok := keyVaultsCache[cacheKey]; ok {
+	// this is used to detect when a Key Vault has been deleted, so intentionally doesn't use the on-disk cache
+	keysmith.Lock()
+	v, ok := keyVaultsCache[cacheKey]
+	keysmith.Unlock()
+	if ok && strings.EqualFold(v.keyVaultId, keyVaultId.ID()) {
 		return true, nil
 	}
 
@@ -104,7 +161,7 @@ func (c *Client) KeyVaultIDFromBaseUrl(ctx context.Context, resourcesClient *res
 	lock[cacheKey].Lock()
 	defer lock[cacheKey].Unlock()
 
-	if v, ok := keyVaultsCache[cacheKey]; ok {
+	if v, ok := c.cachedDetails(cacheKey); ok {
 		return &v.keyVaultId, nil
 	}
 
@@ -157,7 +214,10 @@ func (c *Client) Purge(keyVaultId parse.VaultId) {
 	}
 	keysmith.Unlock()
 	lock[cacheKey].Lock()
+	keysmith.Lock()
 	delete(keyVaultsCache, cacheKey)
+	keysmith.Unlock()
+	c.providerCache.Delete(c.providerCacheKeyForKeyVault(cacheKey))
 	lock[cacheKey].Unlock()
 }
 
@@ -165,6 +225,10 @@ func (c *Client) cacheKeyForKeyVault(name string) string {
 	return strings.ToLower(name)
 }
 
+func (c *Client) providerCacheKeyForKeyVault(cacheKey string) string {
+	return fmt.Sprintf("key-vault/%s", cacheKey)
+}
+
 func (c *Client) parseNameFromBaseUrl(input string) (*string, error) {
 	uri, err := url.Parse(input)
 	if err != nil {
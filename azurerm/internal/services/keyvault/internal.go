@@ -12,6 +12,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/keyvault/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
@@ -23,9 +25,31 @@ type deleteAndPurgeNestedItem interface {
 
 	PurgeNestedItem(ctx context.Context) (autorest.Response, error)
 	NestedItemHasBeenPurged(ctx context.Context) (autorest.Response, error)
+
+	// RecoverNestedItem recovers the Soft Deleted item, returning the URI of the recovered item
+	RecoverNestedItem(ctx context.Context) (*string, error)
+}
+
+// recoverSoftDeletedNestedItem should be called when creating a Certificate, Key or Secret fails with a Conflict, which
+// recovers the Soft Deleted item (when the `recover_soft_deleted_key_vaults` feature is enabled) - returning the
+// original error if the Conflict wasn't caused by a Soft Deleted item
+func recoverSoftDeletedNestedItem(ctx context.Context, description string, options features.SoftDeleteFeatures, helper deleteAndPurgeNestedItem, conflictErr error) error {
+	softDeleted := softDeletedNestedItem{
+		description: description,
+		helper:      helper,
+	}
+	recovered, err := sdk.RecoverSoftDeletedOnCreate(ctx, description, "key_vault.recover_soft_deleted_key_vaults", options, softDeleted)
+	if err != nil {
+		return err
+	}
+	if !recovered {
+		return conflictErr
+	}
+
+	return nil
 }
 
-func deleteAndOptionallyPurge(ctx context.Context, description string, shouldPurge bool, helper deleteAndPurgeNestedItem) error {
+func deleteAndOptionallyPurge(ctx context.Context, description string, options features.SoftDeleteFeatures, helper deleteAndPurgeNestedItem) error {
 	timeout, ok := ctx.Deadline()
 	if !ok {
 		return fmt.Errorf("context is missing a timeout")
@@ -64,32 +88,91 @@ func deleteAndOptionallyPurge(ctx context.Context, description string, shouldPur
 	}
 	log.Printf("[DEBUG] Deleted %s.", description)
 
-	if !shouldPurge {
-		log.Printf("[DEBUG] Skipping purging of %s as opted-out..", description)
+	softDeleted := softDeletedNestedItem{
+		description: description,
+		helper:      helper,
+	}
+	return sdk.PurgeSoftDeletedOnDestroy(ctx, description, options, softDeleted)
+}
+
+var _ sdk.SoftDeletedResource = softDeletedNestedItem{}
+
+// softDeletedNestedItem finds, recovers and purges a Soft Deleted Certificate, Key or Secret
+type softDeletedNestedItem struct {
+	description string
+	helper      deleteAndPurgeNestedItem
+}
+
+func (s softDeletedNestedItem) FindSoftDeleted(ctx context.Context) (bool, error) {
+	resp, err := s.helper.NestedItemHasBeenPurged(ctx)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s softDeletedNestedItem) RecoverSoftDeleted(ctx context.Context) error {
+	timeout, ok := ctx.Deadline()
+	if !ok {
+		return fmt.Errorf("context is missing a timeout")
+	}
+
+	uri, err := s.helper.RecoverNestedItem(ctx)
+	if err != nil {
+		return err
+	}
+	if uri == nil {
 		return nil
 	}
 
-	log.Printf("[DEBUG] Purging %s..", description)
+	// We need to wait for consistency, recovered Key Vault Child items are not as readily available as newly created
+	log.Printf("[DEBUG] Waiting for the recovered %s to become available..", s.description)
+	stateConf := &resource.StateChangeConf{
+		Pending:                   []string{"pending"},
+		Target:                    []string{"available"},
+		Refresh:                   keyVaultChildItemRefreshFunc(*uri),
+		Delay:                     30 * time.Second,
+		PollInterval:              10 * time.Second,
+		ContinuousTargetOccurence: 10,
+		Timeout:                   time.Until(timeout),
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("waiting for the recovered %s to become available: %+v", s.description, err)
+	}
+
+	return nil
+}
+
+func (s softDeletedNestedItem) PurgeSoftDeleted(ctx context.Context) error {
+	timeout, ok := ctx.Deadline()
+	if !ok {
+		return fmt.Errorf("context is missing a timeout")
+	}
+
 	err := resource.Retry(time.Until(timeout), func() *resource.RetryError {
-		_, err := helper.PurgeNestedItem(ctx)
+		_, err := s.helper.PurgeNestedItem(ctx)
 		if err == nil {
 			return nil
 		}
 		if strings.Contains(err.Error(), "is currently being deleted") {
-			return resource.RetryableError(fmt.Errorf("%s is currently being deleted, retrying", description))
+			return resource.RetryableError(fmt.Errorf("%s is currently being deleted, retrying", s.description))
 		}
-		return resource.NonRetryableError(fmt.Errorf("Error purging of %s : %+v", description, err))
+		return resource.NonRetryableError(err)
 	})
 	if err != nil {
 		return err
 	}
 
-	log.Printf("[DEBUG] Waiting for %s to finish purging..", description)
-	stateConf = &resource.StateChangeConf{
+	log.Printf("[DEBUG] Waiting for %s to finish purging..", s.description)
+	stateConf := &resource.StateChangeConf{
 		Pending: []string{"InProgress"},
 		Target:  []string{"NotFound"},
 		Refresh: func() (interface{}, string, error) {
-			item, err := helper.NestedItemHasBeenPurged(ctx)
+			item, err := s.helper.NestedItemHasBeenPurged(ctx)
 			if err != nil {
 				if utils.ResponseWasNotFound(item) {
 					return item, "NotFound", nil
@@ -105,9 +188,8 @@ func deleteAndOptionallyPurge(ctx context.Context, description string, shouldPur
 		Timeout:                   time.Until(timeout),
 	}
 	if _, err := stateConf.WaitForState(); err != nil {
-		return fmt.Errorf("waiting for %s to finish purging: %+v", description, err)
+		return fmt.Errorf("waiting for %s to finish purging: %+v", s.description, err)
 	}
-	log.Printf("[DEBUG] Purged %s.", description)
 
 	return nil
 }
@@ -406,29 +406,17 @@ func resourceKeyVaultCertificateCreate(d *schema.ResourceData, meta interface{})
 			Tags:              tags.Expand(t),
 		}
 		if resp, err := client.CreateCertificate(ctx, *keyVaultBaseUrl, name, parameters); err != nil {
-			if meta.(*clients.Client).Features.KeyVault.RecoverSoftDeletedKeyVaults && utils.ResponseWasConflict(resp.Response) {
-				recoveredCertificate, err := client.RecoverDeletedCertificate(ctx, *keyVaultBaseUrl, name)
-				if err != nil {
-					return err
-				}
-				log.Printf("[DEBUG] Recovering Secret %q with ID: %q", name, *recoveredCertificate.ID)
-				if certificate := recoveredCertificate.ID; certificate != nil {
-					stateConf := &resource.StateChangeConf{
-						Pending:                   []string{"pending"},
-						Target:                    []string{"available"},
-						Refresh:                   keyVaultChildItemRefreshFunc(*certificate),
-						Delay:                     30 * time.Second,
-						PollInterval:              10 * time.Second,
-						ContinuousTargetOccurence: 10,
-						Timeout:                   d.Timeout(schema.TimeoutCreate),
-					}
+			if !utils.ResponseWasConflict(resp.Response) {
+				return err
+			}
 
-					if _, err := stateConf.WaitForState(); err != nil {
-						return fmt.Errorf("Error waiting for Key Vault Secret %q to become available: %s", name, err)
-					}
-					log.Printf("[DEBUG] Secret %q recovered with ID: %q", name, *recoveredCertificate.ID)
-				}
-			} else {
+			description := fmt.Sprintf("Certificate %q (Key Vault %q)", name, *keyVaultBaseUrl)
+			helper := deleteAndPurgeCertificate{
+				client:      client,
+				keyVaultUri: *keyVaultBaseUrl,
+				name:        name,
+			}
+			if err := recoverSoftDeletedNestedItem(ctx, description, meta.(*clients.Client).Features.KeyVault.SoftDeleteFeatures(), helper, err); err != nil {
 				return err
 			}
 		}
@@ -610,14 +598,13 @@ func resourceKeyVaultCertificateDelete(d *schema.ResourceData, meta interface{})
 		return nil
 	}
 
-	shouldPurge := meta.(*clients.Client).Features.KeyVault.PurgeSoftDeleteOnDestroy
 	description := fmt.Sprintf("Certificate %q (Key Vault %q)", id.Name, id.KeyVaultBaseUrl)
 	deleter := deleteAndPurgeCertificate{
 		client:      client,
 		keyVaultUri: id.KeyVaultBaseUrl,
 		name:        id.Name,
 	}
-	if err := deleteAndOptionallyPurge(ctx, description, shouldPurge, deleter); err != nil {
+	if err := deleteAndOptionallyPurge(ctx, description, meta.(*clients.Client).Features.KeyVault.SoftDeleteFeatures(), deleter); err != nil {
 		return err
 	}
 
@@ -651,6 +638,11 @@ func (d deleteAndPurgeCertificate) NestedItemHasBeenPurged(ctx context.Context)
 	return resp.Response, err
 }
 
+func (d deleteAndPurgeCertificate) RecoverNestedItem(ctx context.Context) (*string, error) {
+	resp, err := d.client.RecoverDeletedCertificate(ctx, d.keyVaultUri, d.name)
+	return resp.ID, err
+}
+
 func expandKeyVaultCertificatePolicy(d *schema.ResourceData) keyvault.CertificatePolicy {
 	policies := d.Get("certificate_policy").([]interface{})
 	policyRaw := policies[0].(map[string]interface{})
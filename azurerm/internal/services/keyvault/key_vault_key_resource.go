@@ -11,7 +11,6 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/date"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
@@ -232,31 +231,19 @@ func resourceKeyVaultKeyCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if resp, err := client.CreateKey(ctx, *keyVaultBaseUri, name, parameters); err != nil {
-		if meta.(*clients.Client).Features.KeyVault.RecoverSoftDeletedKeyVaults && utils.ResponseWasConflict(resp.Response) {
-			recoveredKey, err := client.RecoverDeletedKey(ctx, *keyVaultBaseUri, name)
-			if err != nil {
-				return err
-			}
-			log.Printf("[DEBUG] Recovering Key %q with ID: %q", name, *recoveredKey.Key.Kid)
-			if kid := recoveredKey.Key.Kid; kid != nil {
-				stateConf := &resource.StateChangeConf{
-					Pending:                   []string{"pending"},
-					Target:                    []string{"available"},
-					Refresh:                   keyVaultChildItemRefreshFunc(*kid),
-					Delay:                     30 * time.Second,
-					PollInterval:              10 * time.Second,
-					ContinuousTargetOccurence: 10,
-					Timeout:                   d.Timeout(schema.TimeoutCreate),
-				}
-
-				if _, err := stateConf.WaitForState(); err != nil {
-					return fmt.Errorf("Error waiting for Key Vault Secret %q to become available: %s", name, err)
-				}
-				log.Printf("[DEBUG] Key %q recovered with ID: %q", name, *kid)
-			}
-		} else {
+		if !utils.ResponseWasConflict(resp.Response) {
 			return fmt.Errorf("Error Creating Key: %+v", err)
 		}
+
+		description := fmt.Sprintf("Key %q (Key Vault %q)", name, *keyVaultBaseUri)
+		helper := deleteAndPurgeKey{
+			client:      client,
+			keyVaultUri: *keyVaultBaseUri,
+			name:        name,
+		}
+		if err := recoverSoftDeletedNestedItem(ctx, description, meta.(*clients.Client).Features.KeyVault.SoftDeleteFeatures(), helper, fmt.Errorf("Error Creating Key: %+v", err)); err != nil {
+			return err
+		}
 	}
 
 	// "" indicates the latest version
@@ -458,14 +445,13 @@ func resourceKeyVaultKeyDelete(d *schema.ResourceData, meta interface{}) error {
 		return nil
 	}
 
-	shouldPurge := meta.(*clients.Client).Features.KeyVault.PurgeSoftDeleteOnDestroy
 	description := fmt.Sprintf("Key %q (Key Vault %q)", id.Name, id.KeyVaultBaseUrl)
 	deleter := deleteAndPurgeKey{
 		client:      client,
 		keyVaultUri: id.KeyVaultBaseUrl,
 		name:        id.Name,
 	}
-	if err := deleteAndOptionallyPurge(ctx, description, shouldPurge, deleter); err != nil {
+	if err := deleteAndOptionallyPurge(ctx, description, meta.(*clients.Client).Features.KeyVault.SoftDeleteFeatures(), deleter); err != nil {
 		return err
 	}
 
@@ -499,6 +485,14 @@ func (d deleteAndPurgeKey) NestedItemHasBeenPurged(ctx context.Context) (autores
 	return resp.Response, err
 }
 
+func (d deleteAndPurgeKey) RecoverNestedItem(ctx context.Context) (*string, error) {
+	resp, err := d.client.RecoverDeletedKey(ctx, d.keyVaultUri, d.name)
+	if err != nil || resp.Key == nil {
+		return nil, err
+	}
+	return resp.Key.Kid, nil
+}
+
 func expandKeyVaultKeyOptions(d *schema.ResourceData) *[]keyvault.JSONWebKeyOperation {
 	options := d.Get("key_opts").([]interface{})
 	results := make([]keyvault.JSONWebKeyOperation, 0, len(options))
@@ -22,6 +22,8 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/location"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/locks"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/resourcemove"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/keyvault/migration"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/keyvault/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/keyvault/validate"
@@ -64,6 +66,8 @@ func resourceKeyVault() *schema.Resource {
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: resourcemove.CustomizeDiff,
+
 		Schema: func() map[string]*schema.Schema {
 			rSchema := map[string]*schema.Schema{
 				"name": {
@@ -75,7 +79,7 @@ func resourceKeyVault() *schema.Resource {
 
 				"location": azure.SchemaLocation(),
 
-				"resource_group_name": azure.SchemaResourceGroupName(),
+				"resource_group_name": resourcemove.SchemaResourceGroupName(),
 
 				"sku_name": {
 					Type:     schema.TypeString,
@@ -271,27 +275,6 @@ func resourceKeyVaultCreate(d *schema.ResourceData, meta interface{}) error {
 		return tf.ImportAsExistsError("azurerm_key_vault", id.ID())
 	}
 
-	// before creating check to see if the key vault exists in the soft delete state
-	softDeletedKeyVault, err := client.GetDeleted(ctx, id.Name, location)
-	if err != nil {
-		// If Terraform lacks permission to read at the Subscription we'll get 409, not 404
-		if !utils.ResponseWasNotFound(softDeletedKeyVault.Response) && !utils.ResponseWasForbidden(softDeletedKeyVault.Response) {
-			return fmt.Errorf("checking for the presence of an existing Soft-Deleted Key Vault %q (Location %q): %+v", id.Name, location, err)
-		}
-	}
-
-	// if so, does the user want us to recover it?
-
-	recoverSoftDeletedKeyVault := false
-	if !utils.ResponseWasNotFound(softDeletedKeyVault.Response) && !utils.ResponseWasForbidden(softDeletedKeyVault.Response) {
-		if !meta.(*clients.Client).Features.KeyVault.RecoverSoftDeletedKeyVaults {
-			// this exists but the users opted out so they must import this it out-of-band
-			return fmt.Errorf(optedOutOfRecoveringSoftDeletedKeyVaultErrorFmt(id.Name, location))
-		}
-
-		recoverSoftDeletedKeyVault = true
-	}
-
 	tenantUUID := uuid.FromStringOrNil(d.Get("tenant_id").(string))
 	enabledForDeployment := d.Get("enabled_for_deployment").(bool)
 	enabledForDiskEncryption := d.Get("enabled_for_disk_encryption").(bool)
@@ -338,10 +321,6 @@ func resourceKeyVaultCreate(d *schema.ResourceData, meta interface{}) error {
 		parameters.Properties.SoftDeleteRetentionInDays = utils.Int32(int32(v.(int)))
 	}
 
-	if recoverSoftDeletedKeyVault {
-		parameters.Properties.CreateMode = keyvault.CreateModeRecover
-	}
-
 	// also lock on the Virtual Network ID's since modifications in the networking stack are exclusive
 	virtualNetworkNames := make([]string, 0)
 	for _, v := range subnetIds {
@@ -357,8 +336,22 @@ func resourceKeyVaultCreate(d *schema.ResourceData, meta interface{}) error {
 	locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
 	defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
 
-	if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.Name, parameters); err != nil {
-		return fmt.Errorf("creating %s: %+v", id, err)
+	// before creating check to see if the key vault exists in the soft delete state - and if so, whether the user wants us to recover it
+	softDeleted := keyVaultSoftDeletedVault{
+		client:     client,
+		id:         id,
+		location:   location,
+		parameters: parameters,
+	}
+	recovered, err := sdk.RecoverSoftDeletedOnCreate(ctx, id.String(), "key_vault.recover_soft_deleted_key_vaults", meta.(*clients.Client).Features.KeyVault.SoftDeleteFeatures(), softDeleted)
+	if err != nil {
+		return err
+	}
+
+	if !recovered {
+		if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.Name, parameters); err != nil {
+			return fmt.Errorf("creating %s: %+v", id, err)
+		}
 	}
 
 	read, err := client.Get(ctx, id.ResourceGroup, id.Name)
@@ -421,6 +414,18 @@ func resourceKeyVaultUpdate(d *schema.ResourceData, meta interface{}) error {
 	locks.ByName(id.Name, keyVaultResourceName)
 	defer locks.UnlockByName(id.Name, keyVaultResourceName)
 
+	if d.HasChange("resource_group_name") {
+		if err := resourcemove.MoveIfResourceGroupChanged(ctx, d, meta); err != nil {
+			return err
+		}
+
+		// the cached details for this Key Vault are refreshed during the Read
+		id, err = parse.VaultID(d.Id())
+		if err != nil {
+			return err
+		}
+	}
+
 	d.Partial(true)
 
 	// first pull the existing key vault since we need to lock on several bits of its information
@@ -763,9 +768,11 @@ func resourceKeyVaultDelete(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	// Purge the soft deleted key vault permanently if the feature flag is enabled
-	if meta.(*clients.Client).Features.KeyVault.PurgeSoftDeleteOnDestroy && softDeleteEnabled {
+	if softDeleteEnabled {
+		options := meta.(*clients.Client).Features.KeyVault.SoftDeleteFeatures()
+
 		// KeyVaults with Purge Protection Enabled cannot be deleted unless done by Azure
-		if purgeProtectionEnabled {
+		if options.PurgeSoftDeleteOnDestroy && purgeProtectionEnabled {
 			deletedInfo, err := getSoftDeletedStateForKeyVault(ctx, client, id.Name, *read.Location)
 			if err != nil {
 				return fmt.Errorf("retrieving the Deletion Details for %s: %+v", *id, err)
@@ -777,21 +784,17 @@ func resourceKeyVaultDelete(d *schema.ResourceData, meta interface{}) error {
 			} else {
 				log.Printf("[DEBUG] The Key Vault %q has Purge Protection Enabled and will be purged automatically by Azure", id.Name)
 			}
-			return nil
+			options.PurgeSoftDeleteOnDestroy = false
 		}
 
-		log.Printf("[DEBUG] KeyVault %q marked for purge - executing purge", id.Name)
-		future, err := client.PurgeDeleted(ctx, id.Name, *read.Location)
-		if err != nil {
-			return err
+		softDeleted := keyVaultSoftDeletedVault{
+			client:   client,
+			id:       *id,
+			location: azure.NormalizeLocation(*read.Location),
 		}
-
-		log.Printf("[DEBUG] Waiting for purge of KeyVault %q..", id.Name)
-		err = future.WaitForCompletionRef(ctx, client.Client)
-		if err != nil {
-			return fmt.Errorf("purging %s: %+v", *id, err)
+		if err := sdk.PurgeSoftDeletedOnDestroy(ctx, id.String(), options, softDeleted); err != nil {
+			return err
 		}
-		log.Printf("[DEBUG] Purged KeyVault %q.", id.Name)
 	}
 
 	meta.(*clients.Client).KeyVault.Purge(*id)
@@ -963,22 +966,6 @@ func flattenKeyVaultCertificateContactList(input KeyVaultMgmt.Contacts) []interf
 	return results
 }
 
-func optedOutOfRecoveringSoftDeletedKeyVaultErrorFmt(name, location string) string {
-	return fmt.Sprintf(`
-An existing soft-deleted Key Vault exists with the Name %q in the location %q, however
-automatically recovering this KeyVault has been disabled via the "features" block.
-
-Terraform can automatically recover the soft-deleted Key Vault when this behaviour is
-enabled within the "features" block (located within the "provider" block) - more
-information can be found here:
-
-https://www.terraform.io/docs/providers/azurerm/index.html#features
-
-Alternatively you can manually recover this (e.g. using the Azure CLI) and then import
-this into Terraform via "terraform import", or pick a different name/location.
-`, name, location)
-}
-
 type keyVaultDeletionStatus struct {
 	deleteDate string
 	purgeDate  string
@@ -10,7 +10,6 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/date"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
@@ -146,33 +145,19 @@ func resourceKeyVaultSecretCreate(d *schema.ResourceData, meta interface{}) erro
 	}
 
 	if resp, err := client.SetSecret(ctx, *keyVaultBaseUrl, name, parameters); err != nil {
-		// In the case that the Secret already exists in a Soft Deleted / Recoverable state we check if `recover_soft_deleted_key_vaults` is set
-		// and attempt recovery where appropriate
-		if meta.(*clients.Client).Features.KeyVault.RecoverSoftDeletedKeyVaults && utils.ResponseWasConflict(resp.Response) {
-			recoveredSecret, err := client.RecoverDeletedSecret(ctx, *keyVaultBaseUrl, name)
-			if err != nil {
-				return err
-			}
-			log.Printf("[DEBUG] Recovering Secret %q with ID: %q", name, *recoveredSecret.ID)
-			// We need to wait for consistency, recovered Key Vault Child items are not as readily available as newly created
-			if secret := recoveredSecret.ID; secret != nil {
-				stateConf := &resource.StateChangeConf{
-					Pending:                   []string{"pending"},
-					Target:                    []string{"available"},
-					Refresh:                   keyVaultChildItemRefreshFunc(*secret),
-					Delay:                     30 * time.Second,
-					PollInterval:              10 * time.Second,
-					ContinuousTargetOccurence: 10,
-					Timeout:                   d.Timeout(schema.TimeoutCreate),
-				}
-
-				if _, err := stateConf.WaitForState(); err != nil {
-					return fmt.Errorf("Error waiting for Key Vault Secret %q to become available: %s", name, err)
-				}
-				log.Printf("[DEBUG] Secret %q recovered with ID: %q", name, *recoveredSecret.ID)
-			}
-		} else {
-			// If the error response was anything else, or `recover_soft_deleted_key_vaults` is `false` just return the error
+		// In the case that the Secret already exists in a Soft Deleted / Recoverable state this is recovered
+		// when `recover_soft_deleted_key_vaults` is set
+		if !utils.ResponseWasConflict(resp.Response) {
+			return err
+		}
+
+		description := fmt.Sprintf("Secret %q (Key Vault %q)", name, *keyVaultBaseUrl)
+		helper := deleteAndPurgeSecret{
+			client:      client,
+			keyVaultUri: *keyVaultBaseUrl,
+			name:        name,
+		}
+		if err := recoverSoftDeletedNestedItem(ctx, description, meta.(*clients.Client).Features.KeyVault.SoftDeleteFeatures(), helper, err); err != nil {
 			return err
 		}
 	}
@@ -391,14 +376,13 @@ func resourceKeyVaultSecretDelete(d *schema.ResourceData, meta interface{}) erro
 		return nil
 	}
 
-	shouldPurge := meta.(*clients.Client).Features.KeyVault.PurgeSoftDeleteOnDestroy
 	description := fmt.Sprintf("Secret %q (Key Vault %q)", id.Name, id.KeyVaultBaseUrl)
 	deleter := deleteAndPurgeSecret{
 		client:      client,
 		keyVaultUri: id.KeyVaultBaseUrl,
 		name:        id.Name,
 	}
-	if err := deleteAndOptionallyPurge(ctx, description, shouldPurge, deleter); err != nil {
+	if err := deleteAndOptionallyPurge(ctx, description, meta.(*clients.Client).Features.KeyVault.SoftDeleteFeatures(), deleter); err != nil {
 		return err
 	}
 
@@ -431,3 +415,8 @@ func (d deleteAndPurgeSecret) NestedItemHasBeenPurged(ctx context.Context) (auto
 	resp, err := d.client.GetDeletedSecret(ctx, d.keyVaultUri, d.name)
 	return resp.Response, err
 }
+
+func (d deleteAndPurgeSecret) RecoverNestedItem(ctx context.Context) (*string, error) {
+	resp, err := d.client.RecoverDeletedSecret(ctx, d.keyVaultUri, d.name)
+	return resp.ID, err
+}
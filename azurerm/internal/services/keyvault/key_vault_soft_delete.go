@@ -0,0 +1,60 @@
+package keyvault
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/mgmt/2019-09-01/keyvault"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/keyvault/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+var _ sdk.SoftDeletedResource = keyVaultSoftDeletedVault{}
+
+// keyVaultSoftDeletedVault finds, recovers and purges a Soft Deleted Key Vault
+type keyVaultSoftDeletedVault struct {
+	client   *keyvault.VaultsClient
+	id       parse.VaultId
+	location string
+
+	// parameters are the parameters which the Key Vault is recovered with
+	parameters keyvault.VaultCreateOrUpdateParameters
+}
+
+func (v keyVaultSoftDeletedVault) FindSoftDeleted(ctx context.Context) (bool, error) {
+	resp, err := v.client.GetDeleted(ctx, v.id.Name, v.location)
+	if err != nil {
+		// If Terraform lacks permission to read at the Subscription we'll get 403, not 404
+		if utils.ResponseWasNotFound(resp.Response) || utils.ResponseWasForbidden(resp.Response) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (v keyVaultSoftDeletedVault) RecoverSoftDeleted(ctx context.Context) error {
+	parameters := v.parameters
+	if parameters.Properties != nil {
+		properties := *parameters.Properties
+		properties.CreateMode = keyvault.CreateModeRecover
+		parameters.Properties = &properties
+	}
+
+	future, err := v.client.CreateOrUpdate(ctx, v.id.ResourceGroup, v.id.Name, parameters)
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, v.client.Client)
+}
+
+func (v keyVaultSoftDeletedVault) PurgeSoftDeleted(ctx context.Context) error {
+	future, err := v.client.PurgeDeleted(ctx, v.id.Name, v.location)
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, v.client.Client)
+}
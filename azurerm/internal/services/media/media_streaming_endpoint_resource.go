@@ -230,6 +230,7 @@ func resourceMediaStreamingEndpointCreate(d *schema.ResourceData, meta interface
 			ScaleUnits: utils.Int32(int32(scaleUnits)),
 		},
 		Location: utils.String(location),
+		Tags:     tags.Expand(d.Get("tags").(map[string]interface{})),
 	}
 
 	autoStart := utils.Bool(false)
@@ -303,6 +304,7 @@ func resourceMediaStreamingEndpointUpdate(d *schema.ResourceData, meta interface
 			ScaleUnits: utils.Int32(int32(scaleUnits)),
 		},
 		Location: utils.String(location),
+		Tags:     tags.Expand(d.Get("tags").(map[string]interface{})),
 	}
 
 	if d.HasChange("scale_units") {
@@ -427,7 +429,7 @@ func resourceMediaStreamingEndpointRead(d *schema.ResourceData, meta interface{}
 		d.Set("max_cache_age_seconds", maxCacheAge)
 	}
 
-	return nil
+	return tags.FlattenAndSet(d, resp.Tags)
 }
 
 func resourceMediaStreamingEndpointDelete(d *schema.ResourceData, meta interface{}) error {
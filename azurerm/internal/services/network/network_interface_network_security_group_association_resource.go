@@ -69,17 +69,21 @@ func resourceNetworkInterfaceSecurityGroupAssociationCreate(d *schema.ResourceDa
 	networkInterfaceName := nicId.Path["networkInterfaces"]
 	resourceGroup := nicId.ResourceGroup
 
-	locks.ByName(networkInterfaceName, networkInterfaceResourceName)
-	defer locks.UnlockByName(networkInterfaceName, networkInterfaceResourceName)
-
 	nsgId, err := azure.ParseAzureResourceID(networkSecurityGroupId)
 	if err != nil {
 		return err
 	}
 	nsgName := nsgId.Path["networkSecurityGroups"]
 
-	locks.ByName(nsgName, networkSecurityGroupResourceName)
-	defer locks.UnlockByName(nsgName, networkSecurityGroupResourceName)
+	// the Network Security Group is only referenced, so is locked for reading
+	names := []locks.NameLock{
+		{Name: networkInterfaceName, ResourceType: networkInterfaceResourceName},
+		{Name: nsgName, ResourceType: networkSecurityGroupResourceName, Read: true},
+	}
+	if err := locks.MultipleNamesWithContext(ctx, names...); err != nil {
+		return fmt.Errorf("associating Network Security Group %q with Network Interface %q (Resource Group %q): %+v", nsgName, networkInterfaceName, resourceGroup, err)
+	}
+	defer locks.UnlockMultipleNames(names...)
 
 	read, err := client.Get(ctx, resourceGroup, networkInterfaceName, "")
 	if err != nil {
@@ -54,6 +54,8 @@ func resourcePrivateEndpoint() *schema.Resource {
 
 			"resource_group_name": azure.SchemaResourceGroupNameDiffSuppress(),
 
+			"subscription_id": azure.SchemaSubscriptionIdOverride(),
+
 			"subnet_id": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -211,9 +213,13 @@ func resourcePrivateEndpoint() *schema.Resource {
 }
 
 func resourcePrivateEndpointCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Network.PrivateEndpointClient
-	dnsClient := meta.(*clients.Client).Network.PrivateDnsZoneGroupClient
-	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	subscriptionClient, err := meta.(*clients.Client).ForSubscription(d.Get("subscription_id").(string))
+	if err != nil {
+		return err
+	}
+	client := subscriptionClient.Network.PrivateEndpointClient
+	dnsClient := subscriptionClient.Network.PrivateDnsZoneGroupClient
+	subscriptionId := subscriptionClient.Account.SubscriptionId
 	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -279,8 +285,6 @@ func resourcePrivateEndpointCreate(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourcePrivateEndpointUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Network.PrivateEndpointClient
-	dnsClient := meta.(*clients.Client).Network.PrivateDnsZoneGroupClient
 	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -289,6 +293,13 @@ func resourcePrivateEndpointUpdate(d *schema.ResourceData, meta interface{}) err
 		return err
 	}
 
+	subscriptionClient, err := meta.(*clients.Client).ForSubscription(id.SubscriptionId)
+	if err != nil {
+		return err
+	}
+	client := subscriptionClient.Network.PrivateEndpointClient
+	dnsClient := subscriptionClient.Network.PrivateDnsZoneGroupClient
+
 	if err := ValidatePrivateEndpointSettings(d); err != nil {
 		return fmt.Errorf("validating the configuration for the Private Endpoint %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
 	}
@@ -372,9 +383,6 @@ func resourcePrivateEndpointUpdate(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourcePrivateEndpointRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Network.PrivateEndpointClient
-	nicsClient := meta.(*clients.Client).Network.InterfacesClient
-	dnsClient := meta.(*clients.Client).Network.PrivateDnsZoneGroupClient
 	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -383,6 +391,14 @@ func resourcePrivateEndpointRead(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
+	subscriptionClient, err := meta.(*clients.Client).ForSubscription(id.SubscriptionId)
+	if err != nil {
+		return err
+	}
+	client := subscriptionClient.Network.PrivateEndpointClient
+	nicsClient := subscriptionClient.Network.InterfacesClient
+	dnsClient := subscriptionClient.Network.PrivateDnsZoneGroupClient
+
 	resp, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
 	if err != nil {
 		if utils.ResponseWasNotFound(resp.Response) {
@@ -400,6 +416,7 @@ func resourcePrivateEndpointRead(d *schema.ResourceData, meta interface{}) error
 
 	d.Set("name", id.Name)
 	d.Set("resource_group_name", id.ResourceGroup)
+	d.Set("subscription_id", id.SubscriptionId)
 	d.Set("location", location.NormalizeNilable(resp.Location))
 
 	if props := resp.PrivateEndpointProperties; props != nil {
@@ -455,8 +472,6 @@ func resourcePrivateEndpointRead(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourcePrivateEndpointDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Network.PrivateEndpointClient
-	dnsZoneGroupsClient := meta.(*clients.Client).Network.PrivateDnsZoneGroupClient
 	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -465,6 +480,13 @@ func resourcePrivateEndpointDelete(d *schema.ResourceData, meta interface{}) err
 		return err
 	}
 
+	subscriptionClient, err := meta.(*clients.Client).ForSubscription(id.SubscriptionId)
+	if err != nil {
+		return err
+	}
+	client := subscriptionClient.Network.PrivateEndpointClient
+	dnsZoneGroupsClient := subscriptionClient.Network.PrivateDnsZoneGroupClient
+
 	log.Printf("[DEBUG] Deleting the Private DNS Zone Group associated with Private Endpoint %q / Resource Group %q..", id.Name, id.ResourceGroup)
 	if err := deletePrivateDnsZoneGroupForPrivateEndpoint(ctx, dnsZoneGroupsClient, *id); err != nil {
 		return err
@@ -70,18 +70,16 @@ func resourceSubnetNetworkSecurityGroupAssociationCreate(d *schema.ResourceData,
 		return err
 	}
 
-	locks.ByName(parsedNetworkSecurityGroupId.Name, networkSecurityGroupResourceName)
-	defer locks.UnlockByName(parsedNetworkSecurityGroupId.Name, networkSecurityGroupResourceName)
-
 	subnetName := parsedSubnetId.Path["subnets"]
 	virtualNetworkName := parsedSubnetId.Path["virtualNetworks"]
 	resourceGroup := parsedSubnetId.ResourceGroup
 
-	locks.ByName(subnetName, SubnetResourceName)
-	defer locks.UnlockByName(subnetName, SubnetResourceName)
-
-	locks.ByName(virtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(virtualNetworkName, VirtualNetworkResourceName)
+	// the Network Security Group is locked for writing, since updating it (or its Rules) conflicts with associating it
+	names := subnetNetworkSecurityGroupAssociationLocks(parsedNetworkSecurityGroupId.Name, virtualNetworkName, subnetName)
+	if err := locks.MultipleNamesWithContext(ctx, names...); err != nil {
+		return fmt.Errorf("associating Network Security Group %q with Subnet %q (Virtual Network %q / Resource Group %q): %+v", parsedNetworkSecurityGroupId.Name, subnetName, virtualNetworkName, resourceGroup, err)
+	}
+	defer locks.UnlockMultipleNames(names...)
 
 	subnet, err := client.Get(ctx, resourceGroup, virtualNetworkName, subnetName, "")
 	if err != nil {
@@ -205,14 +203,11 @@ func resourceSubnetNetworkSecurityGroupAssociationDelete(d *schema.ResourceData,
 		return err
 	}
 
-	locks.ByName(parsedNetworkSecurityGroupId.Name, networkSecurityGroupResourceName)
-	defer locks.UnlockByName(parsedNetworkSecurityGroupId.Name, networkSecurityGroupResourceName)
-
-	locks.ByName(virtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(virtualNetworkName, VirtualNetworkResourceName)
-
-	locks.ByName(subnetName, SubnetResourceName)
-	defer locks.UnlockByName(subnetName, SubnetResourceName)
+	names := subnetNetworkSecurityGroupAssociationLocks(parsedNetworkSecurityGroupId.Name, virtualNetworkName, subnetName)
+	if err := locks.MultipleNamesWithContext(ctx, names...); err != nil {
+		return fmt.Errorf("removing Network Security Group Association from Subnet %q (Virtual Network %q / Resource Group %q): %+v", subnetName, virtualNetworkName, resourceGroup, err)
+	}
+	defer locks.UnlockMultipleNames(names...)
 
 	// then re-retrieve it to ensure we've got the latest state
 	read, err = client.Get(ctx, resourceGroup, virtualNetworkName, subnetName, "")
@@ -238,3 +233,11 @@ func resourceSubnetNetworkSecurityGroupAssociationDelete(d *schema.ResourceData,
 
 	return nil
 }
+
+func subnetNetworkSecurityGroupAssociationLocks(networkSecurityGroupName, virtualNetworkName, subnetName string) []locks.NameLock {
+	return []locks.NameLock{
+		{Name: networkSecurityGroupName, ResourceType: networkSecurityGroupResourceName},
+		{Name: virtualNetworkName, ResourceType: VirtualNetworkResourceName},
+		{Name: subnetName, ResourceType: SubnetResourceName},
+	}
+}
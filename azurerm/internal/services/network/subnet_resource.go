@@ -198,8 +198,10 @@ func resourceSubnetCreate(d *schema.ResourceData, meta interface{}) error {
 		return tf.ImportAsExistsError("azurerm_subnet", id.ID())
 	}
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	if err := locks.MultipleNamesWithContext(ctx, subnetLocks(id)...); err != nil {
+		return fmt.Errorf("creating %s: %+v", id, err)
+	}
+	defer locks.UnlockMultipleNames(subnetLocks(id)...)
 
 	properties := network.SubnetPropertiesFormat{}
 	if value, ok := d.GetOk("address_prefixes"); ok {
@@ -397,11 +399,10 @@ func resourceSubnetDelete(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-
-	locks.ByName(id.Name, SubnetResourceName)
-	defer locks.UnlockByName(id.Name, SubnetResourceName)
+	if err := locks.MultipleNamesWithContext(ctx, subnetLocks(*id)...); err != nil {
+		return fmt.Errorf("deleting %s: %+v", *id, err)
+	}
+	defer locks.UnlockMultipleNames(subnetLocks(*id)...)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.VirtualNetworkName, id.Name)
 	if err != nil {
@@ -560,3 +561,12 @@ func flattenSubnetServiceEndpointPolicies(input *[]network.ServiceEndpointPolicy
 	}
 	return output
 }
+
+// subnetLocks returns the names locked when creating or deleting a Subnet - Subnets within the same
+// Virtual Network can't be modified in parallel, so the Virtual Network is locked for writing
+func subnetLocks(id parse.SubnetId) []locks.NameLock {
+	return []locks.NameLock{
+		{Name: id.VirtualNetworkName, ResourceType: VirtualNetworkResourceName},
+		{Name: id.Name, ResourceType: SubnetResourceName},
+	}
+}
@@ -70,15 +70,16 @@ func resourceSubnetRouteTableAssociationCreate(d *schema.ResourceData, meta inte
 		return err
 	}
 
-	locks.ByName(parsedRouteTableId.Name, routeTableResourceName)
-	defer locks.UnlockByName(parsedRouteTableId.Name, routeTableResourceName)
-
 	subnetName := parsedSubnetId.Name
 	virtualNetworkName := parsedSubnetId.VirtualNetworkName
 	resourceGroup := parsedSubnetId.ResourceGroup
 
-	locks.ByName(virtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(virtualNetworkName, VirtualNetworkResourceName)
+	// the Route Table is locked for writing, since updating it (or its Routes) conflicts with associating it
+	names := subnetRouteTableAssociationLocks(parsedRouteTableId.Name, virtualNetworkName, subnetName)
+	if err := locks.MultipleNamesWithContext(ctx, names...); err != nil {
+		return fmt.Errorf("associating Route Table %q with Subnet %q (Virtual Network %q / Resource Group %q): %+v", parsedRouteTableId.Name, subnetName, virtualNetworkName, resourceGroup, err)
+	}
+	defer locks.UnlockMultipleNames(names...)
 
 	subnet, err := client.Get(ctx, resourceGroup, virtualNetworkName, subnetName, "")
 	if err != nil {
@@ -202,11 +203,11 @@ func resourceSubnetRouteTableAssociationDelete(d *schema.ResourceData, meta inte
 		return err
 	}
 
-	locks.ByName(parsedRouteTableId.Name, routeTableResourceName)
-	defer locks.UnlockByName(parsedRouteTableId.Name, routeTableResourceName)
-
-	locks.ByName(virtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(virtualNetworkName, VirtualNetworkResourceName)
+	names := subnetRouteTableAssociationLocks(parsedRouteTableId.Name, virtualNetworkName, subnetName)
+	if err := locks.MultipleNamesWithContext(ctx, names...); err != nil {
+		return fmt.Errorf("removing Route Table Association from Subnet %q (Virtual Network %q / Resource Group %q): %+v", subnetName, virtualNetworkName, resourceGroup, err)
+	}
+	defer locks.UnlockMultipleNames(names...)
 
 	// then re-retrieve it to ensure we've got the latest state
 	read, err = client.Get(ctx, resourceGroup, virtualNetworkName, subnetName, "")
@@ -232,3 +233,11 @@ func resourceSubnetRouteTableAssociationDelete(d *schema.ResourceData, meta inte
 
 	return nil
 }
+
+func subnetRouteTableAssociationLocks(routeTableName, virtualNetworkName, subnetName string) []locks.NameLock {
+	return []locks.NameLock{
+		{Name: routeTableName, ResourceType: routeTableResourceName},
+		{Name: virtualNetworkName, ResourceType: VirtualNetworkResourceName},
+		{Name: subnetName, ResourceType: SubnetResourceName},
+	}
+}
@@ -47,6 +47,8 @@ func resourceVirtualNetworkPeering() *schema.Resource {
 
 			"resource_group_name": azure.SchemaResourceGroupName(),
 
+			"subscription_id": azure.SchemaSubscriptionIdOverride(),
+
 			"virtual_network_name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -87,7 +89,11 @@ func resourceVirtualNetworkPeering() *schema.Resource {
 }
 
 func resourceVirtualNetworkPeeringCreateUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Network.VnetPeeringsClient
+	subscriptionClient, err := meta.(*clients.Client).ForSubscription(d.Get("subscription_id").(string))
+	if err != nil {
+		return err
+	}
+	client := subscriptionClient.Network.VnetPeeringsClient
 	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -118,7 +124,7 @@ func resourceVirtualNetworkPeeringCreateUpdate(d *schema.ResourceData, meta inte
 	peerMutex.Lock()
 	defer peerMutex.Unlock()
 
-	if err := resource.Retry(300*time.Second, retryVnetPeeringsClientCreateUpdate(d, resGroup, vnetName, name, peer, meta)); err != nil {
+	if err := resource.Retry(300*time.Second, retryVnetPeeringsClientCreateUpdate(d, resGroup, vnetName, name, peer, subscriptionClient)); err != nil {
 		return err
 	}
 
@@ -136,7 +142,6 @@ func resourceVirtualNetworkPeeringCreateUpdate(d *schema.ResourceData, meta inte
 }
 
 func resourceVirtualNetworkPeeringRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Network.VnetPeeringsClient
 	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -144,6 +149,12 @@ func resourceVirtualNetworkPeeringRead(d *schema.ResourceData, meta interface{})
 	if err != nil {
 		return err
 	}
+
+	subscriptionClient, err := meta.(*clients.Client).ForSubscription(id.SubscriptionID)
+	if err != nil {
+		return err
+	}
+	client := subscriptionClient.Network.VnetPeeringsClient
 	resGroup := id.ResourceGroup
 	vnetName := id.Path["virtualNetworks"]
 	name := id.Path["virtualNetworkPeerings"]
@@ -159,6 +170,7 @@ func resourceVirtualNetworkPeeringRead(d *schema.ResourceData, meta interface{})
 
 	// update appropriate values
 	d.Set("resource_group_name", resGroup)
+	d.Set("subscription_id", id.SubscriptionID)
 	d.Set("name", resp.Name)
 	d.Set("virtual_network_name", vnetName)
 
@@ -176,7 +188,6 @@ func resourceVirtualNetworkPeeringRead(d *schema.ResourceData, meta interface{})
 }
 
 func resourceVirtualNetworkPeeringDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Network.VnetPeeringsClient
 	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -184,6 +195,12 @@ func resourceVirtualNetworkPeeringDelete(d *schema.ResourceData, meta interface{
 	if err != nil {
 		return err
 	}
+
+	subscriptionClient, err := meta.(*clients.Client).ForSubscription(id.SubscriptionID)
+	if err != nil {
+		return err
+	}
+	client := subscriptionClient.Network.VnetPeeringsClient
 	resGroup := id.ResourceGroup
 	vnetName := id.Path["virtualNetworks"]
 	name := id.Path["virtualNetworkPeerings"]
@@ -221,10 +238,10 @@ func getVirtualNetworkPeeringProperties(d *schema.ResourceData) *network.Virtual
 	}
 }
 
-func retryVnetPeeringsClientCreateUpdate(d *schema.ResourceData, resGroup string, vnetName string, name string, peer network.VirtualNetworkPeering, meta interface{}) func() *resource.RetryError {
+func retryVnetPeeringsClientCreateUpdate(d *schema.ResourceData, resGroup string, vnetName string, name string, peer network.VirtualNetworkPeering, meta *clients.Client) func() *resource.RetryError {
 	return func() *resource.RetryError {
-		vnetPeeringsClient := meta.(*clients.Client).Network.VnetPeeringsClient
-		ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+		vnetPeeringsClient := meta.Network.VnetPeeringsClient
+		ctx, cancel := timeouts.ForCreateUpdate(meta.StopContext, d)
 		defer cancel()
 
 		future, err := vnetPeeringsClient.CreateOrUpdate(ctx, resGroup, vnetName, name, peer)
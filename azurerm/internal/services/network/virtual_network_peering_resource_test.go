@@ -98,6 +98,29 @@ func TestAccVirtualNetworkPeering_update(t *testing.T) {
 	})
 }
 
+func TestAccVirtualNetworkPeering_subscriptionId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_virtual_network_peering", "test1")
+	r := VirtualNetworkPeeringResource{}
+	secondResourceName := "azurerm_virtual_network_peering.test2"
+
+	if data.Client().SubscriptionIDAlt == "" {
+		t.Skip("Skipping since `ARM_SUBSCRIPTION_ID_ALT` isn't specified")
+	}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.subscriptionId(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(secondResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("subscription_id").HasValue(data.Client().SubscriptionIDAlt),
+				check.That(secondResourceName).Key("subscription_id").HasValue(data.Client().SubscriptionID),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func (t VirtualNetworkPeeringResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
 	id, err := azure.ParseAzureResourceID(state.ID)
 	if err != nil {
@@ -107,7 +130,12 @@ func (t VirtualNetworkPeeringResource) Exists(ctx context.Context, clients *clie
 	vnetName := id.Path["virtualNetworks"]
 	name := id.Path["virtualNetworkPeerings"]
 
-	resp, err := clients.Network.VnetPeeringsClient.Get(ctx, resGroup, vnetName, name)
+	subscriptionClient, err := clients.ForSubscription(id.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := subscriptionClient.Network.VnetPeeringsClient.Get(ctx, resGroup, vnetName, name)
 	if err != nil {
 		return nil, fmt.Errorf("reading Virtual Network Peering (%s): %+v", id, err)
 	}
@@ -124,12 +152,17 @@ func (r VirtualNetworkPeeringResource) Destroy(ctx context.Context, client *clie
 	vnetName := id.Path["virtualNetworks"]
 	name := id.Path["virtualNetworkPeerings"]
 
-	future, err := client.Network.VnetPeeringsClient.Delete(ctx, resGroup, vnetName, name)
+	subscriptionClient, err := client.ForSubscription(id.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	future, err := subscriptionClient.Network.VnetPeeringsClient.Delete(ctx, resGroup, vnetName, name)
 	if err != nil {
 		return nil, fmt.Errorf("deleting on virtual network peering: %+v", err)
 	}
 
-	if err = future.WaitForCompletionRef(ctx, client.Network.VnetPeeringsClient.Client); err != nil {
+	if err = future.WaitForCompletionRef(ctx, subscriptionClient.Network.VnetPeeringsClient.Client); err != nil {
 		return nil, fmt.Errorf("waiting for deletion of Peering %q: %+v", id, err)
 	}
 
@@ -237,3 +270,60 @@ resource "azurerm_virtual_network_peering" "test2" {
 }
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger)
 }
+
+func (VirtualNetworkPeeringResource) subscriptionId(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+provider "azurerm" {
+  alias           = "alt"
+  subscription_id = "%s"
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_resource_group" "alt" {
+  provider = azurerm.alt
+  name     = "acctestRG-alt-%d"
+  location = "%s"
+}
+
+resource "azurerm_virtual_network" "test1" {
+  provider            = azurerm.alt
+  name                = "acctestvirtnet-1-%d"
+  resource_group_name = azurerm_resource_group.alt.name
+  address_space       = ["10.0.1.0/24"]
+  location            = azurerm_resource_group.alt.location
+}
+
+resource "azurerm_virtual_network" "test2" {
+  name                = "acctestvirtnet-2-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  address_space       = ["10.0.2.0/24"]
+  location            = azurerm_resource_group.test.location
+}
+
+resource "azurerm_virtual_network_peering" "test1" {
+  name                         = "acctestpeer-1-%d"
+  subscription_id              = "%s"
+  resource_group_name          = azurerm_resource_group.alt.name
+  virtual_network_name         = azurerm_virtual_network.test1.name
+  remote_virtual_network_id    = azurerm_virtual_network.test2.id
+  allow_virtual_network_access = true
+}
+
+resource "azurerm_virtual_network_peering" "test2" {
+  name                         = "acctestpeer-2-%d"
+  resource_group_name          = azurerm_resource_group.test.name
+  virtual_network_name         = azurerm_virtual_network.test2.name
+  remote_virtual_network_id    = azurerm_virtual_network.test1.id
+  allow_virtual_network_access = true
+}
+`, data.Client().SubscriptionIDAlt, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.Client().SubscriptionIDAlt, data.RandomInteger)
+}
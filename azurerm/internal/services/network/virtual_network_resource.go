@@ -199,7 +199,9 @@ func resourceVirtualNetworkCreateUpdate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
-	locks.MultipleByName(&networkSecurityGroupNames, networkSecurityGroupResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, &networkSecurityGroupNames, networkSecurityGroupResourceName); err != nil {
+		return fmt.Errorf("locking the Network Security Groups for %s: %+v", id, err)
+	}
 	defer locks.UnlockMultipleByName(&networkSecurityGroupNames, networkSecurityGroupResourceName)
 
 	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.Name, vnet)
@@ -291,7 +293,9 @@ func resourceVirtualNetworkDelete(d *schema.ResourceData, meta interface{}) erro
 		return fmt.Errorf("Error parsing Network Security Group ID's: %+v", err)
 	}
 
-	locks.MultipleByName(&nsgNames, VirtualNetworkResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, &nsgNames, VirtualNetworkResourceName); err != nil {
+		return fmt.Errorf("locking the Network Security Groups for %s: %+v", *id, err)
+	}
 	defer locks.UnlockMultipleByName(&nsgNames, VirtualNetworkResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
@@ -193,7 +193,7 @@ func resourcePrivateDnsZoneCreateUpdate(d *schema.ResourceData, meta interface{}
 		rsParameters := privatedns.RecordSet{
 			RecordSetProperties: &privatedns.RecordSetProperties{
 				TTL:       utils.Int64(int64(soaRecordRaw["ttl"].(int))),
-				Metadata:  tags.Expand(soaRecordRaw["tags"].(map[string]interface{})),
+				Metadata:  tags.ExpandWithoutDefaults(soaRecordRaw["tags"].(map[string]interface{})),
 				SoaRecord: soaRecord,
 			},
 		}
@@ -305,7 +305,7 @@ func flattenPrivateDNSZoneSOARecord(input *privatedns.RecordSet) []interface{} {
 
 	metaData := make(map[string]interface{})
 	if input.Metadata != nil {
-		metaData = tags.Flatten(input.Metadata)
+		metaData = tags.FlattenIncludingIgnored(input.Metadata)
 	}
 
 	fqdn := ""
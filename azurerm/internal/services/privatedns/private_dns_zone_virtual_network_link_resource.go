@@ -73,14 +73,20 @@ func resourcePrivateDnsZoneVirtualNetworkLink() *schema.Resource {
 			// TODO: make this case sensitive once the API's fixed https://github.com/Azure/azure-rest-api-specs/issues/10933
 			"resource_group_name": azure.SchemaResourceGroupNameDiffSuppress(),
 
+			"subscription_id": azure.SchemaSubscriptionIdOverride(),
+
 			"tags": tags.Schema(),
 		},
 	}
 }
 
 func resourcePrivateDnsZoneVirtualNetworkLinkCreateUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).PrivateDns.VirtualNetworkLinksClient
-	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	subscriptionClient, err := meta.(*clients.Client).ForSubscription(d.Get("subscription_id").(string))
+	if err != nil {
+		return err
+	}
+	client := subscriptionClient.PrivateDns.VirtualNetworkLinksClient
+	subscriptionId := subscriptionClient.Account.SubscriptionId
 	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -132,7 +138,6 @@ func resourcePrivateDnsZoneVirtualNetworkLinkCreateUpdate(d *schema.ResourceData
 }
 
 func resourcePrivateDnsZoneVirtualNetworkLinkRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).PrivateDns.VirtualNetworkLinksClient
 	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -141,6 +146,12 @@ func resourcePrivateDnsZoneVirtualNetworkLinkRead(d *schema.ResourceData, meta i
 		return err
 	}
 
+	subscriptionClient, err := meta.(*clients.Client).ForSubscription(id.SubscriptionId)
+	if err != nil {
+		return err
+	}
+	client := subscriptionClient.PrivateDns.VirtualNetworkLinksClient
+
 	resp, err := client.Get(ctx, id.ResourceGroup, id.PrivateDnsZoneName, id.Name)
 	if err != nil {
 		if utils.ResponseWasNotFound(resp.Response) {
@@ -153,6 +164,7 @@ func resourcePrivateDnsZoneVirtualNetworkLinkRead(d *schema.ResourceData, meta i
 	d.Set("name", id.Name)
 	d.Set("private_dns_zone_name", id.PrivateDnsZoneName)
 	d.Set("resource_group_name", id.ResourceGroup)
+	d.Set("subscription_id", id.SubscriptionId)
 
 	if props := resp.VirtualNetworkLinkProperties; props != nil {
 		d.Set("registration_enabled", props.RegistrationEnabled)
@@ -166,7 +178,6 @@ func resourcePrivateDnsZoneVirtualNetworkLinkRead(d *schema.ResourceData, meta i
 }
 
 func resourcePrivateDnsZoneVirtualNetworkLinkDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).PrivateDns.VirtualNetworkLinksClient
 	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -175,6 +186,12 @@ func resourcePrivateDnsZoneVirtualNetworkLinkDelete(d *schema.ResourceData, meta
 		return err
 	}
 
+	subscriptionClient, err := meta.(*clients.Client).ForSubscription(id.SubscriptionId)
+	if err != nil {
+		return err
+	}
+	client := subscriptionClient.PrivateDns.VirtualNetworkLinksClient
+
 	etag := ""
 	if future, err := client.Delete(ctx, id.ResourceGroup, id.PrivateDnsZoneName, id.Name, etag); err != nil {
 		if response.WasNotFound(future.Response()) {
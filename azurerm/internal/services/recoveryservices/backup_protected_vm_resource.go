@@ -13,6 +13,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
@@ -100,9 +101,24 @@ func resourceRecoveryServicesBackupProtectedVMCreateUpdate(d *schema.ResourceDat
 			}
 		}
 
-		if existing.ID != nil && *existing.ID != "" {
+		// a Soft Deleted Protected VM is still returned from the API, but needs to be recovered rather than imported
+		if existing.ID != nil && *existing.ID != "" && !backupProtectedItemIsSoftDeleted(existing) {
 			return tf.ImportAsExistsError("azurerm_backup_protected_vm", *existing.ID)
 		}
+
+		softDeleted := backupSoftDeletedProtectedVM{
+			client:            client,
+			vaultName:         vaultName,
+			resourceGroup:     resourceGroup,
+			containerName:     containerName,
+			protectedItemName: protectedItemName,
+			vmId:              vmId,
+			vmName:            vmName,
+		}
+		description := fmt.Sprintf("Azure Backup Protected VM %q (Resource Group %q)", protectedItemName, resourceGroup)
+		if _, err := sdk.RecoverSoftDeletedOnCreate(ctx, description, "recovery_services_vault.recover_soft_deleted", meta.(*clients.Client).Features.RecoveryServicesVault, softDeleted); err != nil {
+			return err
+		}
 	}
 
 	item := backup.ProtectedItemResource{
@@ -159,6 +175,12 @@ func resourceRecoveryServicesBackupProtectedVMRead(d *schema.ResourceData, meta
 		return fmt.Errorf("Error making Read request on Azure Backup Protected VM %q (Resource Group %q): %+v", protectedItemName, resourceGroup, err)
 	}
 
+	if backupProtectedItemIsSoftDeleted(resp) {
+		log.Printf("[DEBUG] Azure Backup Protected VM %q (Resource Group %q) has been Soft Deleted - removing from state", protectedItemName, resourceGroup)
+		d.SetId("")
+		return nil
+	}
+
 	d.Set("resource_group_name", resourceGroup)
 	d.Set("recovery_vault_name", vaultName)
 
@@ -259,6 +281,9 @@ func resourceRecoveryServicesBackupProtectedVMRefreshFunc(ctx context.Context, c
 			}
 
 			return resp, "Error", fmt.Errorf("Error making Read request on Azure Backup Protected VM %q (Resource Group %q): %+v", protectedItemName, resourceGroup, err)
+		} else if !newResource && backupProtectedItemIsSoftDeleted(resp) {
+			// when Soft Delete is enabled for the Vault the Protected VM remains available until it's purged
+			return resp, "NotFound", nil
 		} else if !newResource && policyId != "" {
 			if properties := resp.Properties; properties != nil {
 				if vm, ok := properties.AsAzureIaaSComputeVMProtectedItem(); ok {
@@ -0,0 +1,107 @@
+package recoveryservices
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/recoveryservices/mgmt/2019-05-13/backup"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+var _ sdk.SoftDeletedResource = backupSoftDeletedProtectedVM{}
+
+// backupSoftDeletedProtectedVM finds and recovers a Soft Deleted Backup Protected VM within a Recovery Services Vault.
+//
+// When Soft Delete is enabled for the Recovery Services Vault the backup data for a Protected VM is retained for
+// 14 days once it's been deleted, during which time the VM can't be protected again until this has been recovered.
+type backupSoftDeletedProtectedVM struct {
+	client            *backup.ProtectedItemsClient
+	vaultName         string
+	resourceGroup     string
+	containerName     string
+	protectedItemName string
+
+	// the following are required to recover the Protected VM
+	vmId   string
+	vmName string
+}
+
+func (v backupSoftDeletedProtectedVM) FindSoftDeleted(ctx context.Context) (bool, error) {
+	resp, err := v.client.Get(ctx, v.vaultName, v.resourceGroup, "Azure", v.containerName, v.protectedItemName, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return backupProtectedItemIsSoftDeleted(resp), nil
+}
+
+func (v backupSoftDeletedProtectedVM) RecoverSoftDeleted(ctx context.Context) error {
+	timeout, ok := ctx.Deadline()
+	if !ok {
+		return fmt.Errorf("context is missing a timeout")
+	}
+
+	item := backup.ProtectedItemResource{
+		Properties: &backup.AzureIaaSComputeVMProtectedItem{
+			ProtectedItemType: backup.ProtectedItemTypeMicrosoftClassicComputevirtualMachines,
+			WorkloadType:      backup.DataSourceTypeVM,
+			SourceResourceID:  utils.String(v.vmId),
+			FriendlyName:      utils.String(v.vmName),
+			VirtualMachineID:  utils.String(v.vmId),
+			IsRehydrate:       utils.Bool(true),
+		},
+	}
+	if _, err := v.client.CreateOrUpdate(ctx, v.vaultName, v.resourceGroup, "Azure", v.containerName, v.protectedItemName, item); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Waiting for the Backup Protected VM %q to be recovered..", v.protectedItemName)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"SoftDeleted"},
+		Target:  []string{"Recovered"},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := v.client.Get(ctx, v.vaultName, v.resourceGroup, "Azure", v.containerName, v.protectedItemName, "")
+			if err != nil {
+				return nil, "Error", err
+			}
+			if backupProtectedItemIsSoftDeleted(resp) {
+				return resp, "SoftDeleted", nil
+			}
+			return resp, "Recovered", nil
+		},
+		MinTimeout: 30 * time.Second,
+		Timeout:    time.Until(timeout),
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("waiting for the Backup Protected VM %q to be recovered: %+v", v.protectedItemName, err)
+	}
+
+	return nil
+}
+
+func (v backupSoftDeletedProtectedVM) PurgeSoftDeleted(_ context.Context) error {
+	// Azure Backup purges Soft Deleted items once the retention period has passed, but doesn't support purging these on demand
+	return fmt.Errorf("Soft Deleted Backup Protected VMs can't be purged - these are purged automatically by Azure once the retention period has passed")
+}
+
+// backupProtectedItemIsSoftDeleted returns whether the Backup Protected Item has been Soft Deleted, in which case
+// it's still returned from the API until it's been purged
+func backupProtectedItemIsSoftDeleted(item backup.ProtectedItemResource) bool {
+	if item.Properties == nil {
+		return false
+	}
+
+	vm, ok := item.Properties.AsAzureIaaSComputeVMProtectedItem()
+	if !ok || vm.IsScheduledForDeferredDelete == nil {
+		return false
+	}
+
+	return *vm.IsScheduledForDeferredDelete
+}
@@ -5,20 +5,28 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2016-09-01/locks"
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/providercache"
 )
 
 type Client struct {
-	DeploymentsClient *resources.DeploymentsClient
-	GroupsClient      *resources.GroupsClient
-	LocksClient       *locks.ManagementLocksClient
-	ProvidersClient   *providers.ProvidersClient
-	ResourcesClient   *resources.Client
+	DeploymentsClient      *resources.DeploymentsClient
+	GenericResourcesClient *GenericResourcesClient
+	GroupsClient           *resources.GroupsClient
+	LocksClient            *locks.ManagementLocksClient
+	ProvidersClient        *providers.ProvidersClient
+	ResourcesClient        *resources.Client
+
+	// ProviderCache is used to track the Management Locks managed by Terraform - when nil nothing is tracked
+	ProviderCache *providercache.Cache
 }
 
 func NewClient(o *common.ClientOptions) *Client {
 	deploymentsClient := resources.NewDeploymentsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&deploymentsClient.Client, o.ResourceManagerAuthorizer)
 
+	genericResourcesClient := NewGenericResourcesClientWithBaseURI(o.ResourceManagerEndpoint)
+	o.ConfigureClient(&genericResourcesClient.Client, o.ResourceManagerAuthorizer)
+
 	groupsClient := resources.NewGroupsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&groupsClient.Client, o.ResourceManagerAuthorizer)
 
@@ -33,10 +41,12 @@ func NewClient(o *common.ClientOptions) *Client {
 	o.ConfigureClient(&resourcesClient.Client, o.ResourceManagerAuthorizer)
 
 	return &Client{
-		GroupsClient:      &groupsClient,
-		DeploymentsClient: &deploymentsClient,
-		LocksClient:       &locksClient,
-		ProvidersClient:   &providersClient,
-		ResourcesClient:   &resourcesClient,
+		GroupsClient:           &groupsClient,
+		DeploymentsClient:      &deploymentsClient,
+		GenericResourcesClient: &genericResourcesClient,
+		LocksClient:            &locksClient,
+		ProvidersClient:        &providersClient,
+		ResourcesClient:        &resourcesClient,
+		ProviderCache:          o.ProviderCache,
 	}
 }
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+// GenericResourcesClient creates, retrieves, deletes and invokes actions on any Resource ID using the specified API Version,
+// sending and returning the raw JSON - rather than the subset of fields within `resources.GenericResource`
+type GenericResourcesClient struct {
+	autorest.Client
+	BaseURI string
+}
+
+func NewGenericResourcesClientWithBaseURI(baseURI string) GenericResourcesClient {
+	return GenericResourcesClient{
+		Client:  autorest.NewClientWithUserAgent(""),
+		BaseURI: baseURI,
+	}
+}
+
+// CreateOrUpdate creates or updates the specified Resource, returning a Future for the Long Running Operation
+func (client GenericResourcesClient) CreateOrUpdate(ctx context.Context, resourceId string, apiVersion string, body map[string]interface{}) (future azure.Future, err error) {
+	req, err := autorest.Prepare((&http.Request{}).WithContext(ctx),
+		autorest.AsContentType("application/json; charset=utf-8"),
+		autorest.AsPut(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPathParameters("/{resourceId}", map[string]interface{}{
+			"resourceId": strings.TrimPrefix(resourceId, "/"),
+		}),
+		autorest.WithJSON(body),
+		autorest.WithQueryParameters(map[string]interface{}{
+			"api-version": apiVersion,
+		}))
+	if err != nil {
+		return future, autorest.NewErrorWithError(err, "client.GenericResourcesClient", "CreateOrUpdate", nil, "Failure preparing request")
+	}
+
+	return client.sendAsync(req, "CreateOrUpdate", http.StatusOK, http.StatusCreated, http.StatusAccepted)
+}
+
+// Get retrieves the specified Resource - the returned http.Response can be used to determine if the Resource wasn't found
+func (client GenericResourcesClient) Get(ctx context.Context, resourceId string, apiVersion string) (result map[string]interface{}, resp autorest.Response, err error) {
+	req, err := autorest.Prepare((&http.Request{}).WithContext(ctx),
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPathParameters("/{resourceId}", map[string]interface{}{
+			"resourceId": strings.TrimPrefix(resourceId, "/"),
+		}),
+		autorest.WithQueryParameters(map[string]interface{}{
+			"api-version": apiVersion,
+		}))
+	if err != nil {
+		return nil, resp, autorest.NewErrorWithError(err, "client.GenericResourcesClient", "Get", nil, "Failure preparing request")
+	}
+
+	response, err := client.Send(req, azure.DoRetryWithRegistration(client.Client))
+	if err != nil {
+		resp.Response = response
+		return nil, resp, autorest.NewErrorWithError(err, "client.GenericResourcesClient", "Get", resp.Response, "Failure sending request")
+	}
+
+	result = map[string]interface{}{}
+	err = autorest.Respond(
+		response,
+		azure.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&result),
+		autorest.ByClosing())
+	resp.Response = response
+	if err != nil {
+		return nil, resp, autorest.NewErrorWithError(err, "client.GenericResourcesClient", "Get", resp.Response, "Failure responding to request")
+	}
+
+	return result, resp, nil
+}
+
+// Delete deletes the specified Resource, returning a Future for the Long Running Operation
+func (client GenericResourcesClient) Delete(ctx context.Context, resourceId string, apiVersion string) (future azure.Future, err error) {
+	req, err := autorest.Prepare((&http.Request{}).WithContext(ctx),
+		autorest.AsDelete(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPathParameters("/{resourceId}", map[string]interface{}{
+			"resourceId": strings.TrimPrefix(resourceId, "/"),
+		}),
+		autorest.WithQueryParameters(map[string]interface{}{
+			"api-version": apiVersion,
+		}))
+	if err != nil {
+		return future, autorest.NewErrorWithError(err, "client.GenericResourcesClient", "Delete", nil, "Failure preparing request")
+	}
+
+	return client.sendAsync(req, "Delete", http.StatusOK, http.StatusAccepted, http.StatusNoContent)
+}
+
+// Action invokes the specified action (for example `purge`) on a Resource via a POST request, returning a Future
+// for the Long Running Operation
+func (client GenericResourcesClient) Action(ctx context.Context, resourceId string, action string, apiVersion string, body map[string]interface{}) (future azure.Future, err error) {
+	decorators := []autorest.PrepareDecorator{
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPathParameters("/{resourceId}/{action}", map[string]interface{}{
+			"resourceId": strings.TrimPrefix(resourceId, "/"),
+			"action":     autorest.Encode("path", action),
+		}),
+		autorest.WithQueryParameters(map[string]interface{}{
+			"api-version": apiVersion,
+		}),
+	}
+	if body != nil {
+		decorators = append(decorators, autorest.AsContentType("application/json; charset=utf-8"), autorest.WithJSON(body))
+	}
+
+	req, err := autorest.Prepare((&http.Request{}).WithContext(ctx), decorators...)
+	if err != nil {
+		return future, autorest.NewErrorWithError(err, "client.GenericResourcesClient", "Action", nil, "Failure preparing request")
+	}
+
+	return client.sendAsync(req, "Action", http.StatusOK, http.StatusAccepted, http.StatusNoContent)
+}
+
+func (client GenericResourcesClient) sendAsync(req *http.Request, method string, statusCodes ...int) (future azure.Future, err error) {
+	resp, err := client.Send(req, azure.DoRetryWithRegistration(client.Client))
+	if err != nil {
+		return future, autorest.NewErrorWithError(err, "client.GenericResourcesClient", method, resp, "Failure sending request")
+	}
+
+	if err := autorest.Respond(resp, azure.WithErrorUnlessStatusCode(statusCodes...)); err != nil {
+		return future, autorest.NewErrorWithError(err, "client.GenericResourcesClient", method, resp, "Failure responding to request")
+	}
+
+	future, err = azure.NewFutureFromResponse(resp)
+	if err != nil {
+		return future, autorest.NewErrorWithError(err, "client.GenericResourcesClient", method, resp, "Failure creating the Long Running Operation")
+	}
+
+	return future, nil
+}
@@ -0,0 +1,130 @@
+package client_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/fakearm"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestGenericResourcesClientLifecycle(t *testing.T) {
+	for _, longRunningOperations := range []fakearm.LongRunningOperationType{fakearm.LongRunningOperationNone, fakearm.LongRunningOperationAzureAsyncOperation, fakearm.LongRunningOperationLocation} {
+		t.Logf("[DEBUG] Testing with Long Running Operations %q", longRunningOperations)
+
+		server := fakearm.NewServer()
+		server.RegisterResourceProvider("Microsoft.Network", fakearm.ResourceProvider{
+			LongRunningOperations: longRunningOperations,
+			PollsUntilCompleted:   2,
+		})
+
+		groupId := fmt.Sprintf("/subscriptions/%s/resourceGroups/example", fakearm.SubscriptionId)
+		server.SetResource(groupId, map[string]interface{}{
+			"location": "westeurope",
+		})
+
+		armClient, err := server.Client()
+		if err != nil {
+			t.Fatalf("building client: %+v", err)
+		}
+		ctx := armClient.StopContext
+		client := armClient.Resource.GenericResourcesClient
+
+		id := fmt.Sprintf("%s/providers/Microsoft.Network/virtualNetworks/network1", groupId)
+		body := map[string]interface{}{
+			"location": "westeurope",
+			"properties": map[string]interface{}{
+				"addressSpace": map[string]interface{}{
+					"addressPrefixes": []string{"10.0.0.0/16"},
+				},
+			},
+			"zones": []string{"1"},
+		}
+		future, err := client.CreateOrUpdate(ctx, id, "2020-05-01", body)
+		if err != nil {
+			t.Fatalf("creating: %+v", err)
+		}
+		if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			t.Fatalf("waiting for creation: %+v", err)
+		}
+
+		existing, _, err := client.Get(ctx, id, "2020-05-01")
+		if err != nil {
+			t.Fatalf("retrieving: %+v", err)
+		}
+		if existing["zones"] == nil {
+			t.Fatalf("expected fields outside of the Generic Resource model to be returned but got %+v", existing)
+		}
+		if properties := existing["properties"].(map[string]interface{}); properties["provisioningState"] != "Succeeded" {
+			t.Fatalf("expected the Long Running Operation to have completed but got %+v", existing)
+		}
+
+		future, err = client.Delete(ctx, id, "2020-05-01")
+		if err != nil {
+			t.Fatalf("deleting: %+v", err)
+		}
+		if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			t.Fatalf("waiting for deletion: %+v", err)
+		}
+
+		_, resp, err := client.Get(ctx, id, "2020-05-01")
+		if err == nil || !utils.ResponseWasNotFound(resp) {
+			t.Fatalf("expected a 404 after deletion but got %+v", err)
+		}
+	}
+}
+
+func TestGenericResourcesClientAction(t *testing.T) {
+	server := fakearm.NewServer()
+	server.RegisterResourceProvider("Microsoft.Storage", fakearm.ResourceProvider{
+		Actions: map[string]fakearm.ActionFunc{
+			"regenerateKey": func(id string, resource map[string]interface{}, body map[string]interface{}) (interface{}, error) {
+				if body["keyName"] != "key1" {
+					return nil, &fakearm.Error{
+						StatusCode: http.StatusBadRequest,
+						Code:       "InvalidKeyName",
+						Message:    "The key name is invalid.",
+					}
+				}
+				return nil, nil
+			},
+		},
+	})
+
+	groupId := fmt.Sprintf("/subscriptions/%s/resourceGroups/example", fakearm.SubscriptionId)
+	server.SetResource(groupId, map[string]interface{}{
+		"location": "westeurope",
+	})
+	id := fmt.Sprintf("%s/providers/Microsoft.Storage/storageAccounts/account1", groupId)
+	server.SetResource(id, map[string]interface{}{
+		"location": "westeurope",
+	})
+
+	armClient, err := server.Client()
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+	ctx := armClient.StopContext
+	client := armClient.Resource.GenericResourcesClient
+
+	future, err := client.Action(ctx, id, "regenerateKey", "2021-01-01", map[string]interface{}{
+		"keyName": "key1",
+	})
+	if err != nil {
+		t.Fatalf("invoking action: %+v", err)
+	}
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		t.Fatalf("waiting for action: %+v", err)
+	}
+
+	if _, err := client.Action(ctx, id, "regenerateKey", "2021-01-01", map[string]interface{}{
+		"keyName": "invalid",
+	}); err == nil {
+		t.Fatalf("expected an error for an invalid request but didn't get one")
+	}
+
+	if _, err := client.Action(ctx, id, "doesNotExist", "2021-01-01", nil); err == nil {
+		t.Fatalf("expected an error for an unsupported action but didn't get one")
+	}
+}
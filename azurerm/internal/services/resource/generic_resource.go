@@ -0,0 +1,360 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/location"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/locks"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/operations"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/resource/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/resource/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+var _ sdk.ResourceWithUpdate = GenericResource{}
+var _ sdk.ResourceWithCustomImporter = GenericResource{}
+var _ sdk.ResourceWithCustomizeDiff = GenericResource{}
+
+// GenericResource manages any Azure Resource using the specified Resource Type and API Version - which allows
+// functionality to be used before it's supported by a dedicated Resource
+type GenericResource struct{}
+
+type GenericResourceModel struct {
+	Type                 string   `tfschema:"type"`
+	ParentId             string   `tfschema:"parent_id"`
+	Name                 string   `tfschema:"name"`
+	Location             string   `tfschema:"location"`
+	Body                 string   `tfschema:"body"`
+	ResponseExportValues []string `tfschema:"response_export_values"`
+	Output               string   `tfschema:"output"`
+
+	PendingCreationOperation string `tfschema:"pending_creation_operation"`
+}
+
+func (r GenericResource) Arguments() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.GenericResourceType,
+		},
+
+		"parent_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.GenericResourceParentID,
+		},
+
+		"name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"location": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ForceNew:         true,
+			StateFunc:        location.StateFunc,
+			DiffSuppressFunc: location.DiffSuppressFunc,
+		},
+
+		"body": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: structure.SuppressJsonDiff,
+		},
+
+		"response_export_values": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
+
+func (r GenericResource) Attributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"output": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+
+		operations.PendingCreationKey: operations.Schema(),
+	}
+}
+
+func (r GenericResource) ModelObject() interface{} {
+	return GenericResourceModel{}
+}
+
+func (r GenericResource) ResourceType() string {
+	return "azurerm_resource"
+}
+
+func (r GenericResource) IDValidationFunc() schema.SchemaValidateFunc {
+	return validate.GenericResourceImportID
+}
+
+func (r GenericResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.GenericResourcesClient
+
+			var config GenericResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			resourceType, apiVersion, err := splitGenericResourceType(config.Type)
+			if err != nil {
+				return err
+			}
+			id := parse.NewGenericResourceID(config.ParentId, resourceType, config.Name)
+
+			unlock, err := r.lock(ctx, id)
+			if err != nil {
+				return err
+			}
+			defer unlock()
+
+			existing, resp, err := client.Get(ctx, id.ID(), apiVersion)
+			if err != nil && !utils.ResponseWasNotFound(resp) {
+				return fmt.Errorf("checking for the presence of an existing %s: %+v", id, err)
+			}
+			if existing != nil {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			body, err := expandGenericResourceBody(config.Body, location.Normalize(config.Location))
+			if err != nil {
+				return err
+			}
+
+			metadata.Logger.Infof("creating %s..", id)
+			future, err := client.CreateOrUpdate(ctx, id.ID(), apiVersion, body)
+			if err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+			// when the creation is still pending this is resumed during the next apply
+			if _, err := operations.WaitForCreation(ctx, client.Client, metadata.ResourceData, id.ID(), &future); err != nil {
+				return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+		Timeout: 60 * time.Minute,
+	}
+}
+
+func (r GenericResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.GenericResourcesClient
+
+			id, err := parse.GenericResourceID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var state GenericResourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			_, apiVersion, err := splitGenericResourceType(state.Type)
+			if err != nil {
+				return err
+			}
+
+			existing, resp, err := client.Get(ctx, id.ID(), apiVersion)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp) {
+					metadata.Logger.Infof("%s was not found - removing from state", id)
+					return metadata.MarkAsGone()
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			body, err := flattenGenericResourceBody(state.Body, existing)
+			if err != nil {
+				return err
+			}
+
+			output, err := flattenGenericResourceOutput(existing, state.ResponseExportValues)
+			if err != nil {
+				return err
+			}
+
+			model := GenericResourceModel{
+				Type:                 fmt.Sprintf("%s@%s", id.ResourceType, apiVersion),
+				ParentId:             id.ParentId,
+				Name:                 id.Name,
+				Body:                 body,
+				ResponseExportValues: state.ResponseExportValues,
+				Output:               output,
+
+				// this is only set by Create/Update, so is retained from the State
+				PendingCreationOperation: state.PendingCreationOperation,
+			}
+			if v, ok := existing["location"].(string); ok {
+				model.Location = location.Normalize(v)
+			}
+
+			return metadata.Encode(&model)
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+func (r GenericResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.GenericResourcesClient
+
+			id, err := parse.GenericResourceID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config GenericResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// a previous apply may have timed out whilst creating this Resource, in which case that's resumed first
+			if _, err := operations.ResumeCreation(ctx, client.Client, metadata.ResourceData); err != nil {
+				return fmt.Errorf("resuming the creation of %s: %+v", id, err)
+			}
+
+			// only the Output needs to be re-computed when the `response_export_values` change
+			if !metadata.ResourceData.HasChange("body") {
+				return nil
+			}
+
+			_, apiVersion, err := splitGenericResourceType(config.Type)
+			if err != nil {
+				return err
+			}
+
+			unlock, err := r.lock(ctx, *id)
+			if err != nil {
+				return err
+			}
+			defer unlock()
+
+			body, err := expandGenericResourceBody(config.Body, location.Normalize(config.Location))
+			if err != nil {
+				return err
+			}
+
+			metadata.Logger.Infof("updating %s..", id)
+			future, err := client.CreateOrUpdate(ctx, id.ID(), apiVersion, body)
+			if err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for update of %s: %+v", id, err)
+			}
+
+			return nil
+		},
+		Timeout: 60 * time.Minute,
+	}
+}
+
+func (r GenericResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.GenericResourcesClient
+
+			id, err := parse.GenericResourceID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			_, apiVersion, err := splitGenericResourceType(metadata.ResourceData.Get("type").(string))
+			if err != nil {
+				return err
+			}
+
+			unlock, err := r.lock(ctx, *id)
+			if err != nil {
+				return err
+			}
+			defer unlock()
+
+			metadata.Logger.Infof("deleting %s..", id)
+			future, err := client.Delete(ctx, id.ID(), apiVersion)
+			if err != nil {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for deletion of %s: %+v", id, err)
+			}
+
+			return nil
+		},
+		Timeout: 60 * time.Minute,
+	}
+}
+
+func (r GenericResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			return operations.CustomizeDiff(metadata.ResourceDiff, metadata.Client)
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+func (r GenericResource) CustomImporter() sdk.ResourceRunFunc {
+	return func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+		id, apiVersion, err := parse.GenericResourceImportID(metadata.ResourceData.Id())
+		if err != nil {
+			return err
+		}
+
+		metadata.SetID(id)
+		return metadata.Encode(&GenericResourceModel{
+			Type:     fmt.Sprintf("%s@%s", id.ResourceType, apiVersion),
+			ParentId: id.ParentId,
+			Name:     id.Name,
+		})
+	}
+}
+
+// lock locks the Resource - and, for nested Resources, the Parent Resource - returning a function
+// to unlock these, which ensures that changes to nested Resources within a Parent are serialized
+func (r GenericResource) lock(ctx context.Context, id parse.GenericResourceId) (func(), error) {
+	ids := []string{id.ID()}
+	if parentTypeSegments := strings.Split(id.ResourceType, "/"); len(parentTypeSegments) > 2 {
+		ids = append(ids, id.ParentId)
+	}
+
+	if err := locks.MultipleByIDWithContext(ctx, ids...); err != nil {
+		return nil, fmt.Errorf("locking %s: %+v", id, err)
+	}
+
+	return func() {
+		locks.UnlockMultipleByID(ids...)
+	}, nil
+}
@@ -0,0 +1,180 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// genericResourceReadOnlyFields are the top-level fields returned from the API which can't be
+// specified in the `body` - which are excluded when the `body` isn't known (e.g. when importing)
+var genericResourceReadOnlyFields = []string{
+	"etag",
+	"id",
+	"location",
+	"name",
+	"systemData",
+	"type",
+}
+
+// splitGenericResourceType splits a Resource Type with an API Version (e.g. `Microsoft.Network/virtualNetworks@2020-05-01`)
+// into the Resource Type and the API Version
+func splitGenericResourceType(input string) (string, string, error) {
+	segments := strings.Split(input, "@")
+	if len(segments) != 2 || segments[0] == "" || segments[1] == "" {
+		return "", "", fmt.Errorf("expected %q to be in the format `{resourceType}@{apiVersion}`", input)
+	}
+
+	return segments[0], segments[1], nil
+}
+
+func expandGenericResourceBody(input string, location string) (map[string]interface{}, error) {
+	body := make(map[string]interface{})
+	if input != "" {
+		if err := json.Unmarshal([]byte(input), &body); err != nil {
+			return nil, fmt.Errorf("parsing `body`: %+v", err)
+		}
+	}
+
+	if location != "" {
+		body["location"] = location
+	}
+
+	return body, nil
+}
+
+// flattenGenericResourceBody returns the fields within the Resource returned from the API which are
+// specified in the `body` (the configured value) - meaning that a diff is only shown when a field
+// the user has specified has changed, rather than for any other fields returned from the API
+func flattenGenericResourceBody(configured string, resource map[string]interface{}) (string, error) {
+	var output interface{}
+	if configured == "" {
+		output = withoutGenericResourceReadOnlyFields(resource)
+	} else {
+		var body interface{}
+		if err := json.Unmarshal([]byte(configured), &body); err != nil {
+			return "", fmt.Errorf("parsing `body`: %+v", err)
+		}
+
+		output = genericResourceFieldsMatching(body, resource)
+	}
+
+	contents, err := json.Marshal(output)
+	if err != nil {
+		return "", fmt.Errorf("serializing `body`: %+v", err)
+	}
+
+	return string(contents), nil
+}
+
+// genericResourceFieldsMatching returns the value from the API for each field present in the configured value - where
+// fields which aren't returned from the API (such as secrets) retain the configured value, and lists retain any additional
+// items returned from the API
+func genericResourceFieldsMatching(configured interface{}, actual interface{}) interface{} {
+	switch v := configured.(type) {
+	case map[string]interface{}:
+		actualMap, ok := actual.(map[string]interface{})
+		if !ok {
+			return actual
+		}
+
+		output := make(map[string]interface{})
+		for key, value := range v {
+			actualValue, exists := actualMap[key]
+			if !exists {
+				output[key] = value
+				continue
+			}
+
+			output[key] = genericResourceFieldsMatching(value, actualValue)
+		}
+		return output
+
+	case []interface{}:
+		actualList, ok := actual.([]interface{})
+		if !ok {
+			return actual
+		}
+
+		output := make([]interface{}, 0)
+		for i, actualValue := range actualList {
+			if i < len(v) {
+				output = append(output, genericResourceFieldsMatching(v[i], actualValue))
+				continue
+			}
+
+			output = append(output, actualValue)
+		}
+		return output
+	}
+
+	return actual
+}
+
+func withoutGenericResourceReadOnlyFields(resource map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{})
+	for key, value := range resource {
+		output[key] = value
+	}
+	for _, key := range genericResourceReadOnlyFields {
+		delete(output, key)
+	}
+
+	if properties, ok := output["properties"].(map[string]interface{}); ok {
+		withoutProvisioningState := make(map[string]interface{})
+		for key, value := range properties {
+			if key != "provisioningState" {
+				withoutProvisioningState[key] = value
+			}
+		}
+		output["properties"] = withoutProvisioningState
+	}
+
+	return output
+}
+
+// flattenGenericResourceOutput returns the values at each of the specified paths (e.g. `properties.primaryEndpoints.blob`)
+// within the Resource returned from the API - nested within the same structure as in the Resource
+func flattenGenericResourceOutput(resource map[string]interface{}, paths []string) (string, error) {
+	output := make(map[string]interface{})
+
+	for _, path := range paths {
+		keys := strings.Split(path, ".")
+
+		var value interface{} = resource
+		found := true
+		for _, key := range keys {
+			current, ok := value.(map[string]interface{})
+			if !ok {
+				found = false
+				break
+			}
+
+			if value, ok = current[key]; !ok {
+				found = false
+				break
+			}
+		}
+		if !found {
+			continue
+		}
+
+		parent := output
+		for _, key := range keys[0 : len(keys)-1] {
+			next, ok := parent[key].(map[string]interface{})
+			if !ok {
+				next = make(map[string]interface{})
+				parent[key] = next
+			}
+			parent = next
+		}
+		parent[keys[len(keys)-1]] = value
+	}
+
+	contents, err := json.Marshal(output)
+	if err != nil {
+		return "", fmt.Errorf("serializing `output`: %+v", err)
+	}
+
+	return string(contents), nil
+}
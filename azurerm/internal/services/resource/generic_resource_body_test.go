@@ -0,0 +1,107 @@
+package resource
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFlattenGenericResourceBody(t *testing.T) {
+	resource := `{
+  "id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1",
+  "name": "network1",
+  "type": "Microsoft.Network/virtualNetworks",
+  "location": "westeurope",
+  "etag": "W/\"1234\"",
+  "tags": {
+    "environment": "test"
+  },
+  "properties": {
+    "provisioningState": "Succeeded",
+    "resourceGuid": "abc123",
+    "addressSpace": {
+      "addressPrefixes": ["10.0.0.0/16", "10.1.0.0/16"]
+    },
+    "subnets": [
+      {
+        "name": "subnet1",
+        "properties": {
+          "addressPrefix": "10.0.1.0/24",
+          "provisioningState": "Succeeded"
+        }
+      }
+    ]
+  }
+}`
+
+	testData := []struct {
+		Name       string
+		Configured string
+		Expected   string
+	}{
+		{
+			Name:       "Not Configured",
+			Configured: "",
+			Expected:   `{"properties":{"addressSpace":{"addressPrefixes":["10.0.0.0/16","10.1.0.0/16"]},"resourceGuid":"abc123","subnets":[{"name":"subnet1","properties":{"addressPrefix":"10.0.1.0/24","provisioningState":"Succeeded"}}]},"tags":{"environment":"test"}}`,
+		},
+		{
+			Name:       "Only Configured Fields",
+			Configured: `{"properties":{"addressSpace":{"addressPrefixes":["10.0.0.0/16"]}}}`,
+			Expected:   `{"properties":{"addressSpace":{"addressPrefixes":["10.0.0.0/16","10.1.0.0/16"]}}}`,
+		},
+		{
+			Name:       "Nested Objects Within Lists",
+			Configured: `{"properties":{"subnets":[{"name":"subnet1","properties":{"addressPrefix":"10.0.1.0/24"}}]}}`,
+			Expected:   `{"properties":{"subnets":[{"name":"subnet1","properties":{"addressPrefix":"10.0.1.0/24"}}]}}`,
+		},
+		{
+			Name:       "Changed Value",
+			Configured: `{"tags":{"environment":"production"}}`,
+			Expected:   `{"tags":{"environment":"test"}}`,
+		},
+		{
+			Name:       "Write-Only Field",
+			Configured: `{"properties":{"sharedKey":"secret"}}`,
+			Expected:   `{"properties":{"sharedKey":"secret"}}`,
+		},
+	}
+
+	var existing map[string]interface{}
+	if err := json.Unmarshal([]byte(resource), &existing); err != nil {
+		t.Fatalf("parsing resource: %+v", err)
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual, err := flattenGenericResourceBody(v.Configured, existing)
+		if err != nil {
+			t.Fatalf("flattening body: %+v", err)
+		}
+		if actual != v.Expected {
+			t.Fatalf("Expected %s but got %s", v.Expected, actual)
+		}
+	}
+}
+
+func TestFlattenGenericResourceOutput(t *testing.T) {
+	resource := map[string]interface{}{
+		"name": "example",
+		"properties": map[string]interface{}{
+			"primaryEndpoints": map[string]interface{}{
+				"blob":  "https://example.blob.core.windows.net/",
+				"queue": "https://example.queue.core.windows.net/",
+			},
+			"accessTier": "Hot",
+		},
+	}
+
+	actual, err := flattenGenericResourceOutput(resource, []string{"name", "properties.primaryEndpoints.blob", "properties.accessTier", "properties.doesNotExist", "name.invalid"})
+	if err != nil {
+		t.Fatalf("flattening output: %+v", err)
+	}
+
+	expected := `{"name":"example","properties":{"accessTier":"Hot","primaryEndpoints":{"blob":"https://example.blob.core.windows.net/"}}}`
+	if actual != expected {
+		t.Fatalf("Expected %s but got %s", expected, actual)
+	}
+}
@@ -0,0 +1,126 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/location"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/resource/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/resource/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+var _ sdk.DataSource = GenericResourceDataSource{}
+
+type GenericResourceDataSource struct{}
+
+type GenericResourceDataSourceModel struct {
+	Type                 string   `tfschema:"type"`
+	ParentId             string   `tfschema:"parent_id"`
+	Name                 string   `tfschema:"name"`
+	ResponseExportValues []string `tfschema:"response_export_values"`
+	Location             string   `tfschema:"location"`
+	Output               string   `tfschema:"output"`
+}
+
+func (d GenericResourceDataSource) Arguments() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validate.GenericResourceType,
+		},
+
+		"parent_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validate.GenericResourceParentID,
+		},
+
+		"name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"response_export_values": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
+
+func (d GenericResourceDataSource) Attributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"location": location.SchemaComputed(),
+
+		"output": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (d GenericResourceDataSource) ModelObject() interface{} {
+	return GenericResourceDataSourceModel{}
+}
+
+func (d GenericResourceDataSource) ResourceType() string {
+	return "azurerm_resource"
+}
+
+func (d GenericResourceDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.GenericResourcesClient
+
+			var config GenericResourceDataSourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			resourceType, apiVersion, err := splitGenericResourceType(config.Type)
+			if err != nil {
+				return err
+			}
+			id := parse.NewGenericResourceID(config.ParentId, resourceType, config.Name)
+
+			existing, resp, err := client.Get(ctx, id.ID(), apiVersion)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp) {
+					return fmt.Errorf("%s was not found", id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			output, err := flattenGenericResourceOutput(existing, config.ResponseExportValues)
+			if err != nil {
+				return err
+			}
+
+			model := GenericResourceDataSourceModel{
+				Type:                 config.Type,
+				ParentId:             config.ParentId,
+				Name:                 config.Name,
+				ResponseExportValues: config.ResponseExportValues,
+				Output:               output,
+			}
+			if v, ok := existing["location"].(string); ok {
+				model.Location = location.Normalize(v)
+			}
+
+			metadata.SetID(id)
+			return metadata.Encode(&model)
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
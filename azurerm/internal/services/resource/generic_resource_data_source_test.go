@@ -0,0 +1,61 @@
+package resource_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+)
+
+type GenericResourceDataSource struct {
+}
+
+func TestAccGenericResourceDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_resource", "test")
+	r := GenericResourceDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("location").HasValue(azure.NormalizeLocation(data.Locations.Primary)),
+				check.That(data.ResourceName).Key("output").HasValue(`{"properties":{"addressSpace":{"addressPrefixes":["10.0.0.0/16"]}},"tags":{"env":"test"}}`),
+			),
+		},
+	})
+}
+
+func (GenericResourceDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctestvirtnet%d"
+  address_space       = ["10.0.0.0/16"]
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+
+  tags = {
+    env = "test"
+  }
+}
+
+data "azurerm_resource" "test" {
+  type      = "Microsoft.Network/virtualNetworks@2020-05-01"
+  parent_id = azurerm_resource_group.test.id
+  name      = azurerm_virtual_network.test.name
+
+  response_export_values = ["properties.addressSpace", "tags"]
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
+}
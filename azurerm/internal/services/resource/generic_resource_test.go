@@ -0,0 +1,219 @@
+package resource_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/resource/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+type GenericResource struct {
+}
+
+func TestAccGenericResource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource", "test")
+	r := GenericResource{}
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("output").HasValue(`{"properties":{"provisioningState":"Succeeded"}}`),
+			),
+		},
+		r.importStep(data),
+	})
+}
+
+func TestAccGenericResource_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource", "test")
+	r := GenericResource{}
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccGenericResource_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource", "test")
+	r := GenericResource{}
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		r.importStep(data),
+		{
+			Config: r.updated(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		r.importStep(data),
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		r.importStep(data),
+	})
+}
+
+func TestAccGenericResource_nested(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource", "test")
+	r := GenericResource{}
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.nested(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That("azurerm_resource.subnet").ExistsInAzure(r),
+			),
+		},
+		r.importStep(data),
+	})
+}
+
+func (GenericResource) importStep(data acceptance.TestData) resource.TestStep {
+	return resource.TestStep{
+		ResourceName: data.ResourceName,
+		ImportState:  true,
+		ImportStateIdFunc: func(state *terraform.State) (string, error) {
+			rs, ok := state.RootModule().Resources[data.ResourceName]
+			if !ok {
+				return "", fmt.Errorf("%q was not found in the state", data.ResourceName)
+			}
+
+			apiVersion := strings.Split(rs.Primary.Attributes["type"], "@")[1]
+			return fmt.Sprintf("%s?api-version=%s", rs.Primary.ID, apiVersion), nil
+		},
+		ImportStateVerify: true,
+		// the `body` contains all of the fields returned from the API when imported
+		ImportStateVerifyIgnore: []string{"body", "response_export_values", "output"},
+	}
+}
+
+func (GenericResource) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	id, err := parse.GenericResourceID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	apiVersion := strings.Split(state.Attributes["type"], "@")[1]
+	resp, _, err := client.Resource.GenericResourcesClient.Get(ctx, id.ID(), apiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	return utils.Bool(resp != nil), nil
+}
+
+func (GenericResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
+func (r GenericResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_resource" "test" {
+  type      = "Microsoft.Network/virtualNetworks@2020-05-01"
+  parent_id = azurerm_resource_group.test.id
+  name      = "acctestvirtnet%d"
+  location  = azurerm_resource_group.test.location
+
+  body = jsonencode({
+    properties = {
+      addressSpace = {
+        addressPrefixes = ["10.0.0.0/16"]
+      }
+    }
+  })
+
+  response_export_values = ["properties.provisioningState"]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r GenericResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_resource" "import" {
+  type      = azurerm_resource.test.type
+  parent_id = azurerm_resource.test.parent_id
+  name      = azurerm_resource.test.name
+  location  = azurerm_resource.test.location
+  body      = azurerm_resource.test.body
+}
+`, r.basic(data))
+}
+
+func (r GenericResource) updated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_resource" "test" {
+  type      = "Microsoft.Network/virtualNetworks@2020-05-01"
+  parent_id = azurerm_resource_group.test.id
+  name      = "acctestvirtnet%d"
+  location  = azurerm_resource_group.test.location
+
+  body = jsonencode({
+    properties = {
+      addressSpace = {
+        addressPrefixes = ["10.0.0.0/16", "10.1.0.0/16"]
+      }
+    }
+    tags = {
+      environment = "Production"
+    }
+  })
+
+  response_export_values = ["properties.provisioningState", "properties.resourceGuid"]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r GenericResource) nested(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_resource" "subnet" {
+  type      = "Microsoft.Network/virtualNetworks/subnets@2020-05-01"
+  parent_id = azurerm_resource.test.id
+  name      = "internal"
+
+  body = jsonencode({
+    properties = {
+      addressPrefix = "10.0.2.0/24"
+    }
+  })
+}
+`, r.basic(data))
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/managementlocks"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
@@ -112,6 +113,8 @@ func resourceManagementLockCreateUpdate(d *schema.ResourceData, meta interface{}
 	}
 
 	d.SetId(*read.ID)
+	managementlocks.RegisterManaged(meta.(*clients.Client), *read.ID)
+
 	return resourceManagementLockRead(d, meta)
 }
 
@@ -128,12 +131,16 @@ func resourceManagementLockRead(d *schema.ResourceData, meta interface{}) error
 	resp, err := client.GetByScope(ctx, id.Scope, id.Name)
 	if err != nil {
 		if utils.ResponseWasNotFound(resp.Response) {
+			managementlocks.UnregisterManaged(meta.(*clients.Client), d.Id())
 			d.SetId("")
 			return nil
 		}
 		return fmt.Errorf("Error making Read request on AzureRM Management Lock %q (Scope %q): %+v", id.Name, id.Scope, err)
 	}
 
+	// this allows the Lock to be temporarily lifted when the `temporarily_lift_managed_locks` feature is enabled
+	managementlocks.RegisterManaged(meta.(*clients.Client), d.Id())
+
 	d.Set("name", resp.Name)
 	d.Set("scope", id.Scope)
 
@@ -158,12 +165,14 @@ func resourceManagementLockDelete(d *schema.ResourceData, meta interface{}) erro
 	resp, err := client.DeleteByScope(ctx, id.Scope, id.Name)
 	if err != nil {
 		if utils.ResponseWasNotFound(resp) {
+			managementlocks.UnregisterManaged(meta.(*clients.Client), d.Id())
 			return nil
 		}
 
 		return fmt.Errorf("Error issuing AzureRM delete request for Management Lock %q (Scope %q): %+v", id.Name, id.Scope, err)
 	}
 
+	managementlocks.UnregisterManaged(meta.(*clients.Client), d.Id())
 	return nil
 }
 
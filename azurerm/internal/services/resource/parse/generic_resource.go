@@ -0,0 +1,138 @@
+package parse
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// GenericResourceId is the ID of any Azure Resource - which is made up of the ID of the Parent
+// (a Subscription, Resource Group or another Resource), the Resource Type and the Name
+type GenericResourceId struct {
+	// ParentId is the ID of the Subscription, Resource Group or Resource containing this Resource,
+	// which is `/` for Resources at the Tenant level
+	ParentId string
+
+	// ResourceType is the fully qualified Resource Type, e.g. `Microsoft.Network/virtualNetworks/subnets`
+	ResourceType string
+
+	Name string
+}
+
+func NewGenericResourceID(parentId, resourceType, name string) GenericResourceId {
+	return GenericResourceId{
+		ParentId:     parentId,
+		ResourceType: resourceType,
+		Name:         name,
+	}
+}
+
+func (id GenericResourceId) String() string {
+	segments := []string{
+		fmt.Sprintf("Parent %q", id.ParentId),
+		fmt.Sprintf("Type %q", id.ResourceType),
+		fmt.Sprintf("Name %q", id.Name),
+	}
+	segmentsStr := strings.Join(segments, " / ")
+	return fmt.Sprintf("%s: (%s)", "Generic Resource", segmentsStr)
+}
+
+func (id GenericResourceId) ID() string {
+	parentId := strings.TrimSuffix(id.ParentId, "/")
+	typeSegments := strings.Split(id.ResourceType, "/")
+
+	// a nested Resource (e.g. a Subnet) is a child of a Parent Resource of the same Resource Provider
+	// (e.g. a Virtual Network) - otherwise this is a top-level or extension Resource. Resource Groups
+	// are a special-case, being nested within the Subscription rather than a Resource Provider.
+	isResourceGroup := strings.EqualFold(id.ResourceType, "Microsoft.Resources/resourceGroups")
+	if (len(typeSegments) > 2 || isResourceGroup) && parentId != "" {
+		parentType := resourceTypeFromSegments(splitResourceId(parentId))
+		if strings.EqualFold(parentType, strings.Join(typeSegments[0:len(typeSegments)-1], "/")) {
+			return fmt.Sprintf("%s/%s/%s", parentId, typeSegments[len(typeSegments)-1], id.Name)
+		}
+	}
+
+	return fmt.Sprintf("%s/providers/%s/%s", parentId, id.ResourceType, id.Name)
+}
+
+// GenericResourceID parses any Azure Resource ID into a GenericResourceId
+func GenericResourceID(input string) (*GenericResourceId, error) {
+	segments := splitResourceId(input)
+	if len(segments) < 2 || len(segments)%2 != 0 {
+		return nil, fmt.Errorf("parsing %q: expected a Resource ID made up of key/value pairs", input)
+	}
+	if strings.EqualFold(segments[0], "subscriptions") && len(segments) == 2 {
+		return nil, fmt.Errorf("parsing %q: expected a Resource ID but got a Subscription ID", input)
+	}
+	if !strings.EqualFold(segments[0], "subscriptions") && !strings.EqualFold(segments[0], "providers") {
+		return nil, fmt.Errorf("parsing %q: expected the Resource ID to start with either `/subscriptions` or `/providers`", input)
+	}
+
+	// the Parent of a Resource is the ID without the last key/value pair - and the Resource Provider
+	parent := segments[0 : len(segments)-2]
+	if len(parent) >= 2 && strings.EqualFold(parent[len(parent)-2], "providers") {
+		parent = parent[0 : len(parent)-2]
+	}
+
+	resourceId := GenericResourceId{
+		ParentId:     "/" + strings.Join(parent, "/"),
+		ResourceType: resourceTypeFromSegments(segments),
+		Name:         segments[len(segments)-1],
+	}
+	if resourceId.ID() != "/"+strings.Join(segments, "/") {
+		return nil, fmt.Errorf("parsing %q: the Resource Type %q could not be determined", input, resourceId.ResourceType)
+	}
+
+	return &resourceId, nil
+}
+
+// GenericResourceImportID parses the ID used to import a Generic Resource - which is the Resource ID
+// and the API Version, e.g. `{resourceId}?api-version=2020-05-01`
+func GenericResourceImportID(input string) (*GenericResourceId, string, error) {
+	parsed, err := url.Parse(input)
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing %q: %+v", input, err)
+	}
+
+	apiVersion := parsed.Query().Get("api-version")
+	if apiVersion == "" {
+		return nil, "", fmt.Errorf("parsing %q: expected the ID to be in the format `{resourceId}?api-version={apiVersion}`", input)
+	}
+
+	id, err := GenericResourceID(parsed.Path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return id, apiVersion, nil
+}
+
+// resourceTypeFromSegments returns the fully qualified Resource Type of the Resource ID made up of
+// the specified segments, e.g. `Microsoft.Network/virtualNetworks/subnets`
+func resourceTypeFromSegments(segments []string) string {
+	namespace := "Microsoft.Resources"
+	start := 2
+	for i := len(segments) - 2; i >= 0; i -= 2 {
+		if strings.EqualFold(segments[i], "providers") {
+			namespace = segments[i+1]
+			start = i + 2
+			break
+		}
+	}
+
+	types := []string{namespace}
+	for i := start; i < len(segments); i += 2 {
+		types = append(types, segments[i])
+	}
+	return strings.Join(types, "/")
+}
+
+func splitResourceId(input string) []string {
+	segments := make([]string, 0)
+	for _, v := range strings.Split(input, "/") {
+		if v != "" {
+			segments = append(segments, v)
+		}
+	}
+	return segments
+}
@@ -0,0 +1,199 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/resourceid"
+)
+
+var _ resourceid.Formatter = GenericResourceId{}
+
+func TestGenericResourceIDFormatter(t *testing.T) {
+	testData := []struct {
+		Input    GenericResourceId
+		Expected string
+	}{
+		{
+			Input:    NewGenericResourceID("/subscriptions/12345678-1234-9876-4563-123456789012", "Microsoft.Resources/resourceGroups", "group1"),
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1",
+		},
+		{
+			Input:    NewGenericResourceID("/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1", "Microsoft.Network/virtualNetworks", "network1"),
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1",
+		},
+		{
+			Input:    NewGenericResourceID("/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1", "Microsoft.Network/virtualNetworks/subnets", "subnet1"),
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1",
+		},
+		{
+			Input:    NewGenericResourceID("/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1", "Microsoft.Authorization/locks", "lock1"),
+			Expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1/providers/Microsoft.Authorization/locks/lock1",
+		},
+		{
+			Input:    NewGenericResourceID("/", "Microsoft.Management/managementGroups", "group1"),
+			Expected: "/providers/Microsoft.Management/managementGroups/group1",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Expected)
+
+		if actual := v.Input.ID(); actual != v.Expected {
+			t.Fatalf("Expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestGenericResourceID(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Error    bool
+		Expected *GenericResourceId
+	}{
+		{
+			// empty
+			Input: "",
+			Error: true,
+		},
+
+		{
+			// subscription
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012",
+			Error: true,
+		},
+
+		{
+			// missing value for Resource Group
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups",
+			Error: true,
+		},
+
+		{
+			// not a resource manager id
+			Input: "/secrets/example/12345",
+			Error: true,
+		},
+
+		{
+			// resource group
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1",
+			Expected: &GenericResourceId{
+				ParentId:     "/subscriptions/12345678-1234-9876-4563-123456789012",
+				ResourceType: "Microsoft.Resources/resourceGroups",
+				Name:         "group1",
+			},
+		},
+
+		{
+			// resource
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1",
+			Expected: &GenericResourceId{
+				ParentId:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1",
+				ResourceType: "Microsoft.Network/virtualNetworks",
+				Name:         "network1",
+			},
+		},
+
+		{
+			// nested resource
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1",
+			Expected: &GenericResourceId{
+				ParentId:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1",
+				ResourceType: "Microsoft.Network/virtualNetworks/subnets",
+				Name:         "subnet1",
+			},
+		},
+
+		{
+			// extension resource
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1/providers/Microsoft.Authorization/locks/lock1",
+			Expected: &GenericResourceId{
+				ParentId:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1",
+				ResourceType: "Microsoft.Authorization/locks",
+				Name:         "lock1",
+			},
+		},
+
+		{
+			// tenant level resource
+			Input: "/providers/Microsoft.Management/managementGroups/group1",
+			Expected: &GenericResourceId{
+				ParentId:     "/",
+				ResourceType: "Microsoft.Management/managementGroups",
+				Name:         "group1",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := GenericResourceID(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if actual.ParentId != v.Expected.ParentId {
+			t.Fatalf("Expected %q but got %q for ParentId", v.Expected.ParentId, actual.ParentId)
+		}
+		if actual.ResourceType != v.Expected.ResourceType {
+			t.Fatalf("Expected %q but got %q for ResourceType", v.Expected.ResourceType, actual.ResourceType)
+		}
+		if actual.Name != v.Expected.Name {
+			t.Fatalf("Expected %q but got %q for Name", v.Expected.Name, actual.Name)
+		}
+	}
+}
+
+func TestGenericResourceImportID(t *testing.T) {
+	testData := []struct {
+		Input              string
+		Error              bool
+		ExpectedApiVersion string
+	}{
+		{
+			// missing api version
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1",
+			Error: true,
+		},
+
+		{
+			// invalid resource id
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012?api-version=2020-05-01",
+			Error: true,
+		},
+
+		{
+			// valid
+			Input:              "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1?api-version=2020-05-01",
+			ExpectedApiVersion: "2020-05-01",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		_, apiVersion, err := GenericResourceImportID(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if apiVersion != v.ExpectedApiVersion {
+			t.Fatalf("Expected %q but got %q for the API Version", v.ExpectedApiVersion, apiVersion)
+		}
+	}
+}
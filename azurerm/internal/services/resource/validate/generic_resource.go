@@ -0,0 +1,64 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/resource/parse"
+)
+
+var genericResourceTypeRegex = regexp.MustCompile(`^[A-Za-z0-9]+(\.[A-Za-z0-9]+)+(/[A-Za-z0-9]+)+@\d{4}-\d{2}-\d{2}(-[A-Za-z0-9]+)?$`)
+
+// GenericResourceType validates a Resource Type with an API Version, e.g. `Microsoft.Network/virtualNetworks@2020-05-01`
+func GenericResourceType(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+
+	if !genericResourceTypeRegex.MatchString(v) {
+		return nil, []error{fmt.Errorf("%q must be a Resource Type and API Version in the format `Microsoft.Foo/bars@2020-01-01` but got %q", k, v)}
+	}
+
+	return nil, nil
+}
+
+// GenericResourceParentID validates the ID of a Resource which can contain other Resources - that is
+// `/` (the Tenant), a Subscription ID, a Resource Group ID or any other Resource ID
+func GenericResourceParentID(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+
+	if v == "/" {
+		return nil, nil
+	}
+
+	segments := strings.Split(strings.TrimPrefix(v, "/"), "/")
+	if len(segments) == 2 && strings.EqualFold(segments[0], "subscriptions") && segments[1] != "" {
+		return nil, nil
+	}
+
+	if _, err := parse.GenericResourceID(v); err != nil {
+		return nil, []error{fmt.Errorf("%q must be `/`, a Subscription ID or a Resource ID: %+v", k, err)}
+	}
+
+	return nil, nil
+}
+
+// GenericResourceImportID validates the ID used to import a Generic Resource, which is the
+// Resource ID and the API Version, e.g. `{resourceId}?api-version=2020-05-01`
+func GenericResourceImportID(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+
+	if _, _, err := parse.GenericResourceImportID(v); err != nil {
+		return nil, []error{err}
+	}
+
+	return nil, nil
+}
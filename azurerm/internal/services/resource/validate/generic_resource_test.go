@@ -0,0 +1,91 @@
+package validate
+
+import "testing"
+
+func TestGenericResourceType(t *testing.T) {
+	cases := []struct {
+		Input string
+		Valid bool
+	}{
+		{
+			Input: "",
+			Valid: false,
+		},
+		{
+			Input: "Microsoft.Network/virtualNetworks",
+			Valid: false,
+		},
+		{
+			Input: "Microsoft.Network@2020-05-01",
+			Valid: false,
+		},
+		{
+			Input: "Microsoft.Network/virtualNetworks@latest",
+			Valid: false,
+		},
+		{
+			Input: "Microsoft.Network/virtualNetworks@2020-05-01",
+			Valid: true,
+		},
+		{
+			Input: "Microsoft.Network/virtualNetworks/subnets@2020-05-01",
+			Valid: true,
+		},
+		{
+			Input: "Microsoft.ContainerService/managedClusters@2021-02-01-preview",
+			Valid: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing Value %s", tc.Input)
+		_, errors := GenericResourceType(tc.Input, "type")
+		valid := len(errors) == 0
+
+		if tc.Valid != valid {
+			t.Fatalf("Expected %t but got %t", tc.Valid, valid)
+		}
+	}
+}
+
+func TestGenericResourceParentID(t *testing.T) {
+	cases := []struct {
+		Input string
+		Valid bool
+	}{
+		{
+			Input: "",
+			Valid: false,
+		},
+		{
+			Input: "/",
+			Valid: true,
+		},
+		{
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012",
+			Valid: true,
+		},
+		{
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups",
+			Valid: false,
+		},
+		{
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1",
+			Valid: true,
+		},
+		{
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1",
+			Valid: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing Value %s", tc.Input)
+		_, errors := GenericResourceParentID(tc.Input, "parent_id")
+		valid := len(errors) == 0
+
+		if tc.Valid != valid {
+			t.Fatalf("Expected %t but got %t", tc.Valid, valid)
+		}
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/Azure/azure-sdk-for-go/services/resourcegraph/mgmt/2019-04-01/resourcegraph"
 	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
 	"github.com/Azure/azure-sdk-for-go/services/storagesync/mgmt/2020-03-01/storagesync"
 	"github.com/Azure/go-autorest/autorest"
 	az "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/providercache"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/shim"
 	"github.com/tombuildsstuff/giovanni/storage/2019-12-12/blob/accounts"
 	"github.com/tombuildsstuff/giovanni/storage/2019-12-12/blob/blobs"
@@ -32,12 +34,15 @@ type Client struct {
 	CloudEndpointsClient     *storagesync.CloudEndpointsClient
 	EncryptionScopesClient   *storage.EncryptionScopesClient
 	Environment              az.Environment
+	ResourceGraphClient      *resourcegraph.BaseClient
 	SyncServiceClient        *storagesync.ServicesClient
 	SyncGroupsClient         *storagesync.SyncGroupsClient
 	SubscriptionId           string
 
 	resourceManagerAuthorizer autorest.Authorizer
+	configureSender           func(c *autorest.Client)
 	storageAdAuth             *autorest.Authorizer
+	providerCache             *providercache.Cache
 }
 
 func NewClient(options *common.ClientOptions) *Client {
@@ -62,6 +67,9 @@ func NewClient(options *common.ClientOptions) *Client {
 	encryptionScopesClient := storage.NewEncryptionScopesClientWithBaseURI(options.ResourceManagerEndpoint, options.SubscriptionId)
 	options.ConfigureClient(&encryptionScopesClient.Client, options.ResourceManagerAuthorizer)
 
+	resourceGraphClient := resourcegraph.NewWithBaseURI(options.ResourceManagerEndpoint)
+	options.ConfigureClient(&resourceGraphClient.Client, options.ResourceManagerAuthorizer)
+
 	syncServiceClient := storagesync.NewServicesClientWithBaseURI(options.ResourceManagerEndpoint, options.SubscriptionId)
 	options.ConfigureClient(&syncServiceClient.Client, options.ResourceManagerAuthorizer)
 
@@ -79,11 +87,14 @@ func NewClient(options *common.ClientOptions) *Client {
 		CloudEndpointsClient:     &cloudEndpointsClient,
 		EncryptionScopesClient:   &encryptionScopesClient,
 		Environment:              options.Environment,
+		ResourceGraphClient:      &resourceGraphClient,
 		SubscriptionId:           options.SubscriptionId,
 		SyncServiceClient:        &syncServiceClient,
 		SyncGroupsClient:         &syncGroupsClient,
 
 		resourceManagerAuthorizer: options.ResourceManagerAuthorizer,
+		configureSender:           options.ConfigureSender,
+		providerCache:             options.ProviderCache,
 	}
 
 	if options.StorageUseAzureAD {
@@ -96,7 +107,7 @@ func NewClient(options *common.ClientOptions) *Client {
 func (client Client) AccountsDataPlaneClient(ctx context.Context, account accountDetails) (*accounts.Client, error) {
 	if client.storageAdAuth != nil {
 		accountsClient := accounts.NewWithEnvironment(client.Environment)
-		accountsClient.Client.Authorizer = *client.storageAdAuth
+		client.configureDataPlaneClient(&accountsClient.Client, *client.storageAdAuth)
 		return &accountsClient, nil
 	}
 
@@ -111,14 +122,14 @@ func (client Client) AccountsDataPlaneClient(ctx context.Context, account accoun
 	}
 
 	accountsClient := accounts.NewWithEnvironment(client.Environment)
-	accountsClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&accountsClient.Client, storageAuth)
 	return &accountsClient, nil
 }
 
 func (client Client) BlobsClient(ctx context.Context, account accountDetails) (*blobs.Client, error) {
 	if client.storageAdAuth != nil {
 		blobsClient := blobs.NewWithEnvironment(client.Environment)
-		blobsClient.Client.Authorizer = *client.storageAdAuth
+		client.configureDataPlaneClient(&blobsClient.Client, *client.storageAdAuth)
 		return &blobsClient, nil
 	}
 
@@ -133,14 +144,14 @@ func (client Client) BlobsClient(ctx context.Context, account accountDetails) (*
 	}
 
 	blobsClient := blobs.NewWithEnvironment(client.Environment)
-	blobsClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&blobsClient.Client, storageAuth)
 	return &blobsClient, nil
 }
 
 func (client Client) ContainersClient(ctx context.Context, account accountDetails) (shim.StorageContainerWrapper, error) {
 	if client.storageAdAuth != nil {
 		containersClient := containers.NewWithEnvironment(client.Environment)
-		containersClient.Client.Authorizer = *client.storageAdAuth
+		client.configureDataPlaneClient(&containersClient.Client, *client.storageAdAuth)
 		shim := shim.NewDataPlaneStorageContainerWrapper(&containersClient)
 		return shim, nil
 	}
@@ -156,7 +167,7 @@ func (client Client) ContainersClient(ctx context.Context, account accountDetail
 	}
 
 	containersClient := containers.NewWithEnvironment(client.Environment)
-	containersClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&containersClient.Client, storageAuth)
 
 	shim := shim.NewDataPlaneStorageContainerWrapper(&containersClient)
 	return shim, nil
@@ -176,7 +187,7 @@ func (client Client) FileShareDirectoriesClient(ctx context.Context, account acc
 	}
 
 	directoriesClient := directories.NewWithEnvironment(client.Environment)
-	directoriesClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&directoriesClient.Client, storageAuth)
 	return &directoriesClient, nil
 }
 
@@ -194,7 +205,7 @@ func (client Client) FileShareFilesClient(ctx context.Context, account accountDe
 	}
 
 	filesClient := files.NewWithEnvironment(client.Environment)
-	filesClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&filesClient.Client, storageAuth)
 	return &filesClient, nil
 }
 
@@ -212,7 +223,7 @@ func (client Client) FileSharesClient(ctx context.Context, account accountDetail
 	}
 
 	sharesClient := shares.NewWithEnvironment(client.Environment)
-	sharesClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&sharesClient.Client, storageAuth)
 	shim := shim.NewDataPlaneStorageShareWrapper(&sharesClient)
 	return shim, nil
 }
@@ -220,7 +231,7 @@ func (client Client) FileSharesClient(ctx context.Context, account accountDetail
 func (client Client) QueuesClient(ctx context.Context, account accountDetails) (shim.StorageQueuesWrapper, error) {
 	if client.storageAdAuth != nil {
 		queueClient := queues.NewWithEnvironment(client.Environment)
-		queueClient.Client.Authorizer = *client.storageAdAuth
+		client.configureDataPlaneClient(&queueClient.Client, *client.storageAdAuth)
 		return shim.NewDataPlaneStorageQueueWrapper(&queueClient), nil
 	}
 
@@ -235,7 +246,7 @@ func (client Client) QueuesClient(ctx context.Context, account accountDetails) (
 	}
 
 	queuesClient := queues.NewWithEnvironment(client.Environment)
-	queuesClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&queuesClient.Client, storageAuth)
 	return shim.NewDataPlaneStorageQueueWrapper(&queuesClient), nil
 }
 
@@ -253,7 +264,7 @@ func (client Client) TableEntityClient(ctx context.Context, account accountDetai
 	}
 
 	entitiesClient := entities.NewWithEnvironment(client.Environment)
-	entitiesClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&entitiesClient.Client, storageAuth)
 	return &entitiesClient, nil
 }
 
@@ -271,7 +282,16 @@ func (client Client) TablesClient(ctx context.Context, account accountDetails) (
 	}
 
 	tablesClient := tables.NewWithEnvironment(client.Environment)
-	tablesClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&tablesClient.Client, storageAuth)
 	shim := shim.NewDataPlaneStorageTableWrapper(&tablesClient)
 	return shim, nil
 }
+
+// configureDataPlaneClient configures the Data Plane client with the specified Authorizer, and
+// to send requests (e.g. retrying) in the same manner as the Resource Manager clients
+func (client Client) configureDataPlaneClient(c *autorest.Client, authorizer autorest.Authorizer) {
+	c.Authorizer = authorizer
+	if client.configureSender != nil {
+		client.configureSender(c)
+	}
+}
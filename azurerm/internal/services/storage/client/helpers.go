@@ -4,19 +4,39 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/resourcegraph/mgmt/2019-04-01/resourcegraph"
 	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/locks"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+// the Storage Accounts are also cached on-disk (when enabled) so that they don't need to be looked up on every run
+// - the Account Keys are intentionally only cached in-memory
+const accountCacheTTL = 1 * time.Hour
+
+// lookupLockType is used to ensure that a given Storage Account is only looked up once at a time, rather than
+// each Data Plane resource within it looking it up concurrently
+const lookupLockType = "storageAccountLookup"
+
 var (
 	storageAccountsCache = map[string]accountDetails{}
 
+	// accountsLock protects the storageAccountsCache and is only held whilst it's being accessed
 	accountsLock    = sync.RWMutex{}
 	credentialsLock = sync.RWMutex{}
 )
 
+// cachedAccount is the subset of accountDetails which is cached on-disk, since the read-only fields within
+// the AccountProperties aren't serialized by the SDK (as such Properties is nil when loaded from the cache)
+type cachedAccount struct {
+	ID string `json:"id"`
+}
+
 type accountDetails struct {
 	ID            string
 	ResourceGroup string
@@ -37,6 +57,10 @@ func (ad *accountDetails) AccountKey(ctx context.Context, client Client) (*strin
 	log.Printf("[DEBUG] Cache Miss - looking up the account key for storage account %q..", ad.name)
 	props, err := client.AccountsClient.ListKeys(ctx, ad.ResourceGroup, ad.name, storage.Kerb)
 	if err != nil {
+		// the cached details for this Storage Account are out of date, for example it's been recreated elsewhere
+		if utils.ResponseWasNotFound(props.Response) {
+			client.RemoveAccountFromCache(ad.name)
+		}
 		return nil, fmt.Errorf("Error Listing Keys for Storage Account %q (Resource Group %q): %+v", ad.name, ad.ResourceGroup, err)
 	}
 
@@ -48,39 +72,57 @@ func (ad *accountDetails) AccountKey(ctx context.Context, client Client) (*strin
 	ad.accountKey = keys[0].Value
 
 	// force-cache this
+	accountsLock.Lock()
 	storageAccountsCache[ad.name] = *ad
+	accountsLock.Unlock()
 
 	return ad.accountKey, nil
 }
 
 func (client Client) AddToCache(accountName string, props storage.Account) error {
-	accountsLock.Lock()
-	defer accountsLock.Unlock()
-
 	account, err := populateAccountDetails(accountName, props)
 	if err != nil {
 		return err
 	}
 
-	storageAccountsCache[accountName] = *account
-
+	client.cacheAccount(*account)
 	return nil
 }
 
 func (client Client) RemoveAccountFromCache(accountName string) {
 	accountsLock.Lock()
 	delete(storageAccountsCache, accountName)
+	client.providerCache.Delete(accountCacheKey(accountName))
 	accountsLock.Unlock()
 }
 
+// FindAccount looks up the Storage Account with the specified name within the Subscription, returning nil if it
+// doesn't exist. Where the Resource ID of the Storage Account is known FindAccountByID should be used instead.
 func (client Client) FindAccount(ctx context.Context, accountName string) (*accountDetails, error) {
-	accountsLock.Lock()
-	defer accountsLock.Unlock()
+	if existing := client.cachedAccount(accountName); existing != nil {
+		return existing, nil
+	}
+
+	locks.ByName(accountName, lookupLockType)
+	defer locks.UnlockByName(accountName, lookupLockType)
 
-	if existing, ok := storageAccountsCache[accountName]; ok {
-		return &existing, nil
+	// another lookup for this Storage Account may have completed whilst we were waiting
+	if existing := client.cachedAccount(accountName); existing != nil {
+		return existing, nil
+	}
+
+	log.Printf("[DEBUG] Cache Miss - querying Resource Graph for the Storage Account %q..", accountName)
+	accountId, err := client.findAccountIdUsingResourceGraph(ctx, accountName)
+	if err != nil {
+		log.Printf("[DEBUG] Unable to find the Storage Account %q using Resource Graph: %+v", accountName, err)
+	}
+	if accountId != nil {
+		return client.FindAccountByID(ctx, *accountId)
 	}
 
+	// Resource Graph is eventually consistent, so a recently created Storage Account may not be present yet - as
+	// such we fall back to listing the Storage Accounts within the Subscription
+	log.Printf("[DEBUG] Cache Miss - listing the Storage Accounts to find %q..", accountName)
 	accountsPage, err := client.AccountsClient.List(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("Error retrieving storage accounts: %+v", err)
@@ -95,6 +137,7 @@ func (client Client) FindAccount(ctx context.Context, accountName string) (*acco
 		}
 	}
 
+	var found *accountDetails
 	for _, v := range accounts {
 		if v.Name == nil {
 			continue
@@ -105,16 +148,152 @@ func (client Client) FindAccount(ctx context.Context, accountName string) (*acco
 			return nil, err
 		}
 
-		storageAccountsCache[*v.Name] = *account
+		client.cacheAccount(*account)
+		if *v.Name == accountName {
+			found = account
+		}
+	}
+
+	return found, nil
+}
+
+// FindAccountByID retrieves the Storage Account with the specified Resource ID, returning nil if it doesn't exist
+func (client Client) FindAccountByID(ctx context.Context, accountId string) (*accountDetails, error) {
+	id, err := parse.StorageAccountID(accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing := client.cachedAccount(id.Name); existing != nil && strings.EqualFold(existing.ID, id.ID()) {
+		return existing, nil
+	}
+
+	log.Printf("[DEBUG] Cache Miss - retrieving the Storage Account %q..", id.Name)
+	props, err := client.AccountsClient.GetProperties(ctx, id.ResourceGroup, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(props.Response) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	account, err := populateAccountDetails(id.Name, props)
+	if err != nil {
+		return nil, err
 	}
 
-	if existing, ok := storageAccountsCache[accountName]; ok {
-		return &existing, nil
+	client.cacheAccount(*account)
+	return account, nil
+}
+
+// FindAccountByIDOrName looks up the Storage Account using the Resource ID when it's specified and refers to the
+// Storage Account `accountName`, otherwise falling back to looking it up by name
+func (client Client) FindAccountByIDOrName(ctx context.Context, accountId string, accountName string) (*accountDetails, error) {
+	if accountId != "" {
+		id, err := parse.StorageAccountID(accountId)
+		if err != nil {
+			return nil, err
+		}
+
+		if accountName == "" || id.Name == accountName {
+			return client.FindAccountByID(ctx, accountId)
+		}
+	}
+
+	return client.FindAccount(ctx, accountName)
+}
+
+func (client Client) findAccountIdUsingResourceGraph(ctx context.Context, accountName string) (*string, error) {
+	// Storage Account names can only contain lowercase letters and numbers, but guard against this anyway
+	// since the name is interpolated into the query
+	if strings.ContainsAny(accountName, `'"\`) {
+		return nil, fmt.Errorf("the name %q contains invalid characters", accountName)
+	}
+
+	query := fmt.Sprintf("Resources | where type =~ 'Microsoft.Storage/storageAccounts' and name =~ '%s' | project id", accountName)
+	resp, err := client.ResourceGraphClient.Resources(ctx, resourcegraph.QueryRequest{
+		Subscriptions: &[]string{client.SubscriptionId},
+		Query:         utils.String(query),
+		Options: &resourcegraph.QueryRequestOptions{
+			ResultFormat: resourcegraph.ResultFormatObjectArray,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("querying Resource Graph: %+v", err)
+	}
+
+	rows, ok := resp.Data.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected the Resource Graph results to be an array but got %T", resp.Data)
+	}
+
+	for _, row := range rows {
+		values, ok := row.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		accountId, ok := values["id"].(string)
+		if !ok {
+			continue
+		}
+
+		// Resource Graph compares case-insensitively, whereas Storage Account names are lower-case
+		id, err := parse.StorageAccountID(accountId)
+		if err != nil || id.Name != accountName {
+			continue
+		}
+
+		return &accountId, nil
 	}
 
 	return nil, nil
 }
 
+func (client Client) cachedAccount(accountName string) *accountDetails {
+	accountsLock.RLock()
+	existing, ok := storageAccountsCache[accountName]
+	accountsLock.RUnlock()
+	if ok {
+		return &existing
+	}
+
+	var cached cachedAccount
+	if !client.providerCache.Get(accountCacheKey(accountName), accountCacheTTL, &cached) {
+		return nil
+	}
+
+	id, err := parse.StorageAccountID(cached.ID)
+	if err != nil {
+		client.providerCache.Delete(accountCacheKey(accountName))
+		return nil
+	}
+
+	account := accountDetails{
+		name:          accountName,
+		ID:            cached.ID,
+		ResourceGroup: id.ResourceGroup,
+	}
+
+	accountsLock.Lock()
+	storageAccountsCache[accountName] = account
+	accountsLock.Unlock()
+
+	return &account
+}
+
+func (client Client) cacheAccount(account accountDetails) {
+	accountsLock.Lock()
+	storageAccountsCache[account.name] = account
+	accountsLock.Unlock()
+
+	client.providerCache.Set(accountCacheKey(account.name), cachedAccount{ID: account.ID})
+}
+
+func accountCacheKey(accountName string) string {
+	return fmt.Sprintf("storage-account/%s", strings.ToLower(accountName))
+}
+
 func populateAccountDetails(accountName string, props storage.Account) (*accountDetails, error) {
 	if props.ID == nil {
 		return nil, fmt.Errorf("`id` was nil for Account %q", accountName)
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/validate"
+)
+
+// the Data Plane resources can reference the Storage Account either by name or by Resource ID - when the Resource ID
+// is specified the Storage Account can be retrieved directly, rather than having to be looked up by name
+
+func storageAccountNameForDataPlaneSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ForceNew:     true,
+		ValidateFunc: ValidateStorageAccountName,
+		ExactlyOneOf: []string{"storage_account_id", "storage_account_name"},
+	}
+}
+
+func storageAccountIdForDataPlaneSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ForceNew:     true,
+		ValidateFunc: validate.StorageAccountID,
+		ExactlyOneOf: []string{"storage_account_id", "storage_account_name"},
+	}
+}
+
+// storageAccountNameForDataPlane returns the name of the Storage Account specified using either the
+// `storage_account_name` or the `storage_account_id` field
+func storageAccountNameForDataPlane(d *schema.ResourceData) (string, error) {
+	if v := d.Get("storage_account_name").(string); v != "" {
+		return v, nil
+	}
+
+	id, err := parse.StorageAccountID(d.Get("storage_account_id").(string))
+	if err != nil {
+		return "", fmt.Errorf("parsing `storage_account_id`: %+v", err)
+	}
+
+	return id.Name, nil
+}
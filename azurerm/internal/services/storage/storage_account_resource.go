@@ -13,12 +13,14 @@ import (
 	autorestAzure "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/hashicorp/go-azure-helpers/response"
 	"github.com/hashicorp/go-getter/helper/url"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/locks"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/resourcemove"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/network"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
@@ -60,7 +62,7 @@ func resourceStorageAccount() *schema.Resource {
 				ValidateFunc: ValidateStorageAccountName,
 			},
 
-			"resource_group_name": azure.SchemaResourceGroupName(),
+			"resource_group_name": resourcemove.SchemaResourceGroupName(),
 
 			"location": azure.SchemaLocation(),
 
@@ -569,7 +571,7 @@ func resourceStorageAccount() *schema.Resource {
 				},
 			},
 		},
-		CustomizeDiff: func(d *schema.ResourceDiff, v interface{}) error {
+		CustomizeDiff: customdiff.Sequence(resourcemove.CustomizeDiff, func(d *schema.ResourceDiff, v interface{}) error {
 			if d.HasChange("account_kind") {
 				accountKind, changedKind := d.GetChange("account_kind")
 
@@ -589,7 +591,7 @@ func resourceStorageAccount() *schema.Resource {
 			}
 
 			return nil
-		},
+		}),
 	}
 }
 
@@ -762,7 +764,7 @@ func resourceStorageAccountCreate(d *schema.ResourceData, meta interface{}) erro
 
 	if val, ok := d.GetOk("queue_properties"); ok {
 		storageClient := meta.(*clients.Client).Storage
-		account, err := storageClient.FindAccount(ctx, storageAccountName)
+		account, err := storageClient.FindAccountByID(ctx, d.Id())
 		if err != nil {
 			return fmt.Errorf("Error retrieving Account %q: %s", storageAccountName, err)
 		}
@@ -792,7 +794,7 @@ func resourceStorageAccountCreate(d *schema.ResourceData, meta interface{}) erro
 		}
 		storageClient := meta.(*clients.Client).Storage
 
-		account, err := storageClient.FindAccount(ctx, storageAccountName)
+		account, err := storageClient.FindAccountByID(ctx, d.Id())
 		if err != nil {
 			return fmt.Errorf("Error retrieving Account %q: %s", storageAccountName, err)
 		}
@@ -821,6 +823,15 @@ func resourceStorageAccountUpdate(d *schema.ResourceData, meta interface{}) erro
 	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
+	if d.HasChange("resource_group_name") {
+		if err := resourcemove.MoveIfResourceGroupChanged(ctx, d, meta); err != nil {
+			return err
+		}
+
+		// the cached details for this Storage Account include the Resource Group
+		meta.(*clients.Client).Storage.RemoveAccountFromCache(d.Get("name").(string))
+	}
+
 	id, err := azure.ParseAzureResourceID(d.Id())
 	if err != nil {
 		return err
@@ -1020,7 +1031,7 @@ func resourceStorageAccountUpdate(d *schema.ResourceData, meta interface{}) erro
 
 	if d.HasChange("queue_properties") {
 		storageClient := meta.(*clients.Client).Storage
-		account, err := storageClient.FindAccount(ctx, storageAccountName)
+		account, err := storageClient.FindAccountByID(ctx, d.Id())
 		if err != nil {
 			return fmt.Errorf("Error retrieving Account %q: %s", storageAccountName, err)
 		}
@@ -1050,7 +1061,7 @@ func resourceStorageAccountUpdate(d *schema.ResourceData, meta interface{}) erro
 		}
 		storageClient := meta.(*clients.Client).Storage
 
-		account, err := storageClient.FindAccount(ctx, storageAccountName)
+		account, err := storageClient.FindAccountByID(ctx, d.Id())
 		if err != nil {
 			return fmt.Errorf("Error retrieving Account %q: %s", storageAccountName, err)
 		}
@@ -1223,8 +1234,13 @@ func resourceStorageAccountRead(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
+	// we've already retrieved this Storage Account - so cache it to avoid looking it up again
 	storageClient := meta.(*clients.Client).Storage
-	account, err := storageClient.FindAccount(ctx, name)
+	if err := storageClient.AddToCache(name, resp); err != nil {
+		return fmt.Errorf("caching Account %q: %s", name, err)
+	}
+
+	account, err := storageClient.FindAccountByID(ctx, d.Id())
 	if err != nil {
 		return fmt.Errorf("Error retrieving Account %q: %s", name, err)
 	}
@@ -1277,7 +1293,7 @@ func resourceStorageAccountRead(d *schema.ResourceData, meta interface{}) error
 	if resp.Kind == storage.StorageV2 || resp.Kind == storage.BlockBlobStorage {
 		storageClient := meta.(*clients.Client).Storage
 
-		account, err := storageClient.FindAccount(ctx, name)
+		account, err := storageClient.FindAccountByID(ctx, d.Id())
 		if err != nil {
 			return fmt.Errorf("Error retrieving Account %q: %s", name, err)
 		}
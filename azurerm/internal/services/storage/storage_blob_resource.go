@@ -43,12 +43,9 @@ func resourceStorageBlob() *schema.Resource {
 				// TODO: add validation
 			},
 
-			"storage_account_name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: ValidateStorageAccountName,
-			},
+			"storage_account_name": storageAccountNameForDataPlaneSchema(),
+
+			"storage_account_id": storageAccountIdForDataPlaneSchema(),
 
 			"storage_container_name": {
 				Type:         schema.TypeString,
@@ -145,11 +142,14 @@ func resourceStorageBlobCreate(d *schema.ResourceData, meta interface{}) error {
 	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
-	accountName := d.Get("storage_account_name").(string)
+	accountName, err := storageAccountNameForDataPlane(d)
+	if err != nil {
+		return err
+	}
 	containerName := d.Get("storage_container_name").(string)
 	name := d.Get("name").(string)
 
-	account, err := storageClient.FindAccount(ctx, accountName)
+	account, err := storageClient.FindAccountByIDOrName(ctx, d.Get("storage_account_id").(string), accountName)
 	if err != nil {
 		return fmt.Errorf("Error retrieving Account %q for Blob %q (Container %q): %s", accountName, name, containerName, err)
 	}
@@ -224,7 +224,7 @@ func resourceStorageBlobUpdate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error parsing %q: %s", d.Id(), err)
 	}
 
-	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	account, err := storageClient.FindAccountByIDOrName(ctx, d.Get("storage_account_id").(string), id.AccountName)
 	if err != nil {
 		return fmt.Errorf("Error retrieving Account %q for Blob %q (Container %q): %s", id.AccountName, id.BlobName, id.ContainerName, err)
 	}
@@ -296,7 +296,7 @@ func resourceStorageBlobRead(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error parsing %q: %s", d.Id(), err)
 	}
 
-	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	account, err := storageClient.FindAccountByIDOrName(ctx, d.Get("storage_account_id").(string), id.AccountName)
 	if err != nil {
 		return fmt.Errorf("Error retrieving Account %q for Blob %q (Container %q): %s", id.AccountName, id.BlobName, id.ContainerName, err)
 	}
@@ -327,6 +327,7 @@ func resourceStorageBlobRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("name", id.BlobName)
 	d.Set("storage_container_name", id.ContainerName)
 	d.Set("storage_account_name", id.AccountName)
+	d.Set("storage_account_id", account.ID)
 
 	d.Set("access_tier", string(props.AccessTier))
 	d.Set("content_type", props.ContentType)
@@ -366,7 +367,7 @@ func resourceStorageBlobDelete(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error parsing %q: %s", d.Id(), err)
 	}
 
-	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	account, err := storageClient.FindAccountByIDOrName(ctx, d.Get("storage_account_id").(string), id.AccountName)
 	if err != nil {
 		return fmt.Errorf("Error retrieving Account %q for Blob %q (Container %q): %s", id.AccountName, id.BlobName, id.ContainerName, err)
 	}
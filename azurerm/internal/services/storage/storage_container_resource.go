@@ -43,12 +43,9 @@ func resourceStorageContainer() *schema.Resource {
 				ValidateFunc: validate.StorageContainerName,
 			},
 
-			"storage_account_name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: ValidateStorageAccountName,
-			},
+			"storage_account_name": storageAccountNameForDataPlaneSchema(),
+
+			"storage_account_id": storageAccountIdForDataPlaneSchema(),
 
 			"container_access_type": {
 				Type:     schema.TypeString,
@@ -88,14 +85,17 @@ func resourceStorageContainerCreate(d *schema.ResourceData, meta interface{}) er
 	defer cancel()
 
 	containerName := d.Get("name").(string)
-	accountName := d.Get("storage_account_name").(string)
+	accountName, err := storageAccountNameForDataPlane(d)
+	if err != nil {
+		return err
+	}
 	accessLevelRaw := d.Get("container_access_type").(string)
 	accessLevel := expandStorageContainerAccessLevel(accessLevelRaw)
 
 	metaDataRaw := d.Get("metadata").(map[string]interface{})
 	metaData := ExpandMetaData(metaDataRaw)
 
-	account, err := storageClient.FindAccount(ctx, accountName)
+	account, err := storageClient.FindAccountByIDOrName(ctx, d.Get("storage_account_id").(string), accountName)
 	if err != nil {
 		return fmt.Errorf("Error retrieving Account %q for Container %q: %s", accountName, containerName, err)
 	}
@@ -141,7 +141,7 @@ func resourceStorageContainerUpdate(d *schema.ResourceData, meta interface{}) er
 		return err
 	}
 
-	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	account, err := storageClient.FindAccountByIDOrName(ctx, d.Get("storage_account_id").(string), id.AccountName)
 	if err != nil {
 		return fmt.Errorf("retrieving Account %q for Container %q: %s", id.AccountName, id.Name, err)
 	}
@@ -191,7 +191,7 @@ func resourceStorageContainerRead(d *schema.ResourceData, meta interface{}) erro
 		return err
 	}
 
-	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	account, err := storageClient.FindAccountByIDOrName(ctx, d.Get("storage_account_id").(string), id.AccountName)
 	if err != nil {
 		return fmt.Errorf("retrieving Account %q for Container %q: %s", id.AccountName, id.Name, err)
 	}
@@ -217,6 +217,7 @@ func resourceStorageContainerRead(d *schema.ResourceData, meta interface{}) erro
 
 	d.Set("name", id.Name)
 	d.Set("storage_account_name", id.AccountName)
+	d.Set("storage_account_id", account.ID)
 
 	d.Set("container_access_type", flattenStorageContainerAccessLevel(props.AccessLevel))
 
@@ -243,7 +244,7 @@ func resourceStorageContainerDelete(d *schema.ResourceData, meta interface{}) er
 		return err
 	}
 
-	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	account, err := storageClient.FindAccountByIDOrName(ctx, d.Get("storage_account_id").(string), id.AccountName)
 	if err != nil {
 		return fmt.Errorf("Error retrieving Account %q for Container %q: %s", id.AccountName, id.Name, err)
 	}
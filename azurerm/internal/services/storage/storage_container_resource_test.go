@@ -33,6 +33,27 @@ func TestAccStorageContainer_basic(t *testing.T) {
 	})
 }
 
+func TestAccStorageContainer_storageAccountId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_container", "test")
+	r := StorageContainerResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.storageAccountId(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("storage_account_name").Exists(),
+			),
+		},
+		data.ImportStep(),
+		{
+			// switching to the name of the same Storage Account shouldn't recreate the Container
+			Config:   r.basic(data),
+			PlanOnly: true,
+		},
+	})
+}
+
 func TestAccStorageContainer_deleteAndRecreate(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_storage_container", "test")
 	r := StorageContainerResource{}
@@ -243,6 +264,19 @@ resource "azurerm_storage_container" "test" {
 `, template)
 }
 
+func (r StorageContainerResource) storageAccountId(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_container" "test" {
+  name                  = "vhds"
+  storage_account_id    = azurerm_storage_account.test.id
+  container_access_type = "private"
+}
+`, template)
+}
+
 func (r StorageContainerResource) basicAzureADAuth(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -40,12 +40,9 @@ func resourceStorageQueue() *schema.Resource {
 				ValidateFunc: validate.StorageQueueName,
 			},
 
-			"storage_account_name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: ValidateStorageAccountName,
-			},
+			"storage_account_name": storageAccountNameForDataPlaneSchema(),
+
+			"storage_account_id": storageAccountIdForDataPlaneSchema(),
 
 			"metadata": MetaDataSchema(),
 		},
@@ -58,12 +55,15 @@ func resourceStorageQueueCreate(d *schema.ResourceData, meta interface{}) error
 	defer cancel()
 
 	queueName := d.Get("name").(string)
-	accountName := d.Get("storage_account_name").(string)
+	accountName, err := storageAccountNameForDataPlane(d)
+	if err != nil {
+		return err
+	}
 
 	metaDataRaw := d.Get("metadata").(map[string]interface{})
 	metaData := ExpandMetaData(metaDataRaw)
 
-	account, err := storageClient.FindAccount(ctx, accountName)
+	account, err := storageClient.FindAccountByIDOrName(ctx, d.Get("storage_account_id").(string), accountName)
 	if err != nil {
 		return fmt.Errorf("retrieving Account %q for Queue %q: %s", accountName, queueName, err)
 	}
@@ -107,7 +107,7 @@ func resourceStorageQueueUpdate(d *schema.ResourceData, meta interface{}) error
 	metaDataRaw := d.Get("metadata").(map[string]interface{})
 	metaData := ExpandMetaData(metaDataRaw)
 
-	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	account, err := storageClient.FindAccountByIDOrName(ctx, d.Get("storage_account_id").(string), id.AccountName)
 	if err != nil {
 		return fmt.Errorf("retrieving Account %q for Queue %q: %s", id.AccountName, id.Name, err)
 	}
@@ -137,7 +137,7 @@ func resourceStorageQueueRead(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	account, err := storageClient.FindAccountByIDOrName(ctx, d.Get("storage_account_id").(string), id.AccountName)
 	if err != nil {
 		return fmt.Errorf("retrieving Account %q for Queue %q: %s", id.AccountName, id.Name, err)
 	}
@@ -164,6 +164,7 @@ func resourceStorageQueueRead(d *schema.ResourceData, meta interface{}) error {
 
 	d.Set("name", id.Name)
 	d.Set("storage_account_name", id.AccountName)
+	d.Set("storage_account_id", account.ID)
 
 	if err := d.Set("metadata", FlattenMetaData(queue.MetaData)); err != nil {
 		return fmt.Errorf("setting `metadata`: %s", err)
@@ -182,7 +183,7 @@ func resourceStorageQueueDelete(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
-	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	account, err := storageClient.FindAccountByIDOrName(ctx, d.Get("storage_account_id").(string), id.AccountName)
 	if err != nil {
 		return fmt.Errorf("retrieving Account %q for Queue %q: %s", id.AccountName, id.Name, err)
 	}
@@ -54,11 +54,9 @@ func resourceStorageShare() *schema.Resource {
 				ValidateFunc: validate.StorageShareName,
 			},
 
-			"storage_account_name": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
-			},
+			"storage_account_name": storageAccountNameForDataPlaneSchema(),
+
+			"storage_account_id": storageAccountIdForDataPlaneSchema(),
 
 			"quota": {
 				Type:         schema.TypeInt,
@@ -124,7 +122,10 @@ func resourceStorageShareCreate(d *schema.ResourceData, meta interface{}) error
 	defer cancel()
 	storageClient := meta.(*clients.Client).Storage
 
-	accountName := d.Get("storage_account_name").(string)
+	accountName, err := storageAccountNameForDataPlane(d)
+	if err != nil {
+		return err
+	}
 	shareName := d.Get("name").(string)
 	quota := d.Get("quota").(int)
 
@@ -134,7 +135,7 @@ func resourceStorageShareCreate(d *schema.ResourceData, meta interface{}) error
 	aclsRaw := d.Get("acl").(*schema.Set).List()
 	acls := expandStorageShareACLs(aclsRaw)
 
-	account, err := storageClient.FindAccount(ctx, accountName)
+	account, err := storageClient.FindAccountByIDOrName(ctx, d.Get("storage_account_id").(string), accountName)
 	if err != nil {
 		return fmt.Errorf("Error retrieving Account %q for Share %q: %s", accountName, shareName, err)
 	}
@@ -185,7 +186,7 @@ func resourceStorageShareRead(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	account, err := storageClient.FindAccountByIDOrName(ctx, d.Get("storage_account_id").(string), id.AccountName)
 	if err != nil {
 		return fmt.Errorf("retrieving Account %q for Share %q: %s", id.AccountName, id.Name, err)
 	}
@@ -212,6 +213,7 @@ func resourceStorageShareRead(d *schema.ResourceData, meta interface{}) error {
 
 	d.Set("name", id.Name)
 	d.Set("storage_account_name", id.AccountName)
+	d.Set("storage_account_id", account.ID)
 	d.Set("quota", props.QuotaGB)
 	d.Set("url", id.ID())
 
@@ -239,7 +241,7 @@ func resourceStorageShareUpdate(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
-	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	account, err := storageClient.FindAccountByIDOrName(ctx, d.Get("storage_account_id").(string), id.AccountName)
 	if err != nil {
 		return fmt.Errorf("Error retrieving Account %q for Share %q: %s", id.AccountName, id.Name, err)
 	}
@@ -302,7 +304,7 @@ func resourceStorageShareDelete(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
-	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	account, err := storageClient.FindAccountByIDOrName(ctx, d.Get("storage_account_id").(string), id.AccountName)
 	if err != nil {
 		return fmt.Errorf("retrieving Account %q for Share %q: %s", id.AccountName, id.Name, err)
 	}
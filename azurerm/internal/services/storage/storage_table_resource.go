@@ -54,12 +54,9 @@ func resourceStorageTable() *schema.Resource {
 				ValidateFunc: validate.StorageTableName,
 			},
 
-			"storage_account_name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: ValidateStorageAccountName,
-			},
+			"storage_account_name": storageAccountNameForDataPlaneSchema(),
+
+			"storage_account_id": storageAccountIdForDataPlaneSchema(),
 
 			"acl": {
 				Type:     schema.TypeSet,
@@ -107,11 +104,14 @@ func resourceStorageTableCreate(d *schema.ResourceData, meta interface{}) error
 	storageClient := meta.(*clients.Client).Storage
 
 	tableName := d.Get("name").(string)
-	accountName := d.Get("storage_account_name").(string)
+	accountName, err := storageAccountNameForDataPlane(d)
+	if err != nil {
+		return err
+	}
 	aclsRaw := d.Get("acl").(*schema.Set).List()
 	acls := expandStorageTableACLs(aclsRaw)
 
-	account, err := storageClient.FindAccount(ctx, accountName)
+	account, err := storageClient.FindAccountByIDOrName(ctx, d.Get("storage_account_id").(string), accountName)
 	if err != nil {
 		return fmt.Errorf("retrieving Account %q for Table %q: %s", accountName, tableName, err)
 	}
@@ -157,7 +157,7 @@ func resourceStorageTableRead(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	account, err := storageClient.FindAccountByIDOrName(ctx, d.Get("storage_account_id").(string), id.AccountName)
 	if err != nil {
 		return fmt.Errorf("Error retrieving Account %q for Table %q: %s", id.AccountName, id.Name, err)
 	}
@@ -189,6 +189,7 @@ func resourceStorageTableRead(d *schema.ResourceData, meta interface{}) error {
 
 	d.Set("name", id.Name)
 	d.Set("storage_account_name", id.AccountName)
+	d.Set("storage_account_id", account.ID)
 
 	if err := d.Set("acl", flattenStorageTableACLs(acls)); err != nil {
 		return fmt.Errorf("flattening `acl`: %+v", err)
@@ -207,7 +208,7 @@ func resourceStorageTableDelete(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
-	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	account, err := storageClient.FindAccountByIDOrName(ctx, d.Get("storage_account_id").(string), id.AccountName)
 	if err != nil {
 		return fmt.Errorf("Error retrieving Account %q for Table %q: %s", id.AccountName, id.Name, err)
 	}
@@ -238,7 +239,7 @@ func resourceStorageTableUpdate(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
-	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	account, err := storageClient.FindAccountByIDOrName(ctx, d.Get("storage_account_id").(string), id.AccountName)
 	if err != nil {
 		return fmt.Errorf("retrieving Account %q for Table %q: %s", id.AccountName, id.Name, err)
 	}
@@ -0,0 +1,41 @@
+package tags
+
+import "sync"
+
+var (
+	// configuration contains the `default_tags` and `ignore_tags` blocks defined in the Provider block, which
+	// are taken into account by Expand and Flatten. Since Terraform launches a separate process for each
+	// Provider block (including aliases) this is only configured once per process, other than in tests.
+	configuration = struct {
+		sync.RWMutex
+
+		defaults map[string]string
+		ignore   IgnoreConfig
+	}{}
+)
+
+// Configure sets the Default Tags (which Expand merges into the Tags for every Resource) and the
+// Ignored Tags (which Flatten removes from the Tags for every Resource and Data Source) defined in the Provider block
+func Configure(defaults map[string]string, ignore IgnoreConfig) {
+	configuration.Lock()
+	defer configuration.Unlock()
+
+	configuration.defaults = defaults
+	configuration.ignore = ignore
+}
+
+// Defaults returns the Default Tags defined in the Provider block
+func Defaults() map[string]string {
+	configuration.RLock()
+	defer configuration.RUnlock()
+
+	return configuration.defaults
+}
+
+// Ignored returns the configuration for the Tags which are managed outside of Terraform, as defined in the Provider block
+func Ignored() IgnoreConfig {
+	configuration.RLock()
+	defer configuration.RUnlock()
+
+	return configuration.ignore
+}
@@ -0,0 +1,88 @@
+package tags
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// SchemaAll returns the Schema used for the `tags_all` attribute, which exposes the
+// effective tags for a resource (that is, the configured tags merged with any tags
+// defined in the `default_tags` block of the Provider)
+func SchemaAll() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// MergeDefaults merges the configured tags for a resource over the top of the
+// default tags defined in the Provider block - where a key exists in both, the
+// value from the resource takes precedence
+func MergeDefaults(defaults map[string]string, configured map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(defaults)+len(configured))
+
+	for k, v := range defaults {
+		output[k] = v
+	}
+
+	for k, v := range configured {
+		// Validate should have ignored this error already
+		value, _ := TagValueToString(v)
+
+		// tag keys are case-insensitive in Azure, so the resource value replaces the default
+		for existing := range output {
+			if strings.EqualFold(existing, k) {
+				delete(output, existing)
+			}
+		}
+
+		output[k] = value
+	}
+
+	return output
+}
+
+// RemoveDefaults removes any tags inherited from the default tags defined in the Provider
+// block, so that these don't show up as a diff in the user-facing `tags` field.
+//
+// Tags are only removed when the value matches the default value and the key hasn't been
+// explicitly configured on the resource (as determined by the keys within `configured`).
+func RemoveDefaults(defaults map[string]string, flattened map[string]interface{}, configured map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(flattened))
+
+	for k, v := range flattened {
+		if !isConfiguredKey(k, configured) {
+			if defaultValue, ok := lookupDefault(k, defaults); ok && defaultValue == v {
+				continue
+			}
+		}
+
+		output[k] = v
+	}
+
+	return output
+}
+
+func isConfiguredKey(key string, configured map[string]interface{}) bool {
+	for k := range configured {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func lookupDefault(key string, defaults map[string]string) (string, bool) {
+	for k, v := range defaults {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+
+	return "", false
+}
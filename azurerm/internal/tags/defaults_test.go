@@ -0,0 +1,103 @@
+package tags
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeDefaults(t *testing.T) {
+	testData := []struct {
+		Name       string
+		Defaults   map[string]string
+		Configured map[string]interface{}
+		Expected   map[string]interface{}
+	}{
+		{
+			Name:       "Empty",
+			Defaults:   map[string]string{},
+			Configured: map[string]interface{}{},
+			Expected:   map[string]interface{}{},
+		},
+		{
+			Name:     "Defaults Only",
+			Defaults: map[string]string{"env": "prod"},
+			Expected: map[string]interface{}{"env": "prod"},
+		},
+		{
+			Name:       "Configured Only",
+			Configured: map[string]interface{}{"hello": "there", "number": 3},
+			Expected:   map[string]interface{}{"hello": "there", "number": "3"},
+		},
+		{
+			Name:       "Configured Overrides Default",
+			Defaults:   map[string]string{"env": "prod", "owner": "team"},
+			Configured: map[string]interface{}{"env": "dev"},
+			Expected:   map[string]interface{}{"env": "dev", "owner": "team"},
+		},
+		{
+			Name:       "Configured Overrides Default Case Insensitively",
+			Defaults:   map[string]string{"Env": "prod"},
+			Configured: map[string]interface{}{"env": "dev"},
+			Expected:   map[string]interface{}{"env": "dev"},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual := MergeDefaults(v.Defaults, v.Configured)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestRemoveDefaults(t *testing.T) {
+	testData := []struct {
+		Name       string
+		Defaults   map[string]string
+		Flattened  map[string]interface{}
+		Configured map[string]interface{}
+		Expected   map[string]interface{}
+	}{
+		{
+			Name:      "No Defaults",
+			Flattened: map[string]interface{}{"hello": "there"},
+			Expected:  map[string]interface{}{"hello": "there"},
+		},
+		{
+			Name:      "Default Removed",
+			Defaults:  map[string]string{"env": "prod"},
+			Flattened: map[string]interface{}{"env": "prod", "hello": "there"},
+			Expected:  map[string]interface{}{"hello": "there"},
+		},
+		{
+			Name:      "Default With Different Value Retained",
+			Defaults:  map[string]string{"env": "prod"},
+			Flattened: map[string]interface{}{"env": "dev"},
+			Expected:  map[string]interface{}{"env": "dev"},
+		},
+		{
+			Name:       "Default Explicitly Configured Retained",
+			Defaults:   map[string]string{"env": "prod"},
+			Flattened:  map[string]interface{}{"env": "prod"},
+			Configured: map[string]interface{}{"env": "prod"},
+			Expected:   map[string]interface{}{"env": "prod"},
+		},
+		{
+			Name:      "Default Removed Case Insensitively",
+			Defaults:  map[string]string{"Env": "prod"},
+			Flattened: map[string]interface{}{"env": "prod"},
+			Expected:  map[string]interface{}{},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual := RemoveDefaults(v.Defaults, v.Flattened, v.Configured)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
@@ -1,6 +1,14 @@
 package tags
 
+// Expand expands the Tags for a Resource into the format used by the Azure SDK, including
+// any Default Tags defined in the Provider block which haven't been configured on the Resource
 func Expand(tagsMap map[string]interface{}) map[string]*string {
+	return ExpandWithoutDefaults(MergeDefaults(Defaults(), tagsMap))
+}
+
+// ExpandWithoutDefaults expands a map of tags into the format used by the Azure SDK, without
+// including the Default Tags - for example when filtering by, or setting tags on a nested item
+func ExpandWithoutDefaults(tagsMap map[string]interface{}) map[string]*string {
 	output := make(map[string]*string, len(tagsMap))
 
 	for i, v := range tagsMap {
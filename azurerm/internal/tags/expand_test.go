@@ -31,3 +31,29 @@ func TestExpand(t *testing.T) {
 		}
 	}
 }
+
+func TestExpandWithDefaults(t *testing.T) {
+	Configure(map[string]string{"env": "prod", "owner": "team"}, IgnoreConfig{})
+	t.Cleanup(func() {
+		Configure(nil, IgnoreConfig{})
+	})
+
+	expanded := Expand(map[string]interface{}{"env": "dev", "hello": "there"})
+	expected := map[string]string{
+		"env":   "dev",
+		"hello": "there",
+		"owner": "team",
+	}
+	if len(expanded) != len(expected) {
+		t.Fatalf("Expected %d results in expanded tag map, got %d", len(expected), len(expanded))
+	}
+	for k, v := range expected {
+		if expanded[k] == nil || *expanded[k] != v {
+			t.Fatalf("Expanded value %q incorrect: expected %q, got %v", k, v, expanded[k])
+		}
+	}
+
+	if withoutDefaults := ExpandWithoutDefaults(map[string]interface{}{"hello": "there"}); len(withoutDefaults) != 1 {
+		t.Fatalf("Expected 1 result when expanding without the Default Tags, got %d", len(withoutDefaults))
+	}
+}
@@ -6,7 +6,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
+// Flatten flattens the Tags returned from Azure, removing any Tags which are ignored in the Provider block
 func Flatten(tagMap map[string]*string) map[string]interface{} {
+	return FlattenIncludingIgnored(RemoveIgnored(tagMap, Ignored()))
+}
+
+// FlattenIncludingIgnored flattens a map of tags without removing the Ignored Tags - for example
+// for `tags_all`, or for a map of values which aren't Tags (such as the Metadata of a DNS Zone)
+func FlattenIncludingIgnored(tagMap map[string]*string) map[string]interface{} {
 	// If tagsMap is nil, len(tagsMap) will be 0.
 	output := make(map[string]interface{}, len(tagMap))
 
@@ -21,9 +28,31 @@ func Flatten(tagMap map[string]*string) map[string]interface{} {
 	return output
 }
 
+// FlattenAndSet sets the Tags returned from Azure into the `tags` field. When the Resource exposes the
+// `tags_all` field these are set into that instead, with any Default Tags (which haven't been configured
+// on the Resource) removed from `tags`, so that these don't show up as a diff. Any Ignored Tags are always
+// removed from `tags`.
 func FlattenAndSet(d *schema.ResourceData, tagMap map[string]*string) error {
 	flattened := Flatten(tagMap)
-	if err := d.Set("tags", flattened); err != nil {
+
+	// Data Sources (and Resources which don't expose a user-configurable `tags` field) don't have `tags_all`
+	if _, ok := d.Get("tags_all").(map[string]interface{}); !ok {
+		if err := d.Set("tags", flattened); err != nil {
+			return fmt.Errorf("Error setting `tags`: %s", err)
+		}
+
+		return nil
+	}
+
+	// the Ignored Tags are retained in `tags_all`, so that these can be sent back to Azure unchanged when updating
+	if err := d.Set("tags_all", FlattenIncludingIgnored(tagMap)); err != nil {
+		return fmt.Errorf("Error setting `tags_all`: %s", err)
+	}
+
+	// prior to being set `tags` contains either the configuration or (when refreshing) the previous state
+	configured := d.Get("tags").(map[string]interface{})
+	userTags := RemoveDefaults(Defaults(), flattened, configured)
+	if err := d.Set("tags", userTags); err != nil {
 		return fmt.Errorf("Error setting `tags`: %s", err)
 	}
 
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
@@ -51,3 +52,112 @@ func TestFlatten(t *testing.T) {
 		}
 	}
 }
+
+func TestFlattenRemovesIgnored(t *testing.T) {
+	Configure(nil, IgnoreConfig{Keys: []string{"ignored"}, KeyPrefixes: []string{"hidden-link:"}})
+	t.Cleanup(func() {
+		Configure(nil, IgnoreConfig{})
+	})
+
+	input := map[string]*string{
+		"hello":             utils.String("there"),
+		"hidden-link:/path": utils.String("Resource"),
+		"ignored":           utils.String("value"),
+	}
+
+	expected := map[string]interface{}{
+		"hello": "there",
+	}
+	if actual := Flatten(input); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, actual)
+	}
+
+	expectedIncludingIgnored := map[string]interface{}{
+		"hello":             "there",
+		"hidden-link:/path": "Resource",
+		"ignored":           "value",
+	}
+	if actual := FlattenIncludingIgnored(input); !reflect.DeepEqual(actual, expectedIncludingIgnored) {
+		t.Fatalf("Expected %+v but got %+v", expectedIncludingIgnored, actual)
+	}
+}
+
+func TestFlattenAndSet(t *testing.T) {
+	Configure(map[string]string{"env": "prod", "owner": "team"}, IgnoreConfig{KeyPrefixes: []string{"hidden-link:"}})
+	t.Cleanup(func() {
+		Configure(nil, IgnoreConfig{})
+	})
+
+	remote := map[string]*string{
+		"env":               utils.String("prod"),
+		"hello":             utils.String("there"),
+		"hidden-link:/path": utils.String("Resource"),
+		"owner":             utils.String("someone-else"),
+	}
+
+	testData := []struct {
+		Name            string
+		Schema          map[string]*schema.Schema
+		Configured      map[string]interface{}
+		ExpectedTags    map[string]interface{}
+		ExpectedTagsAll map[string]interface{}
+	}{
+		{
+			Name: "Data Source",
+			Schema: map[string]*schema.Schema{
+				"tags": SchemaDataSource(),
+			},
+			ExpectedTags: map[string]interface{}{
+				"env":   "prod",
+				"hello": "there",
+				"owner": "someone-else",
+			},
+		},
+		{
+			Name: "Resource",
+			Schema: map[string]*schema.Schema{
+				"tags":     Schema(),
+				"tags_all": SchemaAll(),
+			},
+			Configured: map[string]interface{}{"hello": "there"},
+			ExpectedTags: map[string]interface{}{
+				"hello": "there",
+				"owner": "someone-else",
+			},
+			ExpectedTagsAll: FlattenIncludingIgnored(remote),
+		},
+		{
+			Name: "Resource Configuring a Default Tag",
+			Schema: map[string]*schema.Schema{
+				"tags":     ForceNewSchema(),
+				"tags_all": SchemaAll(),
+			},
+			Configured: map[string]interface{}{"env": "prod", "hello": "there"},
+			ExpectedTags: map[string]interface{}{
+				"env":   "prod",
+				"hello": "there",
+				"owner": "someone-else",
+			},
+			ExpectedTagsAll: FlattenIncludingIgnored(remote),
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		d := schema.TestResourceDataRaw(t, v.Schema, map[string]interface{}{"tags": v.Configured})
+		if err := FlattenAndSet(d, remote); err != nil {
+			t.Fatalf("Expected no error but got: %+v", err)
+		}
+
+		if actual := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(actual, v.ExpectedTags) {
+			t.Fatalf("Expected `tags` to be %+v but got %+v", v.ExpectedTags, actual)
+		}
+		if v.ExpectedTagsAll == nil {
+			continue
+		}
+		if actual := d.Get("tags_all").(map[string]interface{}); !reflect.DeepEqual(actual, v.ExpectedTagsAll) {
+			t.Fatalf("Expected `tags_all` to be %+v but got %+v", v.ExpectedTagsAll, actual)
+		}
+	}
+}
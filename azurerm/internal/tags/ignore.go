@@ -0,0 +1,61 @@
+package tags
+
+import "strings"
+
+// IgnoreConfig defines the tags which are managed outside of Terraform (for example by
+// Azure Policy) and which should therefore be ignored when reading/updating resources
+type IgnoreConfig struct {
+	// Keys is a list of tag keys which should be ignored
+	Keys []string
+
+	// KeyPrefixes is a list of prefixes, where any tag key starting with one of these should be ignored
+	KeyPrefixes []string
+}
+
+// Ignores returns whether the specified tag key should be ignored
+func (c IgnoreConfig) Ignores(key string) bool {
+	for _, k := range c.Keys {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+
+	for _, prefix := range c.KeyPrefixes {
+		if prefix != "" && strings.HasPrefix(strings.ToLower(key), strings.ToLower(prefix)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RemoveIgnored returns the tags which aren't ignored by the specified configuration
+func RemoveIgnored(tagsMap map[string]*string, config IgnoreConfig) map[string]*string {
+	filtered := Filter(tagsMap, config.Keys...)
+	if len(config.KeyPrefixes) == 0 {
+		return filtered
+	}
+
+	output := make(map[string]*string, len(filtered))
+	for k, v := range filtered {
+		if !config.Ignores(k) {
+			output[k] = v
+		}
+	}
+
+	return output
+}
+
+// OnlyIgnored returns the tags which are ignored by the specified configuration, so that
+// these can be sent back to Azure unchanged when updating a resource
+func OnlyIgnored(tagsMap map[string]interface{}, config IgnoreConfig) map[string]interface{} {
+	output := make(map[string]interface{})
+
+	for k, v := range tagsMap {
+		if config.Ignores(k) {
+			output[k] = v
+		}
+	}
+
+	return output
+}
@@ -0,0 +1,71 @@
+package tags
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestIgnoreConfigIgnores(t *testing.T) {
+	config := IgnoreConfig{
+		Keys:        []string{"CreatedOnDate"},
+		KeyPrefixes: []string{"hidden-link:"},
+	}
+
+	testData := map[string]bool{
+		"CreatedOnDate":                  true,
+		"createdondate":                  true,
+		"hidden-link:/app-insights":      true,
+		"Hidden-Link:/app-insights":      true,
+		"hidden-title":                   false,
+		"environment":                    false,
+		"CreatedOnDateWithSomethingElse": false,
+	}
+
+	for key, expected := range testData {
+		t.Logf("[DEBUG] Testing %q", key)
+
+		if actual := config.Ignores(key); actual != expected {
+			t.Fatalf("Expected %t for %q but got %t", expected, key, actual)
+		}
+	}
+}
+
+func TestRemoveIgnored(t *testing.T) {
+	config := IgnoreConfig{
+		Keys:        []string{"CreatedOnDate"},
+		KeyPrefixes: []string{"hidden-link:"},
+	}
+	input := map[string]*string{
+		"createdondate":             utils.String("2021-01-01"),
+		"hidden-link:/app-insights": utils.String("Resource"),
+		"environment":               utils.String("prod"),
+	}
+
+	actual := RemoveIgnored(input, config)
+	expected := map[string]*string{
+		"environment": utils.String("prod"),
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestOnlyIgnored(t *testing.T) {
+	config := IgnoreConfig{
+		KeyPrefixes: []string{"hidden-link:"},
+	}
+	input := map[string]interface{}{
+		"hidden-link:/app-insights": "Resource",
+		"environment":               "prod",
+	}
+
+	actual := OnlyIgnored(input, config)
+	expected := map[string]interface{}{
+		"hidden-link:/app-insights": "Resource",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, actual)
+	}
+}
@@ -0,0 +1,142 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// the headers returned by Azure which are recorded on the Span for each HTTP request, which are useful
+// to determine whether requests are being throttled and to correlate requests with Azure's logs
+var recordedResponseHeaders = []string{
+	"Retry-After",
+	"x-ms-correlation-request-id",
+	"x-ms-ratelimit-remaining-subscription-reads",
+	"x-ms-ratelimit-remaining-subscription-writes",
+	"x-ms-request-id",
+}
+
+// WithRequestSpans returns a SendDecorator which records a Span for each HTTP request (including each retry) as
+// a child of the Span within the request's context - when tracing isn't enabled this returns the Sender as-is
+func WithRequestSpans() autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		if activeTracer == nil {
+			return s
+		}
+
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			r, span := startRequestSpan(r)
+			resp, err := s.Do(r)
+			endRequestSpan(span, resp, err)
+			return resp, err
+		})
+	}
+}
+
+// requestSpanTransport records a Span for each HTTP request sent using the base Transport, which is
+// used by the Azure SDK for the Data Plane clients which don't otherwise use our Sender
+type requestSpanTransport struct {
+	base http.RoundTripper
+}
+
+func (t requestSpanTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r, span := startRequestSpan(r)
+	resp, err := t.base.RoundTrip(r)
+	endRequestSpan(span, resp, err)
+	return resp, err
+}
+
+func startRequestSpan(r *http.Request) (*http.Request, *Span) {
+	ctx, span := startSpan(r.Context(), fmt.Sprintf("HTTP %s", r.Method), trace.SpanKindClient)
+	if span == nil {
+		return r, nil
+	}
+
+	// the query string is omitted since this can contain secrets (such as SAS Tokens)
+	span.span.SetAttributes(
+		semconv.HTTPMethodKey.String(r.Method),
+		semconv.HTTPURLKey.String(fmt.Sprintf("%s://%s%s", r.URL.Scheme, r.URL.Host, r.URL.Path)),
+		semconv.NetPeerNameKey.String(r.URL.Hostname()),
+	)
+	if apiVersion := r.URL.Query().Get("api-version"); apiVersion != "" {
+		span.SetAttribute("azure.api_version", apiVersion)
+	}
+
+	// the Trace Context is sent to Azure, which allows requests to be correlated with the Span
+	r = r.WithContext(ctx)
+	r.Header = r.Header.Clone()
+	if r.Header == nil {
+		r.Header = http.Header{}
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(r.Header))
+
+	return r, span
+}
+
+func endRequestSpan(span *Span, resp *http.Response, err error) {
+	if span == nil {
+		return
+	}
+
+	if resp != nil {
+		span.span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(resp.StatusCode)...)
+		for _, header := range recordedResponseHeaders {
+			if v := resp.Header.Get(header); v != "" {
+				span.SetAttribute(fmt.Sprintf("http.response.header.%s", strings.ToLower(strings.ReplaceAll(header, "-", "_"))), v)
+			}
+		}
+
+		if err == nil {
+			span.span.SetStatus(semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(resp.StatusCode, trace.SpanKindClient))
+		}
+	}
+
+	span.End(err)
+}
+
+// autorestTracer records the Spans created by the Azure SDK, which creates a Span for each API operation
+// (for example `VirtualNetworksClient.Get`) and for each poll of a Long Running Operation
+type autorestTracer struct{}
+
+func (autorestTracer) NewTransport(base *http.Transport) http.RoundTripper {
+	return requestSpanTransport{
+		base: base,
+	}
+}
+
+func (autorestTracer) StartSpan(ctx context.Context, name string) context.Context {
+	ctx, _ = StartSpan(ctx, autorestSpanName(name))
+	return ctx
+}
+
+func (autorestTracer) EndSpan(ctx context.Context, httpStatusCode int, err error) {
+	span := SpanFromContext(ctx)
+	if span != nil && httpStatusCode > 0 {
+		span.span.SetAttributes(semconv.HTTPStatusCodeKey.Int(httpStatusCode))
+	}
+	span.End(err)
+}
+
+// autorestSpanName returns a shorter name for the Spans created by the Azure SDK, which are otherwise
+// prefixed with the full import path (for example `github.com/Azure/azure-sdk-for-go/services/...`)
+func autorestSpanName(name string) string {
+	switch {
+	case strings.HasSuffix(name, "/async.DoneWithContext"):
+		return "LongRunningOperation.Poll"
+	case strings.HasSuffix(name, "/async.WaitForCompletionRef"):
+		return "LongRunningOperation.Wait"
+	}
+
+	segments := strings.Split(name, "/")
+	if len(segments) > 2 {
+		return strings.Join(segments[len(segments)-2:], "/")
+	}
+	return name
+}
@@ -0,0 +1,239 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	autorestTracing "github.com/Azure/go-autorest/tracing"
+	"github.com/terraform-providers/terraform-provider-azurerm/version"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// EnvironmentVariable enables tracing when set to `otlp` (exporting Spans to an OTLP/HTTP endpoint, configured
+	// using the standard `OTEL_EXPORTER_OTLP_*` Environment Variables) or `file` (appending Spans to a local JSON file)
+	EnvironmentVariable = "ARM_PROVIDER_TRACING"
+
+	// FileEnvironmentVariable is the path to the JSON file which Spans are appended to when using the `file` exporter
+	FileEnvironmentVariable = "ARM_PROVIDER_TRACING_FILE"
+
+	// TraceParentEnvironmentVariable (and TraceStateEnvironmentVariable) optionally contain the W3C Trace Context of
+	// the process running Terraform (for example a CI pipeline), in which case Spans are recorded within that Trace
+	TraceParentEnvironmentVariable = "TRACEPARENT"
+	TraceStateEnvironmentVariable  = "TRACESTATE"
+
+	defaultFilePath = "terraform-provider-azurerm-traces.json"
+
+	instrumentationName = "github.com/terraform-providers/terraform-provider-azurerm"
+	serviceName         = "terraform-provider-azurerm"
+)
+
+var (
+	// activeTracer is the Tracer which Spans are recorded by, which is nil when tracing isn't enabled
+	activeTracer trace.Tracer
+
+	// tracerProvider exports the Spans recorded by activeTracer
+	tracerProvider *sdktrace.TracerProvider
+
+	// parentContext contains the Trace Context (if any) which Spans without a parent are recorded within
+	parentContext = context.Background()
+
+	configureOnce sync.Once
+)
+
+// ConfigureFromEnvironment enables tracing when the Environment Variable is set, which is only configured once
+// per process. When enabled the Azure SDK is also configured to record a Span for each API operation and each
+// poll of a Long Running Operation.
+func ConfigureFromEnvironment() error {
+	var err error
+	configureOnce.Do(func() {
+		var exporter sdktrace.SpanExporter
+		exporter, err = exporterFromEnvironment()
+		if err != nil || exporter == nil {
+			return
+		}
+
+		enable(sdktrace.NewBatchSpanProcessor(exporter))
+		parentContext = propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier{
+			"traceparent": os.Getenv(TraceParentEnvironmentVariable),
+			"tracestate":  os.Getenv(TraceStateEnvironmentVariable),
+		})
+		autorestTracing.Register(autorestTracer{})
+	})
+	return err
+}
+
+func exporterFromEnvironment() (sdktrace.SpanExporter, error) {
+	switch v := strings.ToLower(strings.TrimSpace(os.Getenv(EnvironmentVariable))); v {
+	case "":
+		return nil, nil
+
+	case "file":
+		path := os.Getenv(FileEnvironmentVariable)
+		if path == "" {
+			path = defaultFilePath
+		}
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("opening the file %q: %+v", path, err)
+		}
+
+		log.Printf("[DEBUG] Tracing is enabled - exporting Spans to the file %q", path)
+		return stdouttrace.New(stdouttrace.WithWriter(file))
+
+	case "otlp":
+		log.Printf("[DEBUG] Tracing is enabled - exporting Spans to the OTLP endpoint")
+		return otlptracehttp.New(context.Background())
+
+	default:
+		return nil, fmt.Errorf("`%s` must be either `otlp` or `file` but got %q", EnvironmentVariable, v)
+	}
+}
+
+func enable(processor sdktrace.SpanProcessor) {
+	tracerProvider = sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(serviceName),
+			semconv.ServiceVersionKey.String(version.ProviderVersion),
+		)),
+	)
+	activeTracer = tracerProvider.Tracer(instrumentationName, trace.WithInstrumentationVersion(version.ProviderVersion))
+
+	// the Trace Context is propagated to Azure using the `traceparent` header
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+// IsEnabled returns whether tracing is enabled
+func IsEnabled() bool {
+	return activeTracer != nil
+}
+
+// Shutdown exports any Spans which haven't yet been exported, and should be called prior to the process exiting
+func Shutdown() {
+	if tracerProvider == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := tracerProvider.Shutdown(ctx); err != nil {
+		log.Printf("[DEBUG] Unable to export the pending Spans: %+v", err)
+	}
+}
+
+type contextKey int
+
+const resourceOperationContextKey contextKey = iota
+
+// Span represents a single operation within a Trace, which must be ended by calling End. When tracing isn't
+// enabled Spans are nil, which can still be used (but aren't recorded) so that callers needn't check this.
+type Span struct {
+	span trace.Span
+}
+
+// StartSpan starts a Span as a child of the Span within the context (if any), returning a context containing the
+// new Span - which is nil when tracing isn't enabled
+func StartSpan(ctx context.Context, name string) (context.Context, *Span) {
+	return startSpan(ctx, name, trace.SpanKindInternal)
+}
+
+// StartResourceOperation starts a Span for an operation on a Resource (for example `create`), unless the context
+// is already within an operation on a Resource
+func StartResourceOperation(ctx context.Context, resourceType string, operation string) (context.Context, *Span) {
+	if activeTracer == nil || ctx.Value(resourceOperationContextKey) != nil {
+		return ctx, nil
+	}
+
+	ctx, span := StartSpan(ctx, fmt.Sprintf("%s.%s", resourceType, operation))
+	span.SetAttribute("azurerm.resource_type", resourceType)
+	span.SetAttribute("azurerm.operation", operation)
+
+	return context.WithValue(ctx, resourceOperationContextKey, true), span
+}
+
+// SpanFromContext returns the Span within the context, or nil if there isn't one
+func SpanFromContext(ctx context.Context) *Span {
+	if activeTracer == nil || ctx == nil {
+		return nil
+	}
+
+	span := trace.SpanFromContext(ctx)
+	if !span.SpanContext().IsValid() {
+		return nil
+	}
+	return &Span{
+		span: span,
+	}
+}
+
+func startSpan(ctx context.Context, name string, kind trace.SpanKind) (context.Context, *Span) {
+	if activeTracer == nil {
+		return ctx, nil
+	}
+
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		if parent := trace.SpanContextFromContext(parentContext); parent.IsValid() {
+			ctx = trace.ContextWithRemoteSpanContext(ctx, parent)
+		}
+	}
+
+	ctx, span := activeTracer.Start(ctx, name, trace.WithSpanKind(kind))
+	return ctx, &Span{
+		span: span,
+	}
+}
+
+// SetAttribute sets the attribute on this Span, which can be a string, bool, integer or float
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+
+	s.span.SetAttributes(attributeFor(key, value))
+}
+
+// End ends this Span, marking it as failed when an error is specified - Spans can only be ended once
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
+
+func attributeFor(key string, value interface{}) attribute.KeyValue {
+	switch v := value.(type) {
+	case string:
+		return attribute.String(key, v)
+	case bool:
+		return attribute.Bool(key, v)
+	case int:
+		return attribute.Int(key, v)
+	case int64:
+		return attribute.Int64(key, v)
+	case float64:
+		return attribute.Float64(key, v)
+	default:
+		return attribute.String(key, fmt.Sprintf("%v", v))
+	}
+}
@@ -0,0 +1,220 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// enableTestTracer enables tracing for the duration of the test, returning the exporter which Spans are exported to
+func enableTestTracer(t *testing.T) *tracetest.InMemoryExporter {
+	exp := tracetest.NewInMemoryExporter()
+	enable(sdktrace.NewSimpleSpanProcessor(exp))
+	t.Cleanup(func() {
+		Shutdown()
+		activeTracer = nil
+		tracerProvider = nil
+		parentContext = context.Background()
+	})
+	return exp
+}
+
+func attributeValue(span tracetest.SpanStub, key string) string {
+	for _, attr := range span.Attributes {
+		if attr.Key == attribute.Key(key) {
+			return attr.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func TestSpansDisabled(t *testing.T) {
+	ctx, span := StartSpan(context.Background(), "example")
+	if span != nil {
+		t.Fatalf("expected no Span when tracing is disabled but got %+v", span)
+	}
+	if SpanFromContext(ctx) != nil {
+		t.Fatalf("expected no Span within the context when tracing is disabled")
+	}
+
+	// these must be safe to call on a nil Span
+	span.SetAttribute("key", "value")
+	span.End(nil)
+
+	if _, span := StartResourceOperation(context.Background(), "azurerm_example", "create"); span != nil {
+		t.Fatalf("expected no Span for a Resource Operation when tracing is disabled")
+	}
+}
+
+func TestSpansParentChild(t *testing.T) {
+	exp := enableTestTracer(t)
+
+	ctx, root := StartResourceOperation(context.Background(), "azurerm_example", "create")
+	if root == nil {
+		t.Fatalf("expected a Span for the Resource Operation")
+	}
+
+	// a nested Resource Operation shouldn't create a Span
+	nestedCtx, nested := StartResourceOperation(ctx, "azurerm_example", "read")
+	if nested != nil {
+		t.Fatalf("expected no Span for a nested Resource Operation")
+	}
+
+	_, child := StartSpan(nestedCtx, "child")
+	child.End(fmt.Errorf("boom"))
+	root.End(nil)
+
+	spans := exp.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 Spans but got %d", len(spans))
+	}
+
+	childSpan, rootSpan := spans[0], spans[1]
+	if rootSpan.Name != "azurerm_example.create" {
+		t.Fatalf("expected the root Span to be named %q but got %q", "azurerm_example.create", rootSpan.Name)
+	}
+	if rootSpan.Parent.IsValid() {
+		t.Fatalf("expected the root Span to have no parent but got %q", rootSpan.Parent.SpanID())
+	}
+	if v := attributeValue(rootSpan, "azurerm.resource_type"); v != "azurerm_example" {
+		t.Fatalf("expected the Resource Type to be %q but got %q", "azurerm_example", v)
+	}
+	if childSpan.SpanContext.TraceID() != rootSpan.SpanContext.TraceID() {
+		t.Fatalf("expected the child Span to be within Trace %q but got %q", rootSpan.SpanContext.TraceID(), childSpan.SpanContext.TraceID())
+	}
+	if childSpan.Parent.SpanID() != rootSpan.SpanContext.SpanID() {
+		t.Fatalf("expected the child Span's parent to be %q but got %q", rootSpan.SpanContext.SpanID(), childSpan.Parent.SpanID())
+	}
+	if childSpan.Status.Code != codes.Error || childSpan.Status.Description != "boom" {
+		t.Fatalf("expected the child Span to have failed with %q but got %+v", "boom", childSpan.Status)
+	}
+}
+
+func TestSpansWithinParentTraceContext(t *testing.T) {
+	exp := enableTestTracer(t)
+	parentContext = propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier{
+		"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+	})
+
+	_, span := StartResourceOperation(context.Background(), "azurerm_example", "create")
+	span.End(nil)
+
+	spans := exp.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 Span but got %d", len(spans))
+	}
+	if v := spans[0].SpanContext.TraceID().String(); v != "0af7651916cd43dd8448eb211c80319c" {
+		t.Fatalf("expected the Span to be within the Trace from the Environment but got %q", v)
+	}
+	if v := spans[0].Parent.SpanID().String(); v != "b7ad6b7169203331" {
+		t.Fatalf("expected the Span's parent to be the Span from the Environment but got %q", v)
+	}
+}
+
+func TestWithRequestSpans(t *testing.T) {
+	var traceParent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceParent = r.Header.Get("traceparent")
+		w.Header().Set("x-ms-request-id", "abc123")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	exp := enableTestTracer(t)
+
+	ctx, root := StartSpan(context.Background(), "root")
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/example?api-version=2020-01-01&sig=secret", nil)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+
+	resp, err := autorest.DecorateSender(http.DefaultClient, WithRequestSpans()).Do(req.WithContext(ctx))
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	resp.Body.Close()
+	root.End(nil)
+
+	spans := exp.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 Spans but got %d", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name != "HTTP GET" {
+		t.Fatalf("expected the Span to be named %q but got %q", "HTTP GET", span.Name)
+	}
+	if span.SpanKind != trace.SpanKindClient {
+		t.Fatalf("expected the Span to be a Client Span but got %s", span.SpanKind)
+	}
+	if span.Parent.SpanID() != spans[1].SpanContext.SpanID() {
+		t.Fatalf("expected the Span's parent to be %q but got %q", spans[1].SpanContext.SpanID(), span.Parent.SpanID())
+	}
+	if v := attributeValue(span, "http.url"); strings.Contains(v, "secret") || !strings.HasSuffix(v, "/example") {
+		t.Fatalf("expected the URL to omit the query string but got %q", v)
+	}
+	if v := attributeValue(span, "azure.api_version"); v != "2020-01-01" {
+		t.Fatalf("expected the API Version to be %q but got %q", "2020-01-01", v)
+	}
+	if v := attributeValue(span, "http.status_code"); v != "404" {
+		t.Fatalf("expected the Status Code to be %q but got %q", "404", v)
+	}
+	if v := attributeValue(span, "http.response.header.x_ms_request_id"); v != "abc123" {
+		t.Fatalf("expected the Request ID to be %q but got %q", "abc123", v)
+	}
+	if span.Status.Code != codes.Error {
+		t.Fatalf("expected the Span to have failed but got %+v", span.Status)
+	}
+
+	expectedTraceParent := fmt.Sprintf("00-%s-%s-01", span.SpanContext.TraceID(), span.SpanContext.SpanID())
+	if traceParent != expectedTraceParent {
+		t.Fatalf("expected the `traceparent` header to be %q but got %q", expectedTraceParent, traceParent)
+	}
+	if req.Header.Get("traceparent") != "" {
+		t.Fatalf("expected the original request not to be modified")
+	}
+}
+
+func TestExporterFromEnvironment(t *testing.T) {
+	t.Setenv(EnvironmentVariable, "")
+	if exp, err := exporterFromEnvironment(); err != nil || exp != nil {
+		t.Fatalf("expected no exporter when tracing is disabled but got %+v / %+v", exp, err)
+	}
+
+	t.Setenv(EnvironmentVariable, "file")
+	t.Setenv(FileEnvironmentVariable, t.TempDir()+"/traces.json")
+	if exp, err := exporterFromEnvironment(); err != nil || exp == nil {
+		t.Fatalf("expected a file exporter but got %+v / %+v", exp, err)
+	}
+
+	t.Setenv(EnvironmentVariable, "zipkin")
+	if _, err := exporterFromEnvironment(); err == nil {
+		t.Fatalf("expected an error for an unsupported exporter")
+	}
+}
+
+func TestAutorestSpanName(t *testing.T) {
+	testData := map[string]string{
+		"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-05-01/network.VirtualNetworksClient.Get": "2020-05-01/network.VirtualNetworksClient.Get",
+		"github.com/Azure/go-autorest/autorest/azure/async.DoneWithContext":                                    "LongRunningOperation.Poll",
+		"github.com/Azure/go-autorest/autorest/azure/async.WaitForCompletionRef":                               "LongRunningOperation.Wait",
+		"example": "example",
+	}
+
+	for input, expected := range testData {
+		if actual := autorestSpanName(input); actual != expected {
+			t.Fatalf("expected %q to be %q but got %q", input, expected, actual)
+		}
+	}
+}
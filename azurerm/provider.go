@@ -3,8 +3,14 @@ package azurerm
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/provider"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tracing"
 )
 
 func Provider() terraform.ResourceProvider {
 	return provider.AzureProvider()
 }
+
+// Shutdown exports any pending Spans when tracing is enabled, and should be called once the Provider has been served
+func Shutdown() {
+	tracing.Shutdown()
+}
@@ -14,4 +14,6 @@ func main() {
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: azurerm.Provider,
 	})
+
+	azurerm.Shutdown()
 }